@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/workspace"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+)
+
+var generateCatalogCmd = &cobra.Command{
+	Use:   "generate-catalog",
+	Short: "Generate a JSON catalog of toolsets and tools",
+	Long:  `Generate a machine-readable JSON catalog of every toolset and tool, including the dynamic toolset. The catalog is described by the JSON Schema in docs/tool-catalog.schema.json.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		catalog := generateCatalog()
+
+		data, err := json.MarshalIndent(catalog, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal catalog: %w", err)
+		}
+		data = append(data, '\n')
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(output, data, 0600); err != nil {
+			return fmt.Errorf("failed to write catalog: %w", err)
+		}
+		fmt.Printf("Successfully wrote tool catalog to %s\n", output)
+		return nil
+	},
+}
+
+func init() {
+	generateCatalogCmd.Flags().String("output", "", "File to write the catalog to, defaults to stdout")
+	rootCmd.AddCommand(generateCatalogCmd)
+}
+
+type (
+	// Catalog describes every toolset the server offers, see docs/tool-catalog.schema.json
+	Catalog struct {
+		Version  string           `json:"version"`
+		Toolsets []CatalogToolset `json:"toolsets"`
+	}
+
+	// CatalogToolset describes a toolset and its tools
+	CatalogToolset struct {
+		Name        string        `json:"name"`
+		Description string        `json:"description"`
+		Dynamic     bool          `json:"dynamic"`
+		Tools       []CatalogTool `json:"tools"`
+	}
+
+	// CatalogTool describes a single tool
+	CatalogTool struct {
+		Name            string              `json:"name"`
+		Title           string              `json:"title"`
+		Description     string              `json:"description"`
+		ReadOnly        bool                `json:"readOnly"`
+		Annotations     mcp.ToolAnnotation  `json:"annotations"`
+		InputSchema     mcp.ToolInputSchema `json:"inputSchema"`
+		RequiredScopes  []string            `json:"requiredScopes"`
+		TranslationKeys map[string]string   `json:"translationKeys"`
+	}
+)
+
+// generateCatalog builds the catalog from DefaultToolsetGroup plus the dynamic toolset
+func generateCatalog() Catalog {
+	tsg, dynamic := buildToolsets(translations.NullTranslationHelper)
+	translationKeys := buildToolTranslationKeys()
+
+	toolsetList := make([]*toolsets.Toolset, 0, len(tsg.Toolsets)+1)
+	for _, toolset := range tsg.Toolsets {
+		toolsetList = append(toolsetList, toolset)
+	}
+	sort.Slice(toolsetList, func(i, j int) bool {
+		return toolsetList[i].Name < toolsetList[j].Name
+	})
+	toolsetList = append(toolsetList, dynamic)
+
+	catalog := Catalog{Version: version, Toolsets: []CatalogToolset{}}
+	for _, toolset := range toolsetList {
+		catalogToolset := CatalogToolset{
+			Name:        toolset.Name,
+			Description: toolset.Description,
+			Dynamic:     toolset == dynamic,
+			Tools:       []CatalogTool{},
+		}
+
+		tools := toolset.GetAvailableTools()
+		sort.Slice(tools, func(i, j int) bool {
+			return tools[i].Tool.Name < tools[j].Tool.Name
+		})
+		for _, serverTool := range tools {
+			tool := serverTool.Tool
+			scopes, ok := github.RequiredToolScopes(toolset.Name, tool.Name)
+			if !ok {
+				scopes = []string{}
+			}
+			keys := translationKeys[tool.Name]
+			if keys == nil {
+				keys = map[string]string{}
+			}
+			catalogToolset.Tools = append(catalogToolset.Tools, CatalogTool{
+				Name:            tool.Name,
+				Title:           tool.Annotations.Title,
+				Description:     tool.Description,
+				ReadOnly:        tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint,
+				Annotations:     tool.Annotations,
+				InputSchema:     tool.InputSchema,
+				RequiredScopes:  scopes,
+				TranslationKeys: keys,
+			})
+		}
+
+		catalog.Toolsets = append(catalog.Toolsets, catalogToolset)
+	}
+
+	return catalog
+}
+
+// translationKeyMarker matches the markers buildToolTranslationKeys has t return in place of the text of a key
+var translationKeyMarker = regexp.MustCompile(`\x00([^\x00]+)\x00`)
+
+// buildToolTranslationKeys builds every toolset with a t that returns a marker naming the requested key instead
+// of its text, and reads the keys of the description and title of each tool back from the markers
+func buildToolTranslationKeys() map[string]map[string]string {
+	t := func(key string, _ string) string {
+		return "\x00" + strings.ToUpper(key) + "\x00"
+	}
+
+	keys := map[string]map[string]string{}
+	record := func(tool mcp.Tool) {
+		toolKeys := map[string]string{}
+		if m := translationKeyMarker.FindStringSubmatch(tool.Description); m != nil {
+			toolKeys["description"] = m[1]
+		}
+		if m := translationKeyMarker.FindStringSubmatch(tool.Annotations.Title); m != nil {
+			toolKeys["title"] = m[1]
+		}
+		keys[tool.Name] = toolKeys
+	}
+
+	tsg, dynamic := buildToolsets(t)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			record(tool.Tool)
+		}
+	}
+	for _, tool := range dynamic.GetAvailableTools() {
+		record(tool.Tool)
+	}
+	return keys
+}
+
+// buildToolsetsRecordingTranslations builds every toolset, including the dynamic one, with the default
+// translations and records the translation keys requested along with the text they produced
+func buildToolsetsRecordingTranslations() (*toolsets.ToolsetGroup, *toolsets.Toolset, map[string]string) {
+	translationValues := map[string]string{}
+	t := func(key string, defaultValue string) string {
+		value := translations.NullTranslationHelper(key, defaultValue)
+		translationValues[strings.ToUpper(key)] = value
+		return value
+	}
+
+	tsg, dynamic := buildToolsets(t)
+	return tsg, dynamic, translationValues
+}
+
+// buildToolsets builds every toolset, including the dynamic one, with mock clients, which requests
+// every translation key of the tools from t
+func buildToolsets(t translations.TranslationHelperFunc) (*toolsets.ToolsetGroup, *toolsets.Toolset) {
+	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, workspace.NewManager(workspace.DefaultLimits), t)
+	dynamic := github.InitDynamicToolset(server.NewMCPServer("github-mcp-server", version), tsg, t)
+	return tsg, dynamic
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_generateCatalog(t *testing.T) {
+	catalog := generateCatalog()
+
+	require.NotEmpty(t, catalog.Toolsets)
+	last := catalog.Toolsets[len(catalog.Toolsets)-1]
+	assert.Equal(t, "dynamic", last.Name)
+	assert.True(t, last.Dynamic)
+
+	toolNames := map[string]bool{}
+	for _, toolset := range catalog.Toolsets {
+		assert.NotNil(t, toolset.Tools, toolset.Name)
+		for _, tool := range toolset.Tools {
+			assert.False(t, toolNames[tool.Name], "duplicate tool %s", tool.Name)
+			toolNames[tool.Name] = true
+
+			assert.NotEmpty(t, tool.Title, tool.Name)
+			assert.NotNil(t, tool.RequiredScopes, tool.Name)
+			assert.Regexp(t, `^TOOL_[A-Z_]+_DESCRIPTION$`, tool.TranslationKeys["description"], tool.Name)
+			assert.Regexp(t, `^TOOL_[A-Z_]+_USER_TITLE$`, tool.TranslationKeys["title"], tool.Name)
+		}
+	}
+
+	// Spot check a read and a write tool
+	assert.True(t, toolNames["get_me"])
+	for _, toolset := range catalog.Toolsets {
+		for _, tool := range toolset.Tools {
+			switch tool.Name {
+			case "get_file_contents":
+				assert.True(t, tool.ReadOnly)
+				assert.Equal(t, []string{"repo"}, tool.RequiredScopes)
+				assert.Equal(t, "TOOL_GET_FILE_CONTENTS_DESCRIPTION", tool.TranslationKeys["description"])
+			case "create_issue":
+				assert.False(t, tool.ReadOnly)
+				assert.Equal(t, "TOOL_CREATE_ISSUE_USER_TITLE", tool.TranslationKeys["title"])
+			case "set_team_permission":
+				assert.Equal(t, []string{"repo", "admin:org"}, tool.RequiredScopes)
+				assert.Equal(t, "TOOL_SET_TEAM_PERMISSION_DESCRIPTION", tool.TranslationKeys["description"])
+			}
+		}
+	}
+}
+
+func Test_catalogSchema(t *testing.T) {
+	data, err := os.ReadFile("../../docs/tool-catalog.schema.json")
+	require.NoError(t, err)
+
+	var schema struct {
+		Required []string `json:"required"`
+		Defs     map[string]struct {
+			Required []string `json:"required"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	// The fields required by the schema are the ones the catalog emits
+	catalogJSON, err := json.Marshal(generateCatalog())
+	require.NoError(t, err)
+	var catalog struct {
+		Toolsets []map[string]json.RawMessage `json:"toolsets"`
+	}
+	require.NoError(t, json.Unmarshal(catalogJSON, &catalog))
+	var top map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(catalogJSON, &top))
+
+	assert.ElementsMatch(t, schema.Required, keys(top))
+	assert.ElementsMatch(t, schema.Defs["toolset"].Required, keys(catalog.Toolsets[0]))
+
+	var tools []map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(catalog.Toolsets[0]["tools"], &tools))
+	require.NotEmpty(t, tools)
+	assert.ElementsMatch(t, schema.Defs["tool"].Required, keys(tools[0]))
+}
+
+func keys(m map[string]json.RawMessage) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -45,16 +46,26 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			// Same as for toolsets, GITHUB_LOCALE may hold a comma separated list
+			var locales []string
+			if err := viper.UnmarshalKey("locale", &locales); err != nil {
+				return fmt.Errorf("failed to unmarshal locale: %w", err)
+			}
+
 			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
+				Version:                version,
+				Host:                   viper.GetString("host"),
+				Token:                  token,
+				EnabledToolsets:        enabledToolsets,
+				DynamicToolsets:        viper.GetBool("dynamic_toolsets"),
+				ReadOnly:               viper.GetBool("read-only"),
+				ExportTranslations:     viper.GetBool("export-translations"),
+				Locales:                locales,
+				LocalesDir:             viper.GetString("translations-dir"),
+				TranslationsConfigPath: viper.GetString("translations-config"),
+				ResourcePollInterval:   viper.GetDuration("resource-poll-interval"),
+				EnableCommandLogging:   viper.GetBool("enable-command-logging"),
+				LogFilePath:            viper.GetString("log-file"),
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
@@ -75,6 +86,10 @@ func init() {
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
+	rootCmd.PersistentFlags().StringSlice("locale", nil, "An optional comma separated list of locales to translate tool descriptions to, in order of preference (e.g. pt-BR,es)")
+	rootCmd.PersistentFlags().String("translations-dir", "", "Directory of locale files, such as ja.json, overriding the bundled translations key by key")
+	rootCmd.PersistentFlags().String("translations-config", translations.DefaultConfigPath, "JSON file holding translation overrides, also used by --export-translations")
+	rootCmd.PersistentFlags().Duration("resource-poll-interval", github.DefaultResourcePollInterval, "How often the refs of subscribed resources are polled for changes, 0 disables resource subscriptions")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
@@ -84,6 +99,10 @@ func init() {
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("locale", rootCmd.PersistentFlags().Lookup("locale"))
+	_ = viper.BindPFlag("translations-dir", rootCmd.PersistentFlags().Lookup("translations-dir"))
+	_ = viper.BindPFlag("translations-config", rootCmd.PersistentFlags().Lookup("translations-config"))
+	_ = viper.BindPFlag("resource-poll-interval", rootCmd.PersistentFlags().Lookup("resource-poll-interval"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	translationsCmd = &cobra.Command{
+		Use:   "translations",
+		Short: "Manage translations of tool descriptions",
+		Long:  `Manage the locale files used to translate tool descriptions and titles.`,
+	}
+
+	translationsValidateCmd = &cobra.Command{
+		Use:          "validate",
+		Short:        "Validate locale files",
+		SilenceUsage: true,
+		Long: `Validate the bundled locale files, or those of --translations-dir, against the keys the tools actually request.
+Unknown keys, which are never requested, are errors. Missing keys fall back to the next locale in the chain
+and eventually to the default text, so they are only errors with --strict.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var locales []string
+			if err := viper.UnmarshalKey("locale", &locales); err != nil {
+				return fmt.Errorf("failed to unmarshal locale: %w", err)
+			}
+			strict, _ := cmd.Flags().GetBool("strict")
+
+			_, _, requested := buildToolsetsRecordingTranslations()
+			return validateTranslations(os.Stdout, viper.GetString("translations-dir"), locales, requested, strict)
+		},
+	}
+
+	exportTranslationsCmd = &cobra.Command{
+		Use:          "export-translations",
+		Short:        "Export translations to a JSON file",
+		SilenceUsage: true,
+		Long: `Export the translations of every tool, including the dynamic toolset, without starting a server.
+Overrides in the translations config file and GITHUB_MCP_* environment variables are preserved. As the
+config file overrides every locale, exporting fails if a locale is selected. Unless --output is given, the
+translations config file is updated in place.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var locales []string
+			if err := viper.UnmarshalKey("locale", &locales); err != nil {
+				return fmt.Errorf("failed to unmarshal locale: %w", err)
+			}
+
+			opts := translations.Options{
+				ConfigPath: viper.GetString("translations-config"),
+				LocalesDir: viper.GetString("translations-dir"),
+				Locales:    locales,
+			}
+			opts.OutputPath, _ = cmd.Flags().GetString("output")
+
+			output, err := exportTranslations(opts)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Successfully exported translations to %s\n", output)
+			return nil
+		},
+	}
+)
+
+func init() {
+	translationsValidateCmd.Flags().Bool("strict", false, "Treat missing keys as errors")
+	exportTranslationsCmd.Flags().String("output", "", "File to export the translations to, defaults to the translations config file")
+
+	translationsCmd.AddCommand(translationsValidateCmd)
+	rootCmd.AddCommand(translationsCmd)
+	rootCmd.AddCommand(exportTranslationsCmd)
+}
+
+// exportTranslations requests every translation key of the tools and writes them to the output path of opts,
+// which is returned
+func exportTranslations(opts translations.Options) (string, error) {
+	t, dump, err := translations.NewTranslationHelper(opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to load translations: %w", err)
+	}
+	buildToolsets(t)
+
+	output := opts.OutputPath
+	if output == "" {
+		output = opts.ConfigPath
+	}
+	if output == "" {
+		output = translations.DefaultConfigPath
+	}
+	if err := dump(); err != nil {
+		return "", fmt.Errorf("failed to export translations: %w", err)
+	}
+	return output, nil
+}
+
+// validateTranslations reports the missing and unknown keys of the locale files in dir, or of the bundled ones
+// if dir is empty, limited to the given locales if any, compared to the requested keys.
+func validateTranslations(w io.Writer, dir string, locales []string, requested map[string]string, strict bool) error {
+	location := dir
+	var available map[string]*translations.Locale
+	var err error
+	if dir == "" {
+		location = "the bundled locales"
+		available, err = translations.BundledLocales()
+	} else {
+		available, err = translations.LoadLocales(dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	names := translations.LocaleNames(available)
+	if len(locales) > 0 {
+		names = nil
+		for _, locale := range translations.LocaleFallbackChain(locales) {
+			if l, ok := available[strings.ToLower(locale)]; ok {
+				names = append(names, l.Name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no locale files to validate in %s", location)
+	}
+
+	requestedKeys := make([]string, 0, len(requested))
+	for key := range requested {
+		requestedKeys = append(requestedKeys, key)
+	}
+	sort.Strings(requestedKeys)
+
+	problems := 0
+	for _, name := range names {
+		locale := available[strings.ToLower(name)]
+
+		var missing, unknown []string
+		for _, key := range requestedKeys {
+			if _, ok := locale.Translations[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		for key := range locale.Translations {
+			if _, ok := requested[key]; !ok {
+				unknown = append(unknown, key)
+			}
+		}
+		sort.Strings(unknown)
+
+		_, _ = fmt.Fprintf(w, "%s (%s): %d of %d keys translated, %d missing, %d unknown\n",
+			locale.Name, locale.Path, len(requestedKeys)-len(missing), len(requestedKeys), len(missing), len(unknown))
+		for _, key := range unknown {
+			_, _ = fmt.Fprintf(w, "  unknown: %s\n", key)
+		}
+		for _, key := range missing {
+			_, _ = fmt.Fprintf(w, "  missing: %s\n", key)
+		}
+
+		problems += len(unknown)
+		if strict {
+			problems += len(missing)
+		}
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("found %d problems in locale files", problems)
+	}
+	return nil
+}
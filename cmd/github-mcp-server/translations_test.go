@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateTranslations(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ja.json"), []byte(`{"TOOL_A_DESCRIPTION": "A", "TOOL_B_DESCRIPTION": "B"}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pt-BR.json"), []byte(`{"TOOL_A_DESCRIPTION": "A", "TOOL_OLD_DESCRIPTION": "old"}`), 0600))
+
+	requested := map[string]string{"TOOL_A_DESCRIPTION": "Tool A", "TOOL_B_DESCRIPTION": "Tool B"}
+
+	var out bytes.Buffer
+	require.NoError(t, validateTranslations(&out, dir, []string{"ja"}, requested, true))
+	assert.Contains(t, out.String(), "ja ("+filepath.Join(dir, "ja.json")+"): 2 of 2 keys translated, 0 missing, 0 unknown")
+
+	// Missing keys are only errors when strict
+	out.Reset()
+	err := validateTranslations(&out, dir, []string{"pt-BR"}, requested, false)
+	assert.EqualError(t, err, "found 1 problems in locale files")
+	assert.Contains(t, out.String(), "  unknown: TOOL_OLD_DESCRIPTION\n")
+	assert.Contains(t, out.String(), "  missing: TOOL_B_DESCRIPTION\n")
+
+	out.Reset()
+	err = validateTranslations(&out, dir, nil, requested, true)
+	assert.EqualError(t, err, "found 2 problems in locale files")
+
+	err = validateTranslations(&out, dir, []string{"de"}, requested, false)
+	assert.EqualError(t, err, "no locale files to validate in "+dir)
+}
+
+func Test_shippedTranslations(t *testing.T) {
+	_, _, requested := buildToolsetsRecordingTranslations()
+
+	var out bytes.Buffer
+	assert.NoError(t, validateTranslations(&out, "", nil, requested, false), out.String())
+}
+
+func Test_exportTranslations(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"TOOL_GET_ME_DESCRIPTION": "who am I"}`), 0600))
+
+	// Exported locale text would override every locale, so the config file is left alone
+	_, err := exportTranslations(translations.Options{ConfigPath: configPath, Locales: []string{"ja"}})
+	assert.ErrorContains(t, err, "cannot export translations with the locale ja selected")
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"TOOL_GET_ME_DESCRIPTION": "who am I"}`, string(data))
+
+	output, err := exportTranslations(translations.Options{ConfigPath: configPath})
+	require.NoError(t, err)
+	assert.Equal(t, configPath, output)
+
+	data, err = os.ReadFile(configPath)
+	require.NoError(t, err)
+	var exported map[string]string
+	require.NoError(t, json.Unmarshal(data, &exported))
+
+	// Overrides are preserved, the English text is exported and every requested key is included
+	assert.Equal(t, "who am I", exported["TOOL_GET_ME_DESCRIPTION"])
+	assert.Equal(t, "Get my user profile", exported["TOOL_GET_ME_USER_TITLE"])
+	_, _, requested := buildToolsetsRecordingTranslations()
+	assert.Len(t, exported, len(requested))
+
+	outputPath := filepath.Join(dir, "export.json")
+	output, err = exportTranslations(translations.Options{ConfigPath: configPath, OutputPath: outputPath})
+	require.NoError(t, err)
+	assert.Equal(t, outputPath, output)
+	assert.FileExists(t, outputPath)
+}
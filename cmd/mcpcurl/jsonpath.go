@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPathSegment is a single step of a JSONPath expression. Exactly one of
+// the fields is used: a member name, an array index, or a wildcard.
+type jsonPathSegment struct {
+	name     string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// evaluateJSONPath evaluates the subset of JSONPath needed by scenarios against a
+// JSON decoded value: the root $, members as .name or ['name'], array indexes as
+// [0] or [-1] counting from the end, and wildcards as .* or [*].
+//
+// A path without wildcards yields the single value it selects. A path with
+// wildcards yields the list of every value it selects.
+func evaluateJSONPath(path string, value any) (any, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []any{value}
+	hasWildcard := false
+	for _, segment := range segments {
+		var next []any
+		for _, node := range nodes {
+			selected, err := segment.apply(node)
+			if err != nil {
+				if !hasWildcard {
+					return nil, fmt.Errorf("%s: %w", path, err)
+				}
+				// Below a wildcard, results only contain the nodes that match
+				continue
+			}
+			next = append(next, selected...)
+		}
+		hasWildcard = hasWildcard || segment.wildcard
+		nodes = next
+	}
+
+	if hasWildcard {
+		if nodes == nil {
+			return []any{}, nil
+		}
+		return nodes, nil
+	}
+	return nodes[0], nil
+}
+
+func (s jsonPathSegment) apply(node any) ([]any, error) {
+	switch {
+	case s.wildcard:
+		switch v := node.(type) {
+		case []any:
+			return v, nil
+		case map[string]any:
+			// Visit members in a stable order
+			names := make([]string, 0, len(v))
+			for name := range v {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			values := make([]any, 0, len(v))
+			for _, name := range names {
+				values = append(values, v[name])
+			}
+			return values, nil
+		}
+		return nil, fmt.Errorf("cannot use * on %s", typeName(node))
+	case s.isIndex:
+		array, ok := node.([]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot index %s", typeName(node))
+		}
+		index := s.index
+		if index < 0 {
+			index += len(array)
+		}
+		if index < 0 || index >= len(array) {
+			return nil, fmt.Errorf("index %d out of range for array of length %d", s.index, len(array))
+		}
+		return []any{array[index]}, nil
+	default:
+		object, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot select %q from %s", s.name, typeName(node))
+		}
+		member, ok := object[s.name]
+		if !ok {
+			return nil, fmt.Errorf("no member %q", s.name)
+		}
+		return []any{member}, nil
+	}
+}
+
+// parseJSONPath splits a JSONPath expression into its segments
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("JSONPath %q must start with $", path)
+	}
+
+	var segments []jsonPathSegment
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			if name == "" {
+				return nil, fmt.Errorf("JSONPath %q has an empty member name", path)
+			}
+			if name == "*" {
+				segments = append(segments, jsonPathSegment{wildcard: true})
+			} else {
+				segments = append(segments, jsonPathSegment{name: name})
+			}
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("JSONPath %q has an unterminated [", path)
+			}
+			selector := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+
+			switch {
+			case selector == "*":
+				segments = append(segments, jsonPathSegment{wildcard: true})
+			case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0]:
+				segments = append(segments, jsonPathSegment{name: selector[1 : len(selector)-1]})
+			default:
+				index, err := strconv.Atoi(selector)
+				if err != nil {
+					return nil, fmt.Errorf("JSONPath %q has an invalid selector [%s]", path, selector)
+				}
+				segments = append(segments, jsonPathSegment{index: index, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("JSONPath %q has an unexpected character %q", path, rest[0])
+		}
+	}
+	return segments, nil
+}
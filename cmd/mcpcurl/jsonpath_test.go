@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_evaluateJSONPath(t *testing.T) {
+	var document any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"login": "octocat",
+		"repos": [
+			{"name": "hello-world", "owner": {"login": "octocat"}},
+			{"name": "spoon-knife", "owner": {"login": "octo-org"}, "fork": true}
+		],
+		"weird key": 1
+	}`), &document))
+
+	tests := []struct {
+		name          string
+		path          string
+		expected      any
+		expectedError string
+	}{
+		{name: "root", path: "$", expected: document},
+		{name: "member", path: "$.login", expected: "octocat"},
+		{name: "nested index", path: "$.repos[1].owner.login", expected: "octo-org"},
+		{name: "negative index", path: "$.repos[-1].name", expected: "spoon-knife"},
+		{name: "bracket member", path: "$['weird key']", expected: float64(1)},
+		{name: "array wildcard", path: "$.repos[*].name", expected: []any{"hello-world", "spoon-knife"}},
+		{name: "wildcard skips missing members", path: "$.repos.*.fork", expected: []any{true}},
+		{name: "wildcard without matches", path: "$.repos[*].missing", expected: []any{}},
+		{name: "missing member", path: "$.name", expectedError: `$.name: no member "name"`},
+		{name: "index out of range", path: "$.repos[2]", expectedError: "$.repos[2]: index 2 out of range for array of length 2"},
+		{name: "index on object", path: "$.login[0]", expectedError: "$.login[0]: cannot index string"},
+		{name: "missing root", path: "login", expectedError: `JSONPath "login" must start with $`},
+		{name: "unterminated bracket", path: "$.repos[0", expectedError: `JSONPath "$.repos[0" has an unterminated [`},
+		{name: "invalid selector", path: "$.repos[x]", expectedError: `JSONPath "$.repos[x]" has an invalid selector [x]`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := evaluateJSONPath(tc.path, document)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
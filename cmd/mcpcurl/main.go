@@ -2,18 +2,25 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"math/big"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 type (
@@ -34,6 +41,8 @@ type (
 		Name        string      `json:"name"`
 		Description string      `json:"description"`
 		InputSchema InputSchema `json:"inputSchema"`
+		// RawInputSchema keeps the complete input schema, which is used for validation
+		RawInputSchema map[string]interface{} `json:"-"`
 	}
 
 	// InputSchema defines the structure of a tool's input parameters
@@ -94,6 +103,25 @@ type (
 	}
 )
 
+// UnmarshalJSON decodes a tool and keeps a copy of its complete input schema, which
+// the Property model cannot fully express, for validation
+func (t *Tool) UnmarshalJSON(data []byte) error {
+	type toolAlias Tool
+	var raw struct {
+		toolAlias
+		RawInputSchema map[string]interface{} `json:"inputSchema"`
+	}
+	if err := json.Unmarshal(data, &raw.toolAlias); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*t = Tool(raw.toolAlias)
+	t.RawInputSchema = raw.RawInputSchema
+	return nil
+}
+
 var (
 	// Create root command
 	rootCmd = &cobra.Command{
@@ -105,11 +133,19 @@ var (
 			if cmd.Name() == "help" || cmd.Name() == "completion" {
 				return nil
 			}
+			// Scenarios may name their own server, run checks the flags itself
+			if cmd == runCmd {
+				return nil
+			}
 
-			// Check if the required global flag is provided
+			// Check if one of the server flags is provided
 			serverCmd, _ := cmd.Flags().GetString("stdio-server-cmd")
-			if serverCmd == "" {
-				return fmt.Errorf("--stdio-server-cmd is required")
+			serverURL, _ := cmd.Flags().GetString("url")
+			if serverCmd == "" && serverURL == "" {
+				return fmt.Errorf("either --stdio-server-cmd or --url is required")
+			}
+			if serverCmd != "" && serverURL != "" {
+				return fmt.Errorf("--stdio-server-cmd and --url are mutually exclusive")
 			}
 			return nil
 		},
@@ -119,13 +155,25 @@ var (
 	schemaCmd = &cobra.Command{
 		Use:   "schema",
 		Short: "Fetch schema from MCP server",
-		Long:  "Fetches the tools schema from the MCP server specified by --stdio-server-cmd",
+		Long:  "Fetches the tools schema from the MCP server specified by --stdio-server-cmd or --url",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			serverCmd, _ := cmd.Flags().GetString("stdio-server-cmd")
-			if serverCmd == "" {
-				return fmt.Errorf("--stdio-server-cmd is required")
+			if serverURL, _ := cmd.Flags().GetString("url"); serverURL != "" {
+				return withSession(cmd, func(ctx context.Context, client *mcpclient.Client) error {
+					result, err := client.ListTools(ctx, mcp.ListToolsRequest{})
+					if err != nil {
+						return fmt.Errorf("failed to list tools: %w", err)
+					}
+					response, err := json.Marshal(SchemaResponse{JSONRPC: mcp.JSONRPC_VERSION, Result: Result{Tools: toLocalTools(result.Tools)}})
+					if err != nil {
+						return fmt.Errorf("failed to marshal tools: %w", err)
+					}
+					fmt.Println(string(response))
+					return nil
+				})
 			}
 
+			serverCmd, _ := cmd.Flags().GetString("stdio-server-cmd")
+
 			// Build the JSON-RPC request for tools/list
 			jsonRequest, err := buildJSONRPCRequest("tools/list", "", nil)
 			if err != nil {
@@ -154,10 +202,18 @@ var (
 
 func main() {
 	rootCmd.AddCommand(schemaCmd)
+	rootCmd.AddCommand(replCmd)
+	rootCmd.AddCommand(resourcesCmd)
+	rootCmd.AddCommand(promptsCmd)
+	rootCmd.AddCommand(runCmd)
+
+	// Add global flags for the server to talk to, one of them is required
+	rootCmd.PersistentFlags().String("stdio-server-cmd", "", "Shell command to invoke MCP server via stdio")
+	rootCmd.PersistentFlags().String("url", "", "URL of a streamable HTTP MCP server")
+	rootCmd.PersistentFlags().StringArray("header", nil, "HTTP header to send with --url requests in the form 'Name: value', may be repeated")
 
-	// Add global flag for stdio server command
-	rootCmd.PersistentFlags().String("stdio-server-cmd", "", "Shell command to invoke MCP server via stdio (required)")
-	_ = rootCmd.MarkPersistentFlagRequired("stdio-server-cmd")
+	// Add global flag for the session timeout
+	rootCmd.PersistentFlags().Duration("timeout", 2*time.Minute, "Maximum time to wait for the MCP server in session based commands")
 
 	// Add global flag for pretty printing
 	rootCmd.PersistentFlags().Bool("pretty", true, "Pretty print MCP response (only for JSON or JSONL responses)")
@@ -174,9 +230,36 @@ func main() {
 		_, _ = fmt.Fprintf(os.Stderr, "Error getting pretty flag: %v\n", err)
 		os.Exit(1)
 	}
-	// Get server command
+	// Only the tools command needs the tools of the server, other commands do not pay for listing them
+	if target, _, err := rootCmd.Find(os.Args[1:]); err == nil && target == toolsCmd {
+		addToolCommands(prettyPrint)
+	}
+
+	// Execute
+	if err := rootCmd.Execute(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error executing command: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// addToolCommands lists the tools of the server and adds a command for each of them to the tools command
+func addToolCommands(prettyPrint bool) {
+	// Get server URL or command
+	serverURL, _ := rootCmd.Flags().GetString("url")
 	serverCmd, err := rootCmd.Flags().GetString("stdio-server-cmd")
-	if err == nil && serverCmd != "" {
+	if serverURL != "" {
+		// Fetch schema from server over a session, HTTP servers require the initialize handshake
+		_ = withSession(rootCmd, func(ctx context.Context, client *mcpclient.Client) error {
+			result, err := client.ListTools(ctx, mcp.ListToolsRequest{})
+			if err != nil {
+				return err
+			}
+			for _, tool := range toLocalTools(result.Tools) {
+				addCommandFromTool(toolsCmd, &tool, prettyPrint)
+			}
+			return nil
+		})
+	} else if err == nil && serverCmd != "" {
 		// Fetch schema from server
 		jsonRequest, err := buildJSONRPCRequest("tools/list", "", nil)
 		if err == nil {
@@ -193,12 +276,6 @@ func main() {
 			}
 		}
 	}
-
-	// Execute
-	if err := rootCmd.Execute(); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Error executing command: %v\n", err)
-		os.Exit(1)
-	}
 }
 
 // addCommandFromTool creates a cobra command from a tool schema
@@ -208,12 +285,40 @@ func addCommandFromTool(toolsCmd *cobra.Command, tool *Tool, prettyPrint bool) {
 		Use:   tool.Name,
 		Short: tool.Description,
 		Run: func(cmd *cobra.Command, _ []string) {
-			// Build a map of arguments from flags
-			arguments, err := buildArgumentsMap(cmd, tool)
+			// Arguments from --args-file are overridden by --arg-json, which are overridden by individual flags
+			arguments, err := loadArguments(cmd)
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "failed to load arguments: %v\n", err)
+				return
+			}
+			flagArguments, err := buildArgumentsMap(cmd, tool)
 			if err != nil {
 				_, _ = fmt.Fprintf(os.Stderr, "failed to build arguments map: %v\n", err)
 				return
 			}
+			maps.Copy(arguments, flagArguments)
+
+			if err := validateArguments(tool.RawInputSchema, arguments); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "invalid arguments: %v\n", err)
+				return
+			}
+
+			if serverURL, _ := cmd.Flags().GetString("url"); serverURL != "" {
+				err := withSession(cmd, func(ctx context.Context, client *mcpclient.Client) error {
+					request := mcp.CallToolRequest{}
+					request.Params.Name = tool.Name
+					request.Params.Arguments = arguments
+					result, err := client.CallTool(ctx, request)
+					if err != nil {
+						return fmt.Errorf("failed to call tool: %w", err)
+					}
+					return printToolResult(os.Stdout, result, prettyPrint)
+				})
+				if err != nil {
+					_, _ = fmt.Fprintf(os.Stderr, "error calling tool: %v\n", err)
+				}
+				return
+			}
 
 			jsonData, err := buildJSONRPCRequest("tools/call", tool.Name, arguments)
 			if err != nil {
@@ -282,25 +387,25 @@ func addCommandFromTool(toolsCmd *cobra.Command, tool *Tool, prettyPrint bool) {
 			cmd.Flags().Float64(name, 0, description)
 		case "boolean":
 			cmd.Flags().Bool(name, false, description)
+		case "object":
+			cmd.Flags().String(name+"-json", "", description+" (provide as JSON object)")
 		case "array":
-			if prop.Items != nil {
-				switch prop.Items.Type {
-				case "string":
-					cmd.Flags().StringSlice(name, []string{}, description)
-				case "object":
-					cmd.Flags().String(name+"-json", "", description+" (provide as JSON array)")
-				}
+			if prop.Items != nil && prop.Items.Type == "string" {
+				cmd.Flags().StringSlice(name, []string{}, description)
+			} else {
+				cmd.Flags().String(name+"-json", "", description+" (provide as JSON array)")
 			}
 		}
 
-		if isRequired {
-			_ = cmd.MarkFlagRequired(name)
-		}
+		// Required arguments are checked against the schema once all argument sources are merged
 
 		// Bind flag to viper
 		_ = viper.BindPFlag(name, cmd.Flags().Lookup(name))
 	}
 
+	cmd.Flags().String("args-file", "", "JSON or YAML file with the tool arguments")
+	cmd.Flags().String("arg-json", "", "Tool arguments as a JSON object")
+
 	// Add command to root
 	toolsCmd.AddCommand(cmd)
 }
@@ -325,22 +430,25 @@ func buildArgumentsMap(cmd *cobra.Command, tool *Tool) (map[string]interface{},
 				value, _ := cmd.Flags().GetBool(name)
 				arguments[name] = value
 			}
+		case "object":
+			if jsonStr, _ := cmd.Flags().GetString(name + "-json"); jsonStr != "" {
+				var jsonObject map[string]interface{}
+				if err := json.Unmarshal([]byte(jsonStr), &jsonObject); err != nil {
+					return nil, fmt.Errorf("error parsing JSON for %s: %w", name, err)
+				}
+				arguments[name] = jsonObject
+			}
 		case "array":
-			if prop.Items != nil {
-				switch prop.Items.Type {
-				case "string":
-					if values, _ := cmd.Flags().GetStringSlice(name); len(values) > 0 {
-						arguments[name] = values
-					}
-				case "object":
-					if jsonStr, _ := cmd.Flags().GetString(name + "-json"); jsonStr != "" {
-						var jsonArray []interface{}
-						if err := json.Unmarshal([]byte(jsonStr), &jsonArray); err != nil {
-							return nil, fmt.Errorf("error parsing JSON for %s: %w", name, err)
-						}
-						arguments[name] = jsonArray
-					}
+			if prop.Items != nil && prop.Items.Type == "string" {
+				if values, _ := cmd.Flags().GetStringSlice(name); len(values) > 0 {
+					arguments[name] = values
+				}
+			} else if jsonStr, _ := cmd.Flags().GetString(name + "-json"); jsonStr != "" {
+				var jsonArray []interface{}
+				if err := json.Unmarshal([]byte(jsonStr), &jsonArray); err != nil {
+					return nil, fmt.Errorf("error parsing JSON for %s: %w", name, err)
 				}
+				arguments[name] = jsonArray
 			}
 		}
 	}
@@ -348,6 +456,71 @@ func buildArgumentsMap(cmd *cobra.Command, tool *Tool) (map[string]interface{},
 	return arguments, nil
 }
 
+// loadArguments reads the arguments given by --args-file and --arg-json, the latter taking precedence
+func loadArguments(cmd *cobra.Command) (map[string]interface{}, error) {
+	arguments := make(map[string]interface{})
+
+	if argsFile, _ := cmd.Flags().GetString("args-file"); argsFile != "" {
+		fileArguments, err := readArgumentsFile(argsFile)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(arguments, fileArguments)
+	}
+
+	if argJSON, _ := cmd.Flags().GetString("arg-json"); argJSON != "" {
+		var jsonArguments map[string]interface{}
+		if err := json.Unmarshal([]byte(argJSON), &jsonArguments); err != nil {
+			return nil, fmt.Errorf("error parsing --arg-json: %w", err)
+		}
+		maps.Copy(arguments, jsonArguments)
+	}
+
+	return arguments, nil
+}
+
+// readArgumentsFile reads tool arguments from a JSON file, or a YAML file when it has a .yaml or .yml extension
+func readArgumentsFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read arguments file: %w", err)
+	}
+
+	var arguments map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var yamlArguments map[string]interface{}
+		if err := yaml.Unmarshal(data, &yamlArguments); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML arguments file: %w", err)
+		}
+		// Round trip through JSON so YAML values have the same types as JSON ones
+		if err := normalizeJSON(yamlArguments, &arguments); err != nil {
+			return nil, fmt.Errorf("failed to convert YAML arguments: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(data, &arguments); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON arguments file: %w", err)
+		}
+	}
+	if arguments == nil {
+		arguments = make(map[string]interface{})
+	}
+	return arguments, nil
+}
+
+// toLocalTools converts tools listed by an MCP client into the local schema model
+func toLocalTools(tools []mcp.Tool) []Tool {
+	data, err := json.Marshal(tools)
+	if err != nil {
+		return nil
+	}
+	var localTools []Tool
+	if err := json.Unmarshal(data, &localTools); err != nil {
+		return nil
+	}
+	return localTools
+}
+
 // buildJSONRPCRequest creates a JSON-RPC request with the given tool name and arguments
 func buildJSONRPCRequest(method, toolName string, arguments map[string]interface{}) (string, error) {
 	id, err := rand.Int(rand.Reader, big.NewInt(10000))
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	promptsCmd = &cobra.Command{
+		Use:   "prompts",
+		Short: "Access prompts offered by the server",
+		Long:  "Lists and renders prompts from the MCP server",
+	}
+
+	promptsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List prompts",
+		Long:  "Lists the prompts offered by the MCP server together with their arguments",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withSession(cmd, func(ctx context.Context, client *mcpclient.Client) error {
+				result, err := client.ListPrompts(ctx, mcp.ListPromptsRequest{})
+				if err != nil {
+					return fmt.Errorf("failed to list prompts: %w", err)
+				}
+
+				for _, prompt := range result.Prompts {
+					fmt.Printf("%s - %s\n", prompt.Name, prompt.Description)
+					for _, arg := range prompt.Arguments {
+						requiredStr := "optional"
+						if arg.Required {
+							requiredStr = "required"
+						}
+						fmt.Printf("  --arg %s=... %s (%s)\n", arg.Name, arg.Description, requiredStr)
+					}
+				}
+				return nil
+			})
+		},
+	}
+
+	promptsGetCmd = &cobra.Command{
+		Use:   "get <name>",
+		Short: "Get a prompt",
+		Long:  "Renders a prompt with the arguments given by --arg name=value and prints its messages",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rawArgs, _ := cmd.Flags().GetStringArray("arg")
+			arguments := make(map[string]string, len(rawArgs))
+			for _, arg := range rawArgs {
+				name, value, found := strings.Cut(arg, "=")
+				if !found {
+					return fmt.Errorf("argument %q must be in the form name=value", arg)
+				}
+				arguments[name] = value
+			}
+
+			return withSession(cmd, func(ctx context.Context, client *mcpclient.Client) error {
+				result, err := getPrompt(ctx, client, args[0], arguments)
+				if err != nil {
+					return fmt.Errorf("failed to get prompt: %w", err)
+				}
+
+				if result.Description != "" {
+					fmt.Printf("# %s\n\n", result.Description)
+				}
+				for _, message := range result.Messages {
+					fmt.Printf("[%s]\n", message.Role)
+					content, err := mcp.ParseContent(message.Content)
+					if err != nil {
+						return fmt.Errorf("failed to parse message content: %w", err)
+					}
+					switch c := content.(type) {
+					case mcp.TextContent:
+						fmt.Println(c.Text)
+					case mcp.EmbeddedResource:
+						if err := printResourceContents(os.Stdout, []mcp.ResourceContents{c.Resource}, "."); err != nil {
+							return err
+						}
+					default:
+						data, err := json.MarshalIndent(c, "", "  ")
+						if err != nil {
+							return fmt.Errorf("failed to marshal message content: %w", err)
+						}
+						fmt.Println(string(data))
+					}
+					fmt.Println()
+				}
+				return nil
+			})
+		},
+	}
+)
+
+func init() {
+	promptsGetCmd.Flags().StringArray("arg", nil, "Prompt argument in the form name=value, may be repeated")
+
+	promptsCmd.AddCommand(promptsListCmd)
+	promptsCmd.AddCommand(promptsGetCmd)
+}
+
+// promptResult is a lenient version of mcp.GetPromptResult
+type promptResult struct {
+	Description string `json:"description"`
+	Messages    []struct {
+		Role    string         `json:"role"`
+		Content map[string]any `json:"content"`
+	} `json:"messages"`
+}
+
+// getPrompt sends prompts/get directly over the transport. client.GetPrompt
+// rejects any role other than user and assistant, but prompts such as
+// AssignCodingAgent also send system messages, which we still want to show.
+func getPrompt(ctx context.Context, client *mcpclient.Client, name string, arguments map[string]string) (*promptResult, error) {
+	params := mcp.GetPromptParams{Name: name, Arguments: arguments}
+	response, err := client.GetTransport().SendRequest(ctx, transport.JSONRPCRequest{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      mcp.NewRequestId("mcpcurl-prompts-get"),
+		Method:  string(mcp.MethodPromptsGet),
+		Params:  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, errors.New(response.Error.Message)
+	}
+
+	var result promptResult
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &result, nil
+}
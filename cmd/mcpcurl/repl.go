@@ -0,0 +1,497 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const replPrompt = "mcp> "
+
+// replCommands are the built-in commands of the interactive shell, in the order shown by help
+var replCommands = []string{"help", "tools", "describe", "call", "ping", "exit", "quit"}
+
+const replHelp = `Commands:
+  tools                          List the tools offered by the server
+  describe <tool>                Show the input schema of a tool
+  call <tool> [name=value ...]   Call a tool, values are converted using the tool schema
+  ping                           Ping the server
+  help                           Show this help
+  exit, quit                     Close the session (Ctrl-D also works)
+
+Array values can be given as a comma separated list or as JSON, object values as JSON.
+Press Tab to complete commands, tool names, argument names and enum values.`
+
+var replCmd = &cobra.Command{
+	Use:   "repl",
+	Short: "Start an interactive session with the MCP server",
+	Long:  "Starts the MCP server once, performs the initialize handshake and keeps the session open for interactive tool calls. Notifications sent by the server are printed as they arrive.",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		prettyPrint, _ := cmd.Flags().GetBool("pretty")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		// When attached to a terminal we use raw mode to support tab completion,
+		// otherwise commands are read line by line, which allows piping a script in.
+		fd := int(os.Stdin.Fd()) //nolint:gosec // file descriptors fit in an int
+		if !term.IsTerminal(fd) {
+			r := &repl{out: os.Stdout, prettyPrint: prettyPrint, timeout: timeout}
+			return r.start(ctx, cmd, bufioLineReader(os.Stdin))
+		}
+
+		oldState, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("failed to set terminal to raw mode: %w", err)
+		}
+		defer func() { _ = term.Restore(fd, oldState) }()
+
+		terminal := term.NewTerminal(struct {
+			io.Reader
+			io.Writer
+		}{os.Stdin, os.Stdout}, replPrompt)
+
+		r := &repl{out: terminal, prettyPrint: prettyPrint, timeout: timeout}
+		terminal.AutoCompleteCallback = r.autoComplete
+		return r.start(ctx, cmd, terminal.ReadLine)
+	},
+}
+
+// bufioLineReader adapts a reader to the ReadLine signature used by term.Terminal
+func bufioLineReader(in io.Reader) func() (string, error) {
+	scanner := bufio.NewScanner(in)
+	return func() (string, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", io.EOF
+		}
+		return scanner.Text(), nil
+	}
+}
+
+// repl holds the state of an interactive session
+type repl struct {
+	client      *mcpclient.Client
+	out         io.Writer
+	prettyPrint bool
+	timeout     time.Duration
+
+	mu    sync.RWMutex
+	tools map[string]mcp.Tool
+}
+
+func (r *repl) start(ctx context.Context, cmd *cobra.Command, readLine func() (string, error)) error {
+	client, initResult, err := newSession(ctx, cmd, r.out)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	r.client = client
+
+	client.OnNotification(r.handleNotification)
+
+	_, _ = fmt.Fprintf(r.out, "Connected to %s %s (protocol %s)\n",
+		initResult.ServerInfo.Name, initResult.ServerInfo.Version, initResult.ProtocolVersion)
+
+	if err := r.refreshTools(ctx); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(r.out, "%d tools available, type \"help\" for a list of commands\n", len(r.toolNames()))
+
+	for {
+		line, err := readLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		cmdCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		done, err := r.execute(cmdCtx, line)
+		cancel()
+		if err != nil {
+			_, _ = fmt.Fprintf(r.out, "error: %v\n", err)
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// execute runs a single line of input, returning true when the session should end
+func (r *repl) execute(ctx context.Context, line string) (bool, error) {
+	args, err := splitArgs(line)
+	if err != nil {
+		return false, err
+	}
+	if len(args) == 0 {
+		return false, nil
+	}
+
+	switch args[0] {
+	case "exit", "quit":
+		return true, nil
+	case "help":
+		_, _ = fmt.Fprintln(r.out, replHelp)
+	case "tools":
+		for _, name := range r.toolNames() {
+			tool, _ := r.tool(name)
+			_, _ = fmt.Fprintf(r.out, "%-45s %s\n", name, firstLine(tool.Description))
+		}
+	case "describe":
+		if len(args) != 2 {
+			return false, errors.New("usage: describe <tool>")
+		}
+		tool, ok := r.tool(args[1])
+		if !ok {
+			return false, fmt.Errorf("unknown tool: %s", args[1])
+		}
+		schema, err := json.MarshalIndent(tool.InputSchema, "", "  ")
+		if err != nil {
+			return false, fmt.Errorf("failed to marshal input schema: %w", err)
+		}
+		_, _ = fmt.Fprintf(r.out, "%s\n\n%s\n", tool.Description, schema)
+	case "call":
+		if len(args) < 2 {
+			return false, errors.New("usage: call <tool> [name=value ...]")
+		}
+		tool, ok := r.tool(args[1])
+		if !ok {
+			return false, fmt.Errorf("unknown tool: %s", args[1])
+		}
+		arguments, err := parseToolArguments(tool, args[2:])
+		if err != nil {
+			return false, err
+		}
+		if err := validateArguments(tool.InputSchema, arguments); err != nil {
+			return false, err
+		}
+
+		request := mcp.CallToolRequest{}
+		request.Params.Name = tool.Name
+		request.Params.Arguments = arguments
+		result, err := r.client.CallTool(ctx, request)
+		if err != nil {
+			return false, fmt.Errorf("failed to call tool: %w", err)
+		}
+		return false, printToolResult(r.out, result, r.prettyPrint)
+	case "ping":
+		if err := r.client.Ping(ctx); err != nil {
+			return false, fmt.Errorf("ping failed: %w", err)
+		}
+		_, _ = fmt.Fprintln(r.out, "pong")
+	default:
+		return false, fmt.Errorf("unknown command %q, type \"help\" for a list of commands", args[0])
+	}
+	return false, nil
+}
+
+// handleNotification prints server notifications and keeps the tool list in sync
+func (r *repl) handleNotification(notification mcp.JSONRPCNotification) {
+	params, _ := json.Marshal(notification.Params)
+	_, _ = fmt.Fprintf(r.out, "<- %s %s\n", notification.Method, params)
+
+	if notification.Method == string(mcp.MethodNotificationToolsListChanged) {
+		// Notifications are delivered on the transport's read loop, so requests
+		// sent from here would never see their response. Refresh asynchronously.
+		go func() {
+			if err := r.refreshTools(context.Background()); err != nil {
+				_, _ = fmt.Fprintf(r.out, "error: %v\n", err)
+			}
+		}()
+	}
+}
+
+func (r *repl) refreshTools(ctx context.Context) error {
+	result, err := r.client.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	tools := make(map[string]mcp.Tool, len(result.Tools))
+	for _, tool := range result.Tools {
+		tools[tool.Name] = tool
+	}
+
+	r.mu.Lock()
+	r.tools = tools
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *repl) tool(name string) (mcp.Tool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tool, ok := r.tools[name]
+	return tool, ok
+}
+
+func (r *repl) toolNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.tools))
+	for name := range r.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// autoComplete implements term.Terminal.AutoCompleteCallback for the tab key
+func (r *repl) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+
+	word, candidates := r.completions(line[:pos])
+	switch len(candidates) {
+	case 0:
+		return "", 0, false
+	case 1:
+		completed := candidates[0]
+		if !strings.HasSuffix(completed, "=") {
+			completed += " "
+		}
+		return line[:pos-len(word)] + completed + line[pos:], pos - len(word) + len(completed), true
+	default:
+		_, _ = fmt.Fprintln(r.out, strings.Join(candidates, "  "))
+		prefix := commonPrefix(candidates)
+		return line[:pos-len(word)] + prefix + line[pos:], pos - len(word) + len(prefix), true
+	}
+}
+
+// completions returns the word being completed at the end of input and the
+// candidates that could replace it
+func (r *repl) completions(input string) (string, []string) {
+	fields := strings.Fields(input)
+	word := ""
+	if len(fields) > 0 && !strings.HasSuffix(input, " ") {
+		word = fields[len(fields)-1]
+		fields = fields[:len(fields)-1]
+	}
+
+	var options []string
+	switch {
+	case len(fields) == 0:
+		options = replCommands
+	case len(fields) == 1 && (fields[0] == "call" || fields[0] == "describe"):
+		options = r.toolNames()
+	case len(fields) >= 2 && fields[0] == "call":
+		tool, ok := r.tool(fields[1])
+		if !ok {
+			return word, nil
+		}
+		if name, _, found := strings.Cut(word, "="); found {
+			for _, value := range propertyValues(tool, name) {
+				options = append(options, name+"="+value)
+			}
+		} else {
+			for name := range tool.InputSchema.Properties {
+				options = append(options, name+"=")
+			}
+		}
+	}
+
+	var candidates []string
+	for _, option := range options {
+		if strings.HasPrefix(option, word) {
+			candidates = append(candidates, option)
+		}
+	}
+	sort.Strings(candidates)
+	return word, candidates
+}
+
+// propertyValues returns the values a property can take when they are known from the schema
+func propertyValues(tool mcp.Tool, name string) []string {
+	prop, ok := tool.InputSchema.Properties[name].(map[string]any)
+	if !ok {
+		return nil
+	}
+	if prop["type"] == "boolean" {
+		return []string{"true", "false"}
+	}
+	switch enum := prop["enum"].(type) {
+	case []string:
+		return enum
+	case []any:
+		values := make([]string, 0, len(enum))
+		for _, v := range enum {
+			values = append(values, fmt.Sprint(v))
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// parseToolArguments converts name=value pairs into tool arguments using the types in the tool schema
+func parseToolArguments(tool mcp.Tool, args []string) (map[string]any, error) {
+	arguments := make(map[string]any)
+	for _, arg := range args {
+		name, value, found := strings.Cut(arg, "=")
+		if !found {
+			return nil, fmt.Errorf("argument %q must be in the form name=value", arg)
+		}
+		prop, ok := tool.InputSchema.Properties[name].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("tool %s has no argument named %s", tool.Name, name)
+		}
+
+		switch prop["type"] {
+		case "number", "integer":
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a number: %w", name, err)
+			}
+			arguments[name] = v
+		case "boolean":
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a boolean: %w", name, err)
+			}
+			arguments[name] = v
+		case "array":
+			if strings.HasPrefix(value, "[") {
+				var v []any
+				if err := json.Unmarshal([]byte(value), &v); err != nil {
+					return nil, fmt.Errorf("error parsing JSON for %s: %w", name, err)
+				}
+				arguments[name] = v
+				continue
+			}
+			arguments[name] = strings.Split(value, ",")
+		case "object":
+			var v map[string]any
+			if err := json.Unmarshal([]byte(value), &v); err != nil {
+				return nil, fmt.Errorf("error parsing JSON for %s: %w", name, err)
+			}
+			arguments[name] = v
+		default:
+			if enum := propertyValues(tool, name); len(enum) > 0 && !slices.Contains(enum, value) {
+				return nil, fmt.Errorf("%s must be one of: %s", name, strings.Join(enum, ", "))
+			}
+			arguments[name] = value
+		}
+	}
+
+	for _, name := range tool.InputSchema.Required {
+		if _, ok := arguments[name]; !ok {
+			return nil, fmt.Errorf("missing required argument: %s", name)
+		}
+	}
+	return arguments, nil
+}
+
+// printToolResult writes the content of a tool result, pretty printing JSON text when requested
+func printToolResult(w io.Writer, result *mcp.CallToolResult, prettyPrint bool) error {
+	if result.IsError {
+		_, _ = fmt.Fprintln(w, "tool returned an error:")
+	}
+
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case mcp.TextContent:
+			if !prettyPrint {
+				_, _ = fmt.Fprintln(w, c.Text)
+				continue
+			}
+			var v any
+			if err := json.Unmarshal([]byte(c.Text), &v); err != nil {
+				// Not JSON, print as is
+				_, _ = fmt.Fprintln(w, c.Text)
+				continue
+			}
+			prettyText, err := json.MarshalIndent(v, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to pretty print text content: %w", err)
+			}
+			_, _ = fmt.Fprintln(w, string(prettyText))
+		default:
+			data, err := json.MarshalIndent(content, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal content: %w", err)
+			}
+			_, _ = fmt.Fprintln(w, string(data))
+		}
+	}
+	return nil
+}
+
+// splitArgs splits a line into words, honouring single and double quotes
+func splitArgs(line string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		quote   rune
+		inWord  bool
+	)
+	for _, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+				continue
+			}
+			current.WriteRune(c)
+		case c == '"' || c == '\'':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t':
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(c)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inWord {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
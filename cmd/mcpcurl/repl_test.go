@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTool() mcp.Tool {
+	return mcp.NewTool("list_issues",
+		mcp.WithString("owner", mcp.Required()),
+		mcp.WithString("state", mcp.Enum("open", "closed", "all")),
+		mcp.WithArray("labels", mcp.Items(map[string]any{"type": "string"})),
+		mcp.WithNumber("perPage"),
+		mcp.WithBoolean("draft"),
+	)
+}
+
+func Test_replCompletions(t *testing.T) {
+	r := &repl{tools: map[string]mcp.Tool{
+		"list_issues": testTool(),
+		"list_tags":   mcp.NewTool("list_tags"),
+	}}
+
+	tests := []struct {
+		name               string
+		input              string
+		expectedWord       string
+		expectedCandidates []string
+	}{
+		{
+			name:               "command",
+			input:              "de",
+			expectedWord:       "de",
+			expectedCandidates: []string{"describe"},
+		},
+		{
+			name:               "tool names",
+			input:              "call list_",
+			expectedWord:       "list_",
+			expectedCandidates: []string{"list_issues", "list_tags"},
+		},
+		{
+			name:               "argument names",
+			input:              "call list_issues st",
+			expectedWord:       "st",
+			expectedCandidates: []string{"state="},
+		},
+		{
+			name:               "enum values",
+			input:              "call list_issues state=c",
+			expectedWord:       "state=c",
+			expectedCandidates: []string{"state=closed"},
+		},
+		{
+			name:               "boolean values",
+			input:              "call list_issues draft=",
+			expectedWord:       "draft=",
+			expectedCandidates: []string{"draft=false", "draft=true"},
+		},
+		{
+			name:         "unknown tool",
+			input:        "call nope o",
+			expectedWord: "o",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			word, candidates := r.completions(tc.input)
+			assert.Equal(t, tc.expectedWord, word)
+			assert.Equal(t, tc.expectedCandidates, candidates)
+		})
+	}
+}
+
+func Test_parseToolArguments(t *testing.T) {
+	tool := testTool()
+
+	args, err := parseToolArguments(tool, []string{"owner=octo", "state=open", "labels=bug,help", "perPage=5", "draft=true"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"owner":   "octo",
+		"state":   "open",
+		"labels":  []string{"bug", "help"},
+		"perPage": float64(5),
+		"draft":   true,
+	}, args)
+
+	args, err = parseToolArguments(tool, []string{"owner=octo", `labels=["a,b"]`})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a,b"}, args["labels"])
+
+	_, err = parseToolArguments(tool, []string{"state=open"})
+	assert.EqualError(t, err, "missing required argument: owner")
+
+	_, err = parseToolArguments(tool, []string{"owner=octo", "state=merged"})
+	assert.EqualError(t, err, "state must be one of: open, closed, all")
+
+	_, err = parseToolArguments(tool, []string{"owner"})
+	assert.EqualError(t, err, `argument "owner" must be in the form name=value`)
+}
+
+func Test_splitArgs(t *testing.T) {
+	args, err := splitArgs(`call create_issue title="Hello world" body='it''s' x=`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"call", "create_issue", "title=Hello world", "body=its", "x="}, args)
+
+	_, err = splitArgs(`call "unterminated`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+var (
+	resourcesCmd = &cobra.Command{
+		Use:   "resources",
+		Short: "Access resources offered by the server",
+		Long:  "Lists resource templates and reads resources from the MCP server",
+	}
+
+	resourcesTemplatesCmd = &cobra.Command{
+		Use:   "templates",
+		Short: "List resource templates",
+		Long:  "Lists the resource templates offered by the MCP server, such as the repo:// templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withSession(cmd, func(ctx context.Context, client *mcpclient.Client) error {
+				result, err := client.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+				if err != nil {
+					return fmt.Errorf("failed to list resource templates: %w", err)
+				}
+
+				for _, template := range result.ResourceTemplates {
+					uriTemplate := ""
+					if template.URITemplate != nil {
+						uriTemplate = template.URITemplate.Raw()
+					}
+					fmt.Printf("%-65s %s\n", uriTemplate, template.Name)
+				}
+				return nil
+			})
+		},
+	}
+
+	resourcesReadCmd = &cobra.Command{
+		Use:   "read <uri>",
+		Short: "Read a resource",
+		Long:  "Reads a resource from the MCP server. Text contents are printed, blob contents are decoded and written to files in --output-dir.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputDir, _ := cmd.Flags().GetString("output-dir")
+
+			return withSession(cmd, func(ctx context.Context, client *mcpclient.Client) error {
+				request := mcp.ReadResourceRequest{}
+				request.Params.URI = args[0]
+				result, err := client.ReadResource(ctx, request)
+				if err != nil {
+					return fmt.Errorf("failed to read resource: %w", err)
+				}
+
+				return printResourceContents(os.Stdout, result.Contents, outputDir)
+			})
+		},
+	}
+)
+
+func init() {
+	resourcesReadCmd.Flags().String("output-dir", ".", "Directory to write blob contents to")
+
+	resourcesCmd.AddCommand(resourcesTemplatesCmd)
+	resourcesCmd.AddCommand(resourcesReadCmd)
+}
+
+// printResourceContents prints text contents to w and writes decoded blob contents to files in outputDir
+func printResourceContents(w io.Writer, contents []mcp.ResourceContents, outputDir string) error {
+	for _, content := range contents {
+		switch c := content.(type) {
+		case mcp.TextResourceContents:
+			_, _ = fmt.Fprintln(w, c.Text)
+		case mcp.BlobResourceContents:
+			data, err := base64.StdEncoding.DecodeString(c.Blob)
+			if err != nil {
+				return fmt.Errorf("failed to decode blob contents of %s: %w", c.URI, err)
+			}
+
+			filePath := filepath.Join(outputDir, blobFileName(c.URI))
+			if err := os.WriteFile(filePath, data, 0600); err != nil {
+				return fmt.Errorf("failed to write blob contents: %w", err)
+			}
+			_, _ = fmt.Fprintf(w, "wrote %d bytes (%s) to %s\n", len(data), c.MIMEType, filePath)
+		}
+	}
+	return nil
+}
+
+// blobFileName derives a local file name from the last element of a resource URI
+func blobFileName(uri string) string {
+	name := ""
+	if u, err := url.Parse(uri); err == nil {
+		name = path.Base(u.Path)
+	}
+	if name == "" || name == "." || name == "/" {
+		return "resource.bin"
+	}
+	return name
+}
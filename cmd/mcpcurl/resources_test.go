@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_printResourceContents(t *testing.T) {
+	dir := t.TempDir()
+	var out bytes.Buffer
+
+	err := printResourceContents(&out, []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: "repo://owner/repo/contents/README.md", MIMEType: "text/markdown", Text: "# Hello"},
+		mcp.BlobResourceContents{URI: "repo://owner/repo/contents/img/logo.png", MIMEType: "image/png", Blob: "aGVsbG8="},
+	}, dir)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "logo.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, "# Hello\nwrote 5 bytes (image/png) to "+filepath.Join(dir, "logo.png")+"\n", out.String())
+
+	err = printResourceContents(&out, []mcp.ResourceContents{
+		mcp.BlobResourceContents{URI: "repo://owner/repo/contents/bad.bin", Blob: "not base64!"},
+	}, dir)
+	assert.Error(t, err)
+}
+
+func Test_blobFileName(t *testing.T) {
+	assert.Equal(t, "logo.png", blobFileName("repo://owner/repo/refs/heads/main/contents/docs/logo.png"))
+	assert.Equal(t, "resource.bin", blobFileName("repo://owner"))
+	assert.Equal(t, "resource.bin", blobFileName("::not a uri"))
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run <scenario.yaml>",
+	Short: "Run a scenario of tool calls",
+	Long: `Runs the tool calls listed in a scenario file against the MCP server and checks their results.
+The server is given by the scenario or by --stdio-server-cmd or --url. A scenario can start a local
+stand-in API, whose address is available as ${API_URL}, for the server to talk to instead of GitHub.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scenario, err := loadScenario(args[0])
+		if err != nil {
+			return err
+		}
+
+		config, err := serverConfigFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		suite := runScenario(cmd.Context(), scenario, config, os.Stdout, os.Stderr)
+
+		if junitPath, _ := cmd.Flags().GetString("junit"); junitPath != "" {
+			if err := writeJUnit(junitPath, suite); err != nil {
+				return err
+			}
+		}
+
+		if suite.Failures > 0 || suite.Errors > 0 {
+			return fmt.Errorf("%d of %d steps failed", suite.Failures+suite.Errors, suite.Tests)
+		}
+		return nil
+	},
+}
+
+func init() {
+	runCmd.Flags().String("junit", "", "Write the results as JUnit XML to this file")
+}
+
+type (
+	// junitTestSuites is the root element of a JUnit XML report
+	junitTestSuites struct {
+		XMLName xml.Name          `xml:"testsuites"`
+		Suites  []*junitTestSuite `xml:"testsuite"`
+	}
+
+	// junitTestSuite holds the results of a scenario
+	junitTestSuite struct {
+		Name      string          `xml:"name,attr"`
+		Tests     int             `xml:"tests,attr"`
+		Failures  int             `xml:"failures,attr"`
+		Errors    int             `xml:"errors,attr"`
+		Time      string          `xml:"time,attr"`
+		TestCases []junitTestCase `xml:"testcase"`
+	}
+
+	// junitTestCase holds the result of a step
+	junitTestCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Time      string        `xml:"time,attr"`
+		Failure   *junitMessage `xml:"failure,omitempty"`
+		Error     *junitMessage `xml:"error,omitempty"`
+		SystemOut string        `xml:"system-out,omitempty"`
+	}
+
+	// junitMessage describes why a test case failed
+	junitMessage struct {
+		Message string `xml:"message,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// runScenario runs every step of the scenario, reporting progress to out and server
+// output to stderrOut. Steps keep running after a failure, so the report is complete.
+func runScenario(ctx context.Context, scenario *Scenario, config serverConfig, out, stderrOut io.Writer) *junitTestSuite {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	start := time.Now()
+	suite := &junitTestSuite{Name: scenario.Name}
+	defer func() {
+		suite.Tests = len(suite.TestCases)
+		suite.Time = formatSeconds(time.Since(start))
+	}()
+
+	// failAll records the same error for every step that did not run
+	failAll := func(err error) *junitTestSuite {
+		_, _ = fmt.Fprintf(out, "ERROR %s: %v\n", scenario.Name, err)
+		for _, step := range scenario.Steps[len(suite.TestCases):] {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      step.Name,
+				ClassName: scenario.Name,
+				Time:      formatSeconds(0),
+				Error:     &junitMessage{Message: err.Error()},
+			})
+			suite.Errors++
+		}
+		return suite
+	}
+
+	vars := scenarioVars{}
+	for name, value := range scenario.Vars {
+		vars[name] = value
+	}
+
+	if scenario.API != nil {
+		apiURL, stop, err := startStandInAPI(scenario.API, stderrOut)
+		if err != nil {
+			return failAll(err)
+		}
+		defer stop()
+		vars["API_URL"] = apiURL
+	}
+
+	config, err := scenarioServerConfig(scenario.Server, config, vars)
+	if err != nil {
+		return failAll(err)
+	}
+
+	client, _, err := startSession(ctx, config, stderrOut)
+	if err != nil {
+		return failAll(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	tools, err := listTools(ctx, client, config.Timeout)
+	if err != nil {
+		return failAll(err)
+	}
+
+	for _, step := range scenario.Steps {
+		stepStart := time.Now()
+		testCase := junitTestCase{Name: step.Name, ClassName: scenario.Name}
+
+		result, err := runStep(ctx, client, tools, step, vars, config.Timeout)
+		switch {
+		case step.Expect.Error != "":
+			// The call is expected to fail, anything else is an assertion failure
+			var failure string
+			switch {
+			case err == nil:
+				failure = fmt.Sprintf("expected call to fail with %q, got result: %s", step.Expect.Error, firstLine(resultText(result)))
+			case !strings.Contains(err.Error(), step.Expect.Error):
+				failure = fmt.Sprintf("expected call to fail with %q, got: %v", step.Expect.Error, err)
+			}
+			if failure != "" {
+				testCase.Failure = &junitMessage{Message: failure}
+				suite.Failures++
+				_, _ = fmt.Fprintf(out, "FAIL  %s\n      %s\n", step.Name, failure)
+			} else {
+				_, _ = fmt.Fprintf(out, "PASS  %s (%s)\n", step.Name, time.Since(stepStart).Round(time.Millisecond))
+			}
+		case err != nil:
+			testCase.Error = &junitMessage{Message: err.Error()}
+			suite.Errors++
+			_, _ = fmt.Fprintf(out, "ERROR %s: %v\n", step.Name, err)
+		default:
+			testCase.SystemOut = resultText(result)
+
+			failures := checkResult(step.Expect, result, vars)
+			captured, err := captureValues(step.Capture, result)
+			if err != nil {
+				failures = append(failures, err.Error())
+			}
+			for name, value := range captured {
+				vars[name] = value
+			}
+
+			if len(failures) > 0 {
+				testCase.Failure = &junitMessage{Message: failures[0], Text: strings.Join(failures, "\n")}
+				suite.Failures++
+				_, _ = fmt.Fprintf(out, "FAIL  %s\n", step.Name)
+				for _, failure := range failures {
+					_, _ = fmt.Fprintf(out, "      %s\n", failure)
+				}
+			} else {
+				_, _ = fmt.Fprintf(out, "PASS  %s (%s)\n", step.Name, time.Since(stepStart).Round(time.Millisecond))
+			}
+		}
+
+		testCase.Time = formatSeconds(time.Since(stepStart))
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	return suite
+}
+
+// runStep expands the arguments of a step, validates them and calls the tool
+func runStep(ctx context.Context, client *mcpclient.Client, tools map[string]mcp.Tool, step ScenarioStep, vars scenarioVars, timeout time.Duration) (*mcp.CallToolResult, error) {
+	tool, ok := tools[step.Tool]
+	if !ok {
+		return nil, fmt.Errorf("unknown tool: %s", step.Tool)
+	}
+
+	arguments, err := vars.expand(step.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateArguments(tool.InputSchema, arguments); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = step.Tool
+	request.Params.Arguments = arguments
+	result, err := client.CallTool(callCtx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call tool: %w", err)
+	}
+	return result, nil
+}
+
+// listTools fetches the tools offered by the server, keyed by name
+func listTools(ctx context.Context, client *mcpclient.Client, timeout time.Duration) (map[string]mcp.Tool, error) {
+	listCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := client.ListTools(listCtx, mcp.ListToolsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	tools := make(map[string]mcp.Tool, len(result.Tools))
+	for _, tool := range result.Tools {
+		tools[tool.Name] = tool
+	}
+	return tools, nil
+}
+
+// scenarioServerConfig applies the server settings of a scenario on top of the global flags
+func scenarioServerConfig(server ScenarioServer, config serverConfig, vars scenarioVars) (serverConfig, error) {
+	if server.Command != "" || server.URL != "" {
+		config.Command, config.URL = "", ""
+	}
+
+	var err error
+	if server.Command != "" {
+		if config.Command, err = vars.expandString(server.Command); err != nil {
+			return config, fmt.Errorf("invalid server command: %w", err)
+		}
+	}
+	if server.URL != "" {
+		if config.URL, err = vars.expandString(server.URL); err != nil {
+			return config, fmt.Errorf("invalid server url: %w", err)
+		}
+	}
+	if config.Command == "" && config.URL == "" {
+		return config, errors.New("no server given, set server.command or server.url in the scenario or use --stdio-server-cmd or --url")
+	}
+	if config.Command != "" && config.URL != "" {
+		return config, errors.New("server command and url are mutually exclusive")
+	}
+
+	headers := make(map[string]string, len(config.Headers)+len(server.Headers))
+	for name, value := range config.Headers {
+		headers[name] = value
+	}
+	for name, value := range server.Headers {
+		if headers[name], err = vars.expandString(value); err != nil {
+			return config, fmt.Errorf("invalid header %s: %w", name, err)
+		}
+	}
+	config.Headers = headers
+
+	config.Env = append([]string(nil), config.Env...)
+	for name, value := range server.Env {
+		expanded, err := vars.expandString(value)
+		if err != nil {
+			return config, fmt.Errorf("invalid env %s: %w", name, err)
+		}
+		config.Env = append(config.Env, name+"="+expanded)
+	}
+
+	return config, nil
+}
+
+// startStandInAPI serves the canned routes on a local port, returning its URL and a function to stop it.
+// Requests are logged to logOut, so unmatched requests are easy to spot when writing a scenario.
+func startStandInAPI(api *StandInAPI, logOut io.Writer) (string, func(), error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to start stand-in API: %w", err)
+	}
+
+	server := &http.Server{
+		Handler:           standInHandler(api, logOut),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { _ = server.Serve(listener) }()
+
+	return "http://" + listener.Addr().String(), func() { _ = server.Close() }, nil
+}
+
+// standInHandler answers requests with the first route matching their method and path.
+// A route path containing a query only matches requests with exactly that query.
+func standInHandler(api *StandInAPI, logOut io.Writer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, route := range api.Routes {
+			if route.Method != "" && !strings.EqualFold(route.Method, r.Method) {
+				continue
+			}
+			target := r.URL.Path
+			if strings.Contains(route.Path, "?") {
+				target = r.URL.RequestURI()
+			}
+			if route.Path != target {
+				continue
+			}
+
+			status := route.Status
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			var body []byte
+			switch b := route.Body.(type) {
+			case nil:
+			case string:
+				body = []byte(b)
+			default:
+				var normalized any
+				if err := normalizeJSON(b, &normalized); err == nil {
+					body, _ = json.Marshal(normalized)
+				}
+				w.Header().Set("Content-Type", "application/json")
+			}
+			for name, value := range route.Headers {
+				w.Header().Set(name, value)
+			}
+
+			_, _ = fmt.Fprintf(logOut, "[api] %s %s -> %d\n", r.Method, r.URL.RequestURI(), status)
+			w.WriteHeader(status)
+			_, _ = w.Write(body)
+			return
+		}
+
+		_, _ = fmt.Fprintf(logOut, "[api] %s %s -> no matching route\n", r.Method, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+}
+
+// writeJUnit writes the suite as a JUnit XML report
+func writeJUnit(path string, suite *junitTestSuite) error {
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []*junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JUnit report: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+// formatSeconds formats a duration the way JUnit reports expect
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
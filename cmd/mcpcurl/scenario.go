@@ -0,0 +1,301 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Scenario is a scripted sequence of tool calls read from a YAML file
+	Scenario struct {
+		Name   string         `yaml:"name"`
+		Server ScenarioServer `yaml:"server"`
+		API    *StandInAPI    `yaml:"api"`
+		Vars   map[string]any `yaml:"vars"`
+		Steps  []ScenarioStep `yaml:"steps"`
+	}
+
+	// ScenarioServer overrides the global --stdio-server-cmd and --url flags
+	ScenarioServer struct {
+		Command string            `yaml:"command"`
+		URL     string            `yaml:"url"`
+		Headers map[string]string `yaml:"headers"`
+		Env     map[string]string `yaml:"env"`
+	}
+
+	// StandInAPI is a local HTTP server answering with canned responses, so a
+	// server binary can be exercised without talking to GitHub
+	StandInAPI struct {
+		Routes []StandInRoute `yaml:"routes"`
+	}
+
+	// StandInRoute is a canned response for requests matching Method and Path
+	StandInRoute struct {
+		Method  string            `yaml:"method"`
+		Path    string            `yaml:"path"`
+		Status  int               `yaml:"status"`
+		Headers map[string]string `yaml:"headers"`
+		Body    any               `yaml:"body"`
+	}
+
+	// ScenarioStep calls a tool and checks its result
+	ScenarioStep struct {
+		Name      string            `yaml:"name"`
+		Tool      string            `yaml:"tool"`
+		Arguments map[string]any    `yaml:"arguments"`
+		Capture   map[string]string `yaml:"capture"`
+		Expect    StepExpectation   `yaml:"expect"`
+	}
+
+	// StepExpectation lists the assertions made on a tool result. Unless IsError
+	// is given, the result is expected not to be an error. Error expects the call
+	// itself to fail with a JSON-RPC error containing the given text instead.
+	StepExpectation struct {
+		Error       string         `yaml:"error"`
+		IsError     *bool          `yaml:"isError"`
+		Contains    []string       `yaml:"contains"`
+		NotContains []string       `yaml:"notContains"`
+		Matches     string         `yaml:"matches"`
+		JSONPath    map[string]any `yaml:"jsonpath"`
+	}
+)
+
+// loadScenario reads and checks a scenario file
+func loadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	var scenario Scenario
+	if err := yaml.Unmarshal(data, &scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+
+	if len(scenario.Steps) == 0 {
+		return nil, fmt.Errorf("scenario %s has no steps", path)
+	}
+	for i := range scenario.Steps {
+		step := &scenario.Steps[i]
+		if step.Tool == "" {
+			return nil, fmt.Errorf("step %d has no tool", i+1)
+		}
+		if step.Name == "" {
+			step.Name = fmt.Sprintf("%d %s", i+1, step.Tool)
+		}
+		if step.Arguments == nil {
+			step.Arguments = map[string]any{}
+		}
+	}
+	if scenario.Name == "" {
+		scenario.Name = path
+	}
+
+	// YAML numbers decode as int, normalize everything to JSON types up front
+	if err := normalizeJSON(scenario.Vars, &scenario.Vars); err != nil {
+		return nil, fmt.Errorf("invalid vars: %w", err)
+	}
+	for i := range scenario.Steps {
+		step := &scenario.Steps[i]
+		if err := normalizeJSON(step.Arguments, &step.Arguments); err != nil {
+			return nil, fmt.Errorf("invalid arguments in step %q: %w", step.Name, err)
+		}
+		if err := normalizeJSON(step.Expect.JSONPath, &step.Expect.JSONPath); err != nil {
+			return nil, fmt.Errorf("invalid jsonpath expectations in step %q: %w", step.Name, err)
+		}
+	}
+
+	return &scenario, nil
+}
+
+// scenarioVarPattern matches ${name} references to scenario variables
+var scenarioVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// scenarioVars holds variables from the scenario, captured from previous results
+// and provided by mcpcurl, such as API_URL for the stand-in API.
+type scenarioVars map[string]any
+
+// expandString substitutes variable references in s
+func (v scenarioVars) expandString(s string) (string, error) {
+	var missing []string
+	expanded := scenarioVarPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := scenarioVarPattern.FindStringSubmatch(ref)[1]
+		value, ok := v[name]
+		if !ok {
+			missing = append(missing, name)
+			return ref
+		}
+		if str, ok := value.(string); ok {
+			return str
+		}
+		data, _ := json.Marshal(value)
+		return string(data)
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("undefined variable: %s", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// expand substitutes variable references in every string of a JSON decoded value.
+// A string consisting of a single reference is replaced by the variable's value,
+// so captured numbers and objects keep their type.
+func (v scenarioVars) expand(value any) (any, error) {
+	switch val := value.(type) {
+	case string:
+		if match := scenarioVarPattern.FindStringSubmatch(val); match != nil && match[0] == val {
+			if captured, ok := v[match[1]]; ok {
+				return captured, nil
+			}
+		}
+		return v.expandString(val)
+	case map[string]any:
+		expanded := make(map[string]any, len(val))
+		for key, item := range val {
+			e, err := v.expand(item)
+			if err != nil {
+				return nil, err
+			}
+			expanded[key] = e
+		}
+		return expanded, nil
+	case []any:
+		expanded := make([]any, len(val))
+		for i, item := range val {
+			e, err := v.expand(item)
+			if err != nil {
+				return nil, err
+			}
+			expanded[i] = e
+		}
+		return expanded, nil
+	}
+	return value, nil
+}
+
+// resultText joins the text contents of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// resultJSON decodes the text contents of a tool result as JSON
+func resultJSON(result *mcp.CallToolResult) (any, error) {
+	var value any
+	if err := json.Unmarshal([]byte(resultText(result)), &value); err != nil {
+		return nil, fmt.Errorf("result is not JSON: %w", err)
+	}
+	return value, nil
+}
+
+// checkResult verifies a tool result against the expectation, returning every failed assertion
+func checkResult(expect StepExpectation, result *mcp.CallToolResult, vars scenarioVars) []string {
+	var failures []string
+	text := resultText(result)
+
+	expectError := false
+	if expect.IsError != nil {
+		expectError = *expect.IsError
+	}
+	if result.IsError != expectError {
+		failures = append(failures, fmt.Sprintf("expected isError to be %t, got %t: %s", expectError, result.IsError, firstLine(text)))
+	}
+
+	for _, s := range expect.Contains {
+		s, err := vars.expandString(s)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		if !strings.Contains(text, s) {
+			failures = append(failures, fmt.Sprintf("expected result to contain %q", s))
+		}
+	}
+	for _, s := range expect.NotContains {
+		s, err := vars.expandString(s)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		if strings.Contains(text, s) {
+			failures = append(failures, fmt.Sprintf("expected result not to contain %q", s))
+		}
+	}
+
+	if expect.Matches != "" {
+		re, err := regexp.Compile(expect.Matches)
+		switch {
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("invalid pattern %q: %v", expect.Matches, err))
+		case !re.MatchString(text):
+			failures = append(failures, fmt.Sprintf("expected result to match %q", expect.Matches))
+		}
+	}
+
+	if len(expect.JSONPath) > 0 {
+		document, err := resultJSON(result)
+		if err != nil {
+			return append(failures, err.Error())
+		}
+
+		paths := make([]string, 0, len(expect.JSONPath))
+		for path := range expect.JSONPath {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			expected, err := vars.expand(expect.JSONPath[path])
+			if err != nil {
+				failures = append(failures, err.Error())
+				continue
+			}
+			actual, err := evaluateJSONPath(path, document)
+			if err != nil {
+				failures = append(failures, err.Error())
+				continue
+			}
+			if !reflect.DeepEqual(expected, actual) {
+				expectedJSON, _ := json.Marshal(expected)
+				actualJSON, _ := json.Marshal(actual)
+				failures = append(failures, fmt.Sprintf("expected %s to be %s, got %s", path, expectedJSON, actualJSON))
+			}
+		}
+	}
+
+	return failures
+}
+
+// captureValues evaluates the capture paths of a step against its result
+func captureValues(capture map[string]string, result *mcp.CallToolResult) (map[string]any, error) {
+	if len(capture) == 0 {
+		return nil, nil
+	}
+	document, err := resultJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("cannot capture: %w", err)
+	}
+
+	values := make(map[string]any, len(capture))
+	for name, path := range capture {
+		value, err := evaluateJSONPath(path, document)
+		if err != nil {
+			return nil, fmt.Errorf("cannot capture %s: %w", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
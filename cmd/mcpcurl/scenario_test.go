@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loadScenario(t *testing.T) {
+	scenario, err := loadScenario(filepath.Join("testdata", "smoke.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "smoke", scenario.Name)
+	assert.Len(t, scenario.API.Routes, 3)
+	assert.Equal(t, "./github-mcp-server stdio --gh-host ${API_URL} --toolsets context,issues", scenario.Server.Command)
+	require.Len(t, scenario.Steps, 3)
+	assert.Equal(t, map[string]string{"login": "$.login"}, scenario.Steps[0].Capture)
+	// Numbers are normalized to JSON types
+	assert.Equal(t, float64(42), scenario.Steps[1].Arguments["issue_number"])
+	assert.Equal(t, "404 Not Found", scenario.Steps[2].Expect.Error)
+
+	path := filepath.Join(t.TempDir(), "empty.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("name: empty\n"), 0600))
+	_, err = loadScenario(path)
+	assert.EqualError(t, err, fmt.Sprintf("scenario %s has no steps", path))
+}
+
+func Test_scenarioVars(t *testing.T) {
+	vars := scenarioVars{"login": "octocat", "id": float64(7)}
+
+	expanded, err := vars.expand(map[string]any{
+		"owner": "${login}",
+		"id":    "${id}",
+		"title": "Issue ${id} by ${login}",
+		"tags":  []any{"${login}", true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"owner": "octocat",
+		"id":    float64(7),
+		"title": "Issue 7 by octocat",
+		"tags":  []any{"octocat", true},
+	}, expanded)
+
+	_, err = vars.expand(map[string]any{"owner": "${nope}"})
+	assert.EqualError(t, err, "undefined variable: nope")
+
+	// JSONPath expressions are not variable references
+	s, err := vars.expandString("$.login")
+	require.NoError(t, err)
+	assert.Equal(t, "$.login", s)
+}
+
+func Test_checkResult(t *testing.T) {
+	isError := true
+	result := mcp.NewToolResultText(`{"login": "octocat", "repos": [{"name": "hello-world"}]}`)
+
+	assert.Empty(t, checkResult(StepExpectation{
+		Contains:    []string{"${login}"},
+		NotContains: []string{"error"},
+		Matches:     `"login":\s*"octo`,
+		JSONPath: map[string]any{
+			"$.login":         "${login}",
+			"$.repos[*].name": []any{"hello-world"},
+		},
+	}, result, scenarioVars{"login": "octocat"}))
+
+	assert.Equal(t, []string{
+		"expected isError to be true, got false: {\"login\": \"octocat\", \"repos\": [{\"name\": \"hello-world\"}]}",
+		`expected result to contain "monalisa"`,
+		`expected result to match "^\\["`,
+		`expected $.login to be "monalisa", got "octocat"`,
+		`$.missing: no member "missing"`,
+	}, checkResult(StepExpectation{
+		IsError:  &isError,
+		Contains: []string{"monalisa"},
+		Matches:  `^\[`,
+		JSONPath: map[string]any{
+			"$.login":   "monalisa",
+			"$.missing": 1,
+		},
+	}, result, scenarioVars{}))
+
+	// Error results fail unless they are expected
+	assert.Equal(t, []string{"expected isError to be false, got true: boom"}, checkResult(StepExpectation{}, mcp.NewToolResultError("boom"), scenarioVars{}))
+	assert.Empty(t, checkResult(StepExpectation{IsError: &isError}, mcp.NewToolResultError("boom"), scenarioVars{}))
+}
+
+func Test_runScenario(t *testing.T) {
+	mcpServer := server.NewMCPServer("test-server", "0.0.1")
+	mcpServer.AddTool(mcp.NewTool("get_me"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(`{"login": "octocat", "id": 7}`), nil
+	})
+	mcpServer.AddTool(mcp.NewTool("get_user",
+		mcp.WithNumber("id", mcp.Required()),
+	), func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id, _ := request.GetArguments()["id"].(float64)
+		if id != 7 {
+			return mcp.NewToolResultError(fmt.Sprintf("user %v not found", id)), nil
+		}
+		return mcp.NewToolResultText(`{"login": "octocat"}`), nil
+	})
+	mcpServer.AddTool(mcp.NewTool("explode"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, fmt.Errorf("kaboom")
+	})
+	httpServer := server.NewTestStreamableHTTPServer(mcpServer)
+	defer httpServer.Close()
+
+	isError := true
+	scenario := &Scenario{
+		Name: "test",
+		Steps: []ScenarioStep{
+			{
+				Name:    "capture id",
+				Tool:    "get_me",
+				Capture: map[string]string{"id": "$.id"},
+			},
+			{
+				Name:      "use captured id",
+				Tool:      "get_user",
+				Arguments: map[string]any{"id": "${id}"},
+				Expect:    StepExpectation{JSONPath: map[string]any{"$.login": "octocat"}},
+			},
+			{
+				Name:      "expected tool error",
+				Tool:      "get_user",
+				Arguments: map[string]any{"id": 1},
+				Expect:    StepExpectation{IsError: &isError, Contains: []string{"not found"}},
+			},
+			{
+				Name:   "expected call error",
+				Tool:   "explode",
+				Expect: StepExpectation{Error: "kaboom"},
+			},
+			{
+				Name:   "failed assertion",
+				Tool:   "get_me",
+				Expect: StepExpectation{Contains: []string{"monalisa"}},
+			},
+			{
+				Name:      "invalid arguments",
+				Tool:      "get_user",
+				Arguments: map[string]any{"id": "seven"},
+			},
+			{
+				Name: "unknown tool",
+				Tool: "nope",
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	suite := runScenario(context.Background(), scenario, serverConfig{URL: httpServer.URL + "/mcp", Timeout: 10 * time.Second}, &out, io.Discard)
+
+	assert.Equal(t, 7, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, 2, suite.Errors)
+	require.Len(t, suite.TestCases, 7)
+	for _, testCase := range suite.TestCases[:4] {
+		assert.Nil(t, testCase.Failure, testCase.Name)
+		assert.Nil(t, testCase.Error, testCase.Name)
+	}
+	assert.Equal(t, `expected result to contain "monalisa"`, suite.TestCases[4].Failure.Message)
+	assert.Equal(t, "invalid arguments: id must be of type number, got string", suite.TestCases[5].Error.Message)
+	assert.Equal(t, "unknown tool: nope", suite.TestCases[6].Error.Message)
+	assert.Contains(t, out.String(), "PASS  use captured id")
+	assert.Contains(t, out.String(), "FAIL  failed assertion")
+
+	// The report is valid JUnit XML
+	path := filepath.Join(t.TempDir(), "report.xml")
+	require.NoError(t, writeJUnit(path, suite))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(data, &report))
+	require.Len(t, report.Suites, 1)
+	assert.Equal(t, 7, report.Suites[0].Tests)
+	assert.Equal(t, "test", report.Suites[0].TestCases[0].ClassName)
+}
+
+func Test_runScenario_noServer(t *testing.T) {
+	scenario := &Scenario{Name: "test", Steps: []ScenarioStep{{Name: "a", Tool: "get_me"}, {Name: "b", Tool: "get_me"}}}
+
+	suite := runScenario(context.Background(), scenario, serverConfig{Timeout: time.Second}, io.Discard, io.Discard)
+
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 2, suite.Errors)
+	assert.Contains(t, suite.TestCases[1].Error.Message, "no server given")
+}
+
+func Test_standInAPI(t *testing.T) {
+	var log bytes.Buffer
+	apiURL, stop, err := startStandInAPI(&StandInAPI{Routes: []StandInRoute{
+		{Method: "GET", Path: "/api/v3/user", Body: map[string]any{"login": "octocat"}},
+		{Path: "/raw/readme?ref=main", Status: http.StatusCreated, Body: "# Hello", Headers: map[string]string{"Content-Type": "text/markdown"}},
+	}}, &log)
+	require.NoError(t, err)
+	defer stop()
+
+	get := func(path string) (*http.Response, string) {
+		resp, err := http.Get(apiURL + path) //nolint:gosec // test server URL
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	resp, body := get("/api/v3/user")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"login": "octocat"}`, body)
+
+	resp, body = get("/raw/readme?ref=main")
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "text/markdown", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "# Hello", body)
+
+	resp, _ = get("/raw/readme?ref=dev")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Contains(t, log.String(), "[api] GET /raw/readme?ref=dev -> no matching route")
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/cobra"
+)
+
+// clientName and clientVersion identify mcpcurl during the initialize handshake
+const (
+	clientName    = "mcpcurl"
+	clientVersion = "0.1.0"
+)
+
+// serverConfig describes how to reach an MCP server, either by starting Command
+// and talking to it over stdio or by connecting to the streamable HTTP endpoint URL.
+type serverConfig struct {
+	Command string
+	Env     []string
+	URL     string
+	Headers map[string]string
+	Timeout time.Duration
+}
+
+// serverConfigFromFlags reads the server configuration from the global flags
+func serverConfigFromFlags(cmd *cobra.Command) (serverConfig, error) {
+	serverCmd, _ := cmd.Flags().GetString("stdio-server-cmd")
+	serverURL, _ := cmd.Flags().GetString("url")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	rawHeaders, _ := cmd.Flags().GetStringArray("header")
+	headers, err := parseHeaders(rawHeaders)
+	if err != nil {
+		return serverConfig{}, err
+	}
+
+	return serverConfig{
+		Command: serverCmd,
+		URL:     serverURL,
+		Headers: headers,
+		Timeout: timeout,
+	}, nil
+}
+
+// newSession connects to the MCP server configured on the command, either by
+// starting it via --stdio-server-cmd or by talking to --url, performs the
+// initialize handshake and returns a client that stays connected until closed.
+// Anything a stdio server writes to stderr is copied to stderrOut line by line.
+func newSession(ctx context.Context, cmd *cobra.Command, stderrOut io.Writer) (*mcpclient.Client, *mcp.InitializeResult, error) {
+	config, err := serverConfigFromFlags(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	return startSession(ctx, config, stderrOut)
+}
+
+// startSession connects to the server described by config and performs the initialize handshake
+func startSession(ctx context.Context, config serverConfig, stderrOut io.Writer) (*mcpclient.Client, *mcp.InitializeResult, error) {
+	client, stderr, err := newClient(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start the client rather than using the New*MCPClient helpers, which only start
+	// the transport and so never deliver notifications to OnNotification handlers.
+	if err := client.Start(ctx); err != nil {
+		_ = client.Close()
+		return nil, nil, fmt.Errorf("failed to start server: %w", err)
+	}
+
+	// The server pipe must be drained, otherwise a chatty server blocks on its stderr writes
+	if stderr != nil {
+		go func() {
+			scanner := bufio.NewScanner(stderr)
+			for scanner.Scan() {
+				_, _ = fmt.Fprintf(stderrOut, "[server] %s\n", scanner.Text())
+			}
+		}()
+	}
+
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{
+		Name:    clientName,
+		Version: clientVersion,
+	}
+
+	// The transport does not fail pending requests when the server exits, so
+	// bound the handshake to notice a server that dies during startup.
+	initCtx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	result, err := client.Initialize(initCtx, initRequest)
+	if err != nil {
+		_ = client.Close()
+		return nil, nil, fmt.Errorf("failed to initialize session: %w", err)
+	}
+
+	return client, result, nil
+}
+
+// newClient creates an unstarted client for the transport selected by config. For stdio servers the
+// command is started right away, and the returned reader is its stderr.
+func newClient(ctx context.Context, config serverConfig) (*mcpclient.Client, io.Reader, error) {
+	if config.URL != "" {
+		httpTransport, err := transport.NewStreamableHTTP(config.URL, transport.WithHTTPHeaders(config.Headers))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create HTTP transport: %w", err)
+		}
+		return mcpclient.NewClient(httpTransport), nil, nil
+	}
+
+	cmdParts := strings.Fields(config.Command)
+	if len(cmdParts) == 0 {
+		return nil, nil, fmt.Errorf("empty command")
+	}
+	cmd := exec.CommandContext(ctx, cmdParts[0], cmdParts[1:]...) //#nosec G204 - the command is given by the user
+	cmd.Env = append(os.Environ(), config.Env...)
+
+	// Unlike a pipe from StdoutPipe, which Wait closes under the reader of the transport, the reader
+	// gets a clean EOF once Wait has copied everything the server wrote
+	stdout, stdoutWriter := io.Pipe()
+	cmd.Stdout = stdoutWriter
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start server: %w", err)
+	}
+
+	return mcpclient.NewClient(&commandTransport{
+		Stdio:        transport.NewIO(stdout, stdin, stderr),
+		cmd:          cmd,
+		stdoutWriter: stdoutWriter,
+	}), stderr, nil
+}
+
+// commandTransport is a stdio transport over the pipes of a server command it started itself
+type commandTransport struct {
+	*transport.Stdio
+	cmd          *exec.Cmd
+	stdoutWriter *io.PipeWriter
+}
+
+// Close closes stdin, so the server exits, waits for it and only then ends the output of the server
+func (t *commandTransport) Close() error {
+	err := t.Stdio.Close()
+	waitErr := t.cmd.Wait()
+	_ = t.stdoutWriter.Close()
+	if err != nil {
+		return err
+	}
+	return waitErr
+}
+
+// parseHeaders parses headers given in the form "Name: value"
+func parseHeaders(rawHeaders []string) (map[string]string, error) {
+	headers := make(map[string]string, len(rawHeaders))
+	for _, header := range rawHeaders {
+		name, value, found := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("header %q must be in the form 'Name: value'", header)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// withSession runs fn against a freshly initialized session, closing it afterwards.
+func withSession(cmd *cobra.Command, fn func(ctx context.Context, client *mcpclient.Client) error) error {
+	// The context is only set once the command is executing, tools are listed before that
+	parent := cmd.Context()
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	client, _, err := newSession(ctx, cmd, os.Stderr)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	return fn(ctx, client)
+}
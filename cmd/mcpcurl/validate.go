@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// schemaResource is the URL the input schema of a tool is compiled under. Only references within the
+// schema itself resolve, nothing is loaded from elsewhere.
+const schemaResource = "mcpcurl://tool/input-schema.json"
+
+// validateArguments checks arguments against a tool's JSON Schema before they are sent
+// to the server. Both values are normalized through JSON first, so callers can pass
+// typed values such as []string or mcp.ToolInputSchema.
+func validateArguments(schema any, arguments any) error {
+	normalizedSchema, err := schemaValue(schema)
+	if err != nil {
+		return fmt.Errorf("failed to read input schema: %w", err)
+	}
+	normalizedArguments, err := schemaValue(arguments)
+	if err != nil {
+		return fmt.Errorf("failed to read arguments: %w", err)
+	}
+	if normalizedArguments == nil {
+		normalizedArguments = map[string]any{}
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+	compiler.UseLoader(noLoader{})
+	if err := compiler.AddResource(schemaResource, normalizedSchema); err != nil {
+		return fmt.Errorf("failed to read input schema: %w", err)
+	}
+	compiled, err := compiler.Compile(schemaResource)
+	if err != nil {
+		return fmt.Errorf("invalid input schema: %w", err)
+	}
+
+	err = compiled.Validate(normalizedArguments)
+	var validationErr *jsonschema.ValidationError
+	if errors.As(err, &validationErr) {
+		return describeValidationError(validationErr, normalizedArguments)
+	}
+	return err
+}
+
+// normalizeJSON converts v into the generic representation encoding/json produces
+func normalizeJSON(v any, out any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// schemaValue converts v into the representation jsonschema validates, with numbers as json.Number
+func schemaValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+}
+
+// noLoader refuses to load schemas referenced by URL, so validating never touches the network or the disk
+type noLoader struct{}
+
+func (noLoader) Load(url string) (any, error) {
+	return nil, fmt.Errorf("loading referenced schema %s is not supported", url)
+}
+
+// describeValidationError picks the first violation of err, ordered by the location of the offending value,
+// and describes it in terms of the argument at that location
+func describeValidationError(err *jsonschema.ValidationError, arguments any) error {
+	violations := collectViolations(err, nil)
+	if len(violations) == 0 {
+		return err
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if pa, pb := strings.Join(a.InstanceLocation, "/"), strings.Join(b.InstanceLocation, "/"); pa != pb {
+			return pa < pb
+		}
+		return violationRank(a.ErrorKind) < violationRank(b.ErrorKind)
+	})
+
+	violation := violations[0]
+	path := argumentPath(violation.InstanceLocation, arguments)
+	return errors.New(describeViolation(path, violation.ErrorKind))
+}
+
+// collectViolations returns the leaves of the error tree, looking through the errors that only group others.
+// anyOf and oneOf are leaves, as the errors of their subschemas are expected for all but the matching one.
+func collectViolations(err *jsonschema.ValidationError, violations []*jsonschema.ValidationError) []*jsonschema.ValidationError {
+	switch err.ErrorKind.(type) {
+	case *kind.Schema, *kind.Group, *kind.AllOf, *kind.Reference:
+		for _, cause := range err.Causes {
+			violations = collectViolations(cause, violations)
+		}
+		return violations
+	}
+	return append(violations, err)
+}
+
+// violationRank orders the violations of the same value like a reader would check them: type first
+func violationRank(k jsonschema.ErrorKind) int {
+	switch k.(type) {
+	case *kind.Type:
+		return 0
+	case *kind.Enum, *kind.Const:
+		return 1
+	case *kind.Required:
+		return 2
+	}
+	return 3
+}
+
+// describeViolation describes a violation by the argument at path
+func describeViolation(path string, errorKind jsonschema.ErrorKind) string {
+	switch k := errorKind.(type) {
+	case *kind.Required:
+		missing := append([]string(nil), k.Missing...)
+		sort.Strings(missing)
+		return fmt.Sprintf("missing required argument: %s", joinPath(path, missing[0]))
+	case *kind.AdditionalProperties:
+		unknown := append([]string(nil), k.Properties...)
+		sort.Strings(unknown)
+		return fmt.Sprintf("unknown argument: %s", joinPath(path, unknown[0]))
+	case *kind.Type:
+		return fmt.Sprintf("%s must be of type %s, got %s", displayPath(path), strings.Join(k.Want, " or "), k.Got)
+	case *kind.Enum:
+		values := make([]string, len(k.Want))
+		for i, e := range k.Want {
+			values[i] = fmt.Sprint(e)
+		}
+		return fmt.Sprintf("%s must be one of: %s", displayPath(path), strings.Join(values, ", "))
+	case *kind.Const:
+		return fmt.Sprintf("%s must be %v", displayPath(path), k.Want)
+	case *kind.MinItems:
+		return fmt.Sprintf("%s must have at least %d items", displayPath(path), k.Want)
+	case *kind.MaxItems:
+		return fmt.Sprintf("%s must have at most %d items", displayPath(path), k.Want)
+	case *kind.MinLength:
+		return fmt.Sprintf("%s must be at least %d characters long", displayPath(path), k.Want)
+	case *kind.MaxLength:
+		return fmt.Sprintf("%s must be at most %d characters long", displayPath(path), k.Want)
+	case *kind.Pattern:
+		return fmt.Sprintf("%s must match pattern %s", displayPath(path), k.Want)
+	case *kind.Format:
+		return fmt.Sprintf("%s must be a valid %s", displayPath(path), k.Want)
+	case *kind.Minimum:
+		return fmt.Sprintf("%s must be >= %s", displayPath(path), ratString(k.Want))
+	case *kind.Maximum:
+		return fmt.Sprintf("%s must be <= %s", displayPath(path), ratString(k.Want))
+	case *kind.ExclusiveMinimum:
+		return fmt.Sprintf("%s must be > %s", displayPath(path), ratString(k.Want))
+	case *kind.ExclusiveMaximum:
+		return fmt.Sprintf("%s must be < %s", displayPath(path), ratString(k.Want))
+	case *kind.AnyOf:
+		return fmt.Sprintf("%s must match at least one of the allowed schemas", displayPath(path))
+	case *kind.OneOf:
+		return fmt.Sprintf("%s must match exactly one of the allowed schemas, matched %d", displayPath(path), len(k.Subschemas))
+	}
+	// Other keywords keep the wording of the validator
+	return fmt.Sprintf("%s: %s", displayPath(path), errorKind.LocalizedString(message.NewPrinter(language.English)))
+}
+
+// ratString formats a schema bound, which the validator reads as a fraction, as a number
+func ratString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	f, _ := r.Float64()
+	return fmt.Sprint(f)
+}
+
+// argumentPath turns the JSON pointer tokens of a value within arguments into a path such as files[0].path,
+// looking at the arguments to tell array indices from property names
+func argumentPath(location []string, arguments any) string {
+	path := ""
+	value := arguments
+	for _, token := range location {
+		switch v := value.(type) {
+		case []any:
+			path = fmt.Sprintf("%s[%s]", path, token)
+			var index int
+			if _, err := fmt.Sscan(token, &index); err == nil && index >= 0 && index < len(v) {
+				value = v[index]
+			} else {
+				value = nil
+			}
+		case map[string]any:
+			path = joinPath(path, token)
+			value = v[token]
+		default:
+			path = joinPath(path, token)
+			value = nil
+		}
+	}
+	return path
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "arguments"
+	}
+	return path
+}
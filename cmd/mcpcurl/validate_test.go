@@ -0,0 +1,219 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pushFilesTool() mcp.Tool {
+	return mcp.NewTool("push_files",
+		mcp.WithString("owner", mcp.Required(), mcp.Pattern(`^[a-z-]+$`)),
+		mcp.WithNumber("perPage", mcp.Min(1), mcp.Max(100)),
+		mcp.WithArray("files",
+			mcp.Required(),
+			mcp.Items(map[string]any{
+				"type":                 "object",
+				"additionalProperties": false,
+				"required":             []string{"path", "content"},
+				"properties": map[string]any{
+					"path":    map[string]any{"type": "string", "minLength": 1},
+					"content": map[string]any{"type": "string"},
+				},
+			}),
+		),
+	)
+}
+
+func Test_validateArguments(t *testing.T) {
+	tool := pushFilesTool()
+
+	tests := []struct {
+		name          string
+		arguments     map[string]any
+		expectedError string
+	}{
+		{
+			name: "valid nested arguments",
+			arguments: map[string]any{
+				"owner":   "octo-org",
+				"perPage": 10,
+				"files":   []map[string]any{{"path": "README.md", "content": "# Hello"}},
+			},
+		},
+		{
+			name:          "missing required argument",
+			arguments:     map[string]any{"owner": "octo"},
+			expectedError: "missing required argument: files",
+		},
+		{
+			name:          "wrong type",
+			arguments:     map[string]any{"owner": "octo", "files": "README.md"},
+			expectedError: "files must be of type array, got string",
+		},
+		{
+			name: "missing nested required argument",
+			arguments: map[string]any{
+				"owner": "octo",
+				"files": []any{map[string]any{"path": "a"}, map[string]any{"content": "b"}},
+			},
+			expectedError: "missing required argument: files[0].content",
+		},
+		{
+			name: "unknown nested argument",
+			arguments: map[string]any{
+				"owner": "octo",
+				"files": []any{map[string]any{"path": "a", "content": "b", "mode": "100644"}},
+			},
+			expectedError: "unknown argument: files[0].mode",
+		},
+		{
+			name: "string too short",
+			arguments: map[string]any{
+				"owner": "octo",
+				"files": []any{map[string]any{"path": "", "content": "b"}},
+			},
+			expectedError: "files[0].path must be at least 1 characters long",
+		},
+		{
+			name:          "pattern mismatch",
+			arguments:     map[string]any{"owner": "Octo", "files": []any{}},
+			expectedError: "owner must match pattern ^[a-z-]+$",
+		},
+		{
+			name:          "number out of range",
+			arguments:     map[string]any{"owner": "octo", "perPage": 500, "files": []any{}},
+			expectedError: "perPage must be <= 100",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateArguments(tool.InputSchema, tc.arguments)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func Test_validateArguments_keywords(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"count":  map[string]any{"type": "integer"},
+			"ref":    map[string]any{"type": []any{"string", "null"}},
+			"state":  map[string]any{"enum": []any{"open", "closed"}},
+			"labels": map[string]any{"type": "array", "maxItems": 2, "items": map[string]any{"type": "string"}},
+			"target": map[string]any{
+				"oneOf": []any{
+					map[string]any{"type": "object", "required": []any{"sha"}},
+					map[string]any{"type": "object", "required": []any{"branch"}},
+				},
+			},
+		},
+		"additionalProperties": map[string]any{"type": "boolean"},
+	}
+
+	assert.NoError(t, validateArguments(schema, map[string]any{
+		"count":  3,
+		"ref":    nil,
+		"state":  "open",
+		"labels": []string{"bug"},
+		"target": map[string]any{"sha": "abc"},
+		"draft":  true,
+	}))
+	assert.EqualError(t, validateArguments(schema, map[string]any{"count": 1.5}), "count must be of type integer, got number")
+	assert.EqualError(t, validateArguments(schema, map[string]any{"state": "merged"}), "state must be one of: open, closed")
+	assert.EqualError(t, validateArguments(schema, map[string]any{"labels": []string{"a", "b", "c"}}), "labels must have at most 2 items")
+	assert.EqualError(t, validateArguments(schema, map[string]any{"draft": "yes"}), "draft must be of type boolean, got string")
+	assert.EqualError(t,
+		validateArguments(schema, map[string]any{"target": map[string]any{"sha": "abc", "branch": "main"}}),
+		"target must match exactly one of the allowed schemas, matched 2")
+}
+
+func Test_validateArguments_references(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"$defs": map[string]any{
+			"file": map[string]any{
+				"type":                 "object",
+				"additionalProperties": false,
+				"required":             []any{"path"},
+				"properties":           map[string]any{"path": map[string]any{"type": "string"}},
+			},
+		},
+		"properties": map[string]any{
+			"files": map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/file"}},
+			"since": map[string]any{"type": "string", "format": "date-time"},
+		},
+	}
+
+	assert.NoError(t, validateArguments(schema, map[string]any{
+		"files": []any{map[string]any{"path": "a"}},
+		"since": "2025-03-04T05:06:07Z",
+	}))
+	assert.EqualError(t, validateArguments(schema, map[string]any{"files": []any{map[string]any{"path": "a", "mode": "x"}}}), "unknown argument: files[0].mode")
+	assert.EqualError(t, validateArguments(schema, map[string]any{"since": "yesterday"}), "since must be a valid date-time")
+
+	// Schemas referenced by URL are never loaded
+	remote := map[string]any{"$ref": "https://example.com/schema.json"}
+	assert.ErrorContains(t, validateArguments(remote, map[string]any{}), "invalid input schema")
+}
+
+func Test_readArgumentsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "args.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte("owner: octo\nperPage: 5\nfiles:\n  - path: README.md\n    content: hello\n"), 0600))
+	arguments, err := readArgumentsFile(yamlPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"owner":   "octo",
+		"perPage": float64(5),
+		"files":   []any{map[string]any{"path": "README.md", "content": "hello"}},
+	}, arguments)
+	assert.NoError(t, validateArguments(pushFilesTool().InputSchema, arguments))
+
+	jsonPath := filepath.Join(dir, "args.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"owner": "octo"}`), 0600))
+	arguments, err = readArgumentsFile(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"owner": "octo"}, arguments)
+
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`[1, 2]`), 0600))
+	_, err = readArgumentsFile(jsonPath)
+	assert.Error(t, err)
+}
+
+func Test_parseHeaders(t *testing.T) {
+	headers, err := parseHeaders([]string{"Authorization: Bearer abc", "X-Empty:"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer abc", "X-Empty": ""}, headers)
+
+	_, err = parseHeaders([]string{"no-colon"})
+	assert.EqualError(t, err, `header "no-colon" must be in the form 'Name: value'`)
+}
+
+func Test_ToolUnmarshalJSON(t *testing.T) {
+	var tool Tool
+	require.NoError(t, tool.UnmarshalJSON([]byte(`{
+		"name": "push_files",
+		"inputSchema": {
+			"type": "object",
+			"properties": {"files": {"type": "array", "items": {"type": "object", "properties": {"path": {"type": "string"}}}}},
+			"required": ["files"]
+		}
+	}`)))
+
+	assert.Equal(t, "push_files", tool.Name)
+	assert.Equal(t, []string{"files"}, tool.InputSchema.Required)
+	assert.Equal(t, "object", tool.InputSchema.Properties["files"].Items.Type)
+	assert.Equal(t, []any{"files"}, tool.RawInputSchema["required"])
+}
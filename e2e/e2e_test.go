@@ -18,6 +18,7 @@ import (
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/workspace"
 	gogithub "github.com/google/go-github/v72/github"
 	mcpClient "github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -172,11 +173,15 @@ func setupMCPClient(t *testing.T, options ...clientOption) *mcpClient.Client {
 			enabledToolsets = github.DefaultTools
 		}
 
+		workspaces := workspace.NewManager(workspace.DefaultLimits)
+		t.Cleanup(func() { _ = workspaces.Close() })
+
 		ghServer, err := ghmcp.NewMCPServer(ghmcp.MCPServerConfig{
 			Token:           token,
 			EnabledToolsets: enabledToolsets,
 			Host:            getE2EHost(),
 			Translator:      translations.NullTranslationHelper,
+			Workspaces:      workspaces,
 		})
 		require.NoError(t, err, "expected to construct MCP server successfully")
 
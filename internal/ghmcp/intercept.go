@@ -0,0 +1,252 @@
+package ghmcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// stdioSessionID is the ID of the only session of the stdio transport of mcp-go
+const stdioSessionID = "stdio"
+
+// interceptedRequestTimeout is how long answering an intercepted request may take
+const interceptedRequestTimeout = 30 * time.Second
+
+// messageHandler answers a request mcp-go does not handle. It returns false for any other message.
+type messageHandler func(ctx context.Context, message json.RawMessage) (mcp.JSONRPCMessage, bool)
+
+// syncWriter serializes writes, so that messages written by different goroutines do not interleave
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// capabilitiesWriter adds capabilities mcp-go cannot declare to the responses to initialize requests, which
+// are told apart by the IDs passed to expect. It relies on every message being written in a single write, as
+// mcp-go does.
+type capabilitiesWriter struct {
+	w            io.Writer
+	capabilities map[string]any
+
+	mu sync.Mutex
+	// initializeIDs are the JSON encoded IDs of the initialize requests not responded to yet
+	initializeIDs map[string]bool
+}
+
+// expect marks the request with the given JSON encoded ID as an initialize request
+func (c *capabilitiesWriter) expect(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.initializeIDs == nil {
+		c.initializeIDs = map[string]bool{}
+	}
+	c.initializeIDs[compactJSON(id)] = true
+}
+
+func (c *capabilitiesWriter) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	pending := len(c.initializeIDs) > 0
+	c.mu.Unlock()
+	if !pending {
+		return c.w.Write(p)
+	}
+
+	var message map[string]json.RawMessage
+	if err := json.Unmarshal(p, &message); err != nil || message["id"] == nil {
+		return c.w.Write(p)
+	}
+	id := compactJSON(string(message["id"]))
+	c.mu.Lock()
+	initialize := c.initializeIDs[id]
+	delete(c.initializeIDs, id)
+	c.mu.Unlock()
+	if !initialize {
+		return c.w.Write(p)
+	}
+
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(message["result"], &result); err != nil || result["capabilities"] == nil {
+		return c.w.Write(p)
+	}
+	var capabilities map[string]any
+	if err := json.Unmarshal(result["capabilities"], &capabilities); err != nil {
+		return c.w.Write(p)
+	}
+	for name, capability := range c.capabilities {
+		capabilities[name] = capability
+	}
+
+	var err error
+	if result["capabilities"], err = json.Marshal(capabilities); err != nil {
+		return 0, fmt.Errorf("failed to marshal capabilities: %w", err)
+	}
+	if message["result"], err = json.Marshal(result); err != nil {
+		return 0, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	if err := writeMessage(c.w, message); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// compactJSON removes the insignificant space from JSON, so that equal IDs compare equal
+func compactJSON(s string) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(s)); err != nil {
+		return s
+	}
+	return b.String()
+}
+
+// interceptRequests answers the requests read from in whose method one of the handlers answers, such as
+// resources/subscribe or completion/complete, by writing the responses to out. Every other message is
+// passed on to the returned reader. out is shared with the server, which writes to it too. initialize is
+// called with the JSON encoded ID of every initialize request before it is passed on.
+//
+// Each request is answered in its own goroutine within timeout, as handlers may make several API calls,
+// so that the messages that follow are read meanwhile. notifications/cancelled notifications cancel the
+// requests they refer to, which are then left unanswered, and are passed on as well.
+func interceptRequests(ctx context.Context, in io.Reader, out *syncWriter, timeout time.Duration, handlers map[mcp.MCPMethod]messageHandler, initialize func(id string)) io.Reader {
+	pr, pw := io.Pipe()
+	i := &interceptor{
+		out:        out,
+		pipe:       pw,
+		timeout:    timeout,
+		handlers:   handlers,
+		initialize: initialize,
+		requests:   map[string]*interceptedRequest{},
+	}
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 && !i.intercept(ctx, line) {
+				if _, writeErr := pw.Write(line); writeErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				// The requests being answered still get their responses
+				i.wg.Wait()
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return pr
+}
+
+// interceptor tracks the requests intercepted by interceptRequests
+type interceptor struct {
+	out        *syncWriter
+	pipe       *io.PipeWriter
+	timeout    time.Duration
+	handlers   map[mcp.MCPMethod]messageHandler
+	initialize func(id string)
+
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	requests map[string]*interceptedRequest
+}
+
+// interceptedRequest is a request being answered
+type interceptedRequest struct {
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// intercept starts answering a message if one of the handlers answers its method, and returns false if
+// the message is left to the server
+func (i *interceptor) intercept(ctx context.Context, line []byte) bool {
+	var message struct {
+		ID     json.RawMessage `json:"id"`
+		Method mcp.MCPMethod   `json:"method"`
+		Params struct {
+			RequestID json.RawMessage `json:"requestId"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(line, &message); err != nil {
+		return false
+	}
+	if message.Method == mcp.MethodInitialize && len(message.ID) > 0 && i.initialize != nil {
+		i.initialize(string(message.ID))
+		return false
+	}
+	if message.Method == "notifications/cancelled" {
+		i.cancel(string(message.Params.RequestID))
+		return false
+	}
+	handler, ok := i.handlers[message.Method]
+	if !ok || len(message.ID) == 0 || string(message.ID) == "null" {
+		return false
+	}
+
+	id := string(message.ID)
+	requestCtx, cancel := context.WithTimeout(ctx, i.timeout)
+	request := &interceptedRequest{cancel: cancel}
+	i.mu.Lock()
+	i.requests[id] = request
+	i.mu.Unlock()
+
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+		defer cancel()
+		response, ok := handler(requestCtx, line)
+
+		i.mu.Lock()
+		if i.requests[id] == request {
+			delete(i.requests, id)
+		}
+		cancelled := request.cancelled
+		i.mu.Unlock()
+
+		switch {
+		case !ok:
+			_, _ = i.pipe.Write(line)
+		case cancelled:
+			// The client does not expect a response to a request it cancelled
+		default:
+			if err := writeMessage(i.out, response); err != nil {
+				_ = i.pipe.CloseWithError(err)
+			}
+		}
+	}()
+	return true
+}
+
+// cancel cancels the request with the given JSON encoded ID, if it is being answered
+func (i *interceptor) cancel(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if request, ok := i.requests[id]; ok {
+		request.cancelled = true
+		request.cancel()
+	}
+}
+
+// writeMessage writes a JSON-RPC message followed by a newline, in a single write
+func writeMessage(w io.Writer, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,139 @@
+package ghmcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	gogithub "github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterceptRequests(t *testing.T) {
+	getClient := func(_ context.Context) (*gogithub.Client, error) {
+		return gogithub.NewClient(nil), nil
+	}
+	notify := func(_, _ string) error { return nil }
+	subscriptions := github.NewResourceSubscriptions(getClient, notify, github.DefaultResourcePollInterval)
+	completions := github.NewCompletions(getClient, github.DefaultCompletionCacheTTL)
+	subscribe := func(ctx context.Context, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+		return subscriptions.HandleMessage(ctx, stdioSessionID, message)
+	}
+	handlers := map[mcp.MCPMethod]messageHandler{
+		"completion/complete":   completions.HandleMessage,
+		"resources/subscribe":   subscribe,
+		"resources/unsubscribe": subscribe,
+	}
+
+	in := strings.Join([]string{
+		`{"jsonrpc": "2.0", "id": "init", "method": "initialize", "params": {}}`,
+		`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`,
+		// Resources pinned to a commit are accepted without an API call
+		`{"jsonrpc": "2.0", "id": 2, "method": "resources/subscribe", "params": {"uri": "repo://owner/repo/sha/abc/contents/README.md"}}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "resources/unsubscribe", "params": {"uri": "file:///etc/passwd"}}`,
+		// Branches cannot be completed before the repository is known, which needs no API call either
+		`{"jsonrpc": "2.0", "id": 4, "method": "completion/complete", "params": {"ref": {"type": "ref/resource", "uri": "repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}"}, "argument": {"name": "branch", "value": "ma"}}}`,
+		`{"jsonrpc": "2.0", "method": "notifications/initialized"}`,
+	}, "\n")
+	var out bytes.Buffer
+
+	var initializeIDs []string
+	initialize := func(id string) { initializeIDs = append(initializeIDs, id) }
+
+	passed, err := io.ReadAll(interceptRequests(context.Background(), strings.NewReader(in), &syncWriter{w: &out}, time.Second, handlers, initialize))
+	require.NoError(t, err)
+
+	assert.Equal(t, strings.Join([]string{
+		`{"jsonrpc": "2.0", "id": "init", "method": "initialize", "params": {}}`,
+		`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`,
+		`{"jsonrpc": "2.0", "method": "notifications/initialized"}`,
+	}, "\n"), string(passed))
+	assert.Equal(t, []string{`"init"`}, initializeIDs)
+	// Requests are answered concurrently, in any order
+	assert.ElementsMatch(t, []string{
+		`{"jsonrpc":"2.0","id":2,"result":{}}`,
+		`{"jsonrpc":"2.0","id":3,"error":{"code":-32602,"message":"unsupported resource URI file:///etc/passwd, only repo:// resources can be subscribed to"}}`,
+		`{"jsonrpc":"2.0","id":4,"result":{"completion":{"values":[]}}}`,
+	}, strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"))
+}
+
+func TestInterceptRequests_Concurrency(t *testing.T) {
+	started := make(chan struct{}, 2)
+	// slow answers once its context is done, with the reason
+	slow := func(ctx context.Context, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+		var request struct {
+			ID any `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(message, &request))
+		started <- struct{}{}
+		<-ctx.Done()
+		return mcp.NewJSONRPCError(mcp.NewRequestId(request.ID), mcp.INTERNAL_ERROR, ctx.Err().Error(), nil), true
+	}
+
+	inReader, inWriter := io.Pipe()
+	var out bytes.Buffer
+	passed := bufio.NewReader(interceptRequests(context.Background(), inReader, &syncWriter{w: &out}, 50*time.Millisecond, map[mcp.MCPMethod]messageHandler{"test/slow": slow}, nil))
+
+	write := func(message string) {
+		t.Helper()
+		_, err := inWriter.Write([]byte(message + "\n"))
+		require.NoError(t, err)
+	}
+
+	// Messages are read while a slow request is answered
+	write(`{"jsonrpc": "2.0", "id": 1, "method": "test/slow"}`)
+	<-started
+	write(`{"jsonrpc": "2.0", "id": 2, "method": "tools/list"}`)
+	line, err := passed.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, `{"jsonrpc": "2.0", "id": 2, "method": "tools/list"}`+"\n", line)
+
+	// Cancelled requests are left unanswered, and the server is notified too
+	write(`{"jsonrpc": "2.0", "method": "notifications/cancelled", "params": {"requestId": 1}}`)
+	line, err = passed.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, `{"jsonrpc": "2.0", "method": "notifications/cancelled", "params": {"requestId": 1}}`+"\n", line)
+
+	// Requests taking too long are answered once they time out
+	write(`{"jsonrpc": "2.0", "id": "3", "method": "test/slow"}`)
+	<-started
+	require.NoError(t, inWriter.Close())
+	_, err = io.ReadAll(passed)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"jsonrpc":"2.0","id":"3","error":{"code":-32603,"message":"context deadline exceeded"}}`+"\n", out.String())
+}
+
+func TestCapabilitiesWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := &capabilitiesWriter{w: &out, capabilities: map[string]any{"completions": struct{}{}}}
+
+	// Only the response to the initialize request gets the capabilities, whatever the other responses contain
+	w.expect("0")
+	unrelated := `{"jsonrpc":"2.0","id":5,"result":{"protocolVersion":"2025-03-26","capabilities":{}}}` + "\n"
+	_, err := w.Write([]byte(unrelated))
+	require.NoError(t, err)
+
+	initialize := `{"jsonrpc":"2.0","id":0,"result":{"protocolVersion":"2025-03-26","capabilities":{"tools":{}},"serverInfo":{"name":"github-mcp-server","version":"dev"}}}` + "\n"
+	n, err := w.Write([]byte(initialize))
+	require.NoError(t, err)
+	assert.Equal(t, len(initialize), n)
+
+	other := `{"jsonrpc":"2.0","id":1,"result":{"tools":[]}}` + "\n"
+	_, err = w.Write([]byte(other))
+	require.NoError(t, err)
+
+	lines := strings.SplitAfter(out.String(), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, unrelated, lines[0])
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":0,"result":{"protocolVersion":"2025-03-26","capabilities":{"completions":{},"tools":{}},"serverInfo":{"name":"github-mcp-server","version":"dev"}}}`, lines[1])
+	assert.Equal(t, other, lines[2])
+}
@@ -2,6 +2,7 @@ package ghmcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -11,12 +12,14 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/github"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/workspace"
 	gogithub "github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -47,12 +50,36 @@ type MCPServerConfig struct {
 
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
+
+	// Workspaces keeps the repository archives downloaded by tools. It is owned by the caller, which must
+	// close it to remove the archives. The tools downloading archives are left out if nil
+	Workspaces *workspace.Manager
 }
 
+// NewMCPServer creates a server for the given config. mcp-go cannot answer completion/complete and
+// resources/subscribe requests, which only the stdio server started by RunStdioServer intercepts, so
+// servers created here offer neither completions nor subscriptions to resources.
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
+	ghServer, _, err := newMCPServer(cfg, 0)
+	return ghServer, err
+}
+
+// protocolExtensions implement the requests of the protocol mcp-go does not handle, which the transport
+// has to pass to them
+type protocolExtensions struct {
+	// subscriptions answers resources/subscribe requests, nil if subscriptions are disabled
+	subscriptions *github.ResourceSubscriptions
+	// completions answers completion/complete requests
+	completions *github.Completions
+}
+
+// newMCPServer creates a server for the given config, along with its protocol extensions. Subscriptions to
+// resources are polled every pollInterval, and are disabled if pollInterval is 0.
+func newMCPServer(cfg MCPServerConfig, pollInterval time.Duration) (*server.MCPServer, protocolExtensions, error) {
+	var extensions protocolExtensions
 	apiHost, err := parseAPIHost(cfg.Host)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse API host: %w", err)
+		return nil, extensions, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
 	// Construct our REST client
@@ -100,7 +127,30 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		},
 	}
 
-	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
+	getClient := func(_ context.Context) (*gogithub.Client, error) {
+		return restClient, nil // closing over client
+	}
+
+	var ghServer *server.MCPServer
+	if pollInterval > 0 {
+		notify := func(sessionID, uri string) error {
+			return ghServer.SendNotificationToSpecificClient(sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+		}
+		subscriptions := github.NewResourceSubscriptions(getClient, notify, pollInterval)
+		hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+			subscriptions.RemoveSession(session.SessionID())
+		})
+		extensions.subscriptions = subscriptions
+	}
+	extensions.completions = github.NewCompletions(getClient, github.DefaultCompletionCacheTTL)
+
+	if workspaces := cfg.Workspaces; workspaces != nil {
+		hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+			workspaces.RemoveSession(session.SessionID())
+		})
+	}
+
+	ghServer = github.NewServer(cfg.Version, server.WithHooks(hooks), server.WithResourceCapabilities(extensions.subscriptions != nil, true))
 
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
@@ -113,10 +163,6 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
-	getClient := func(_ context.Context) (*gogithub.Client, error) {
-		return restClient, nil // closing over client
-	}
-
 	getGQLClient := func(_ context.Context) (*githubv4.Client, error) {
 		return gqlClient, nil // closing over client
 	}
@@ -126,15 +172,15 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 		}
-		return raw.NewClient(client, apiHost.rawURL), nil // closing over client
+		return raw.NewClient(client, apiHost.rawURL, raw.WithLFSURL(apiHost.lfsURL)), nil // closing over client
 	}
 
 	// Create default toolsets
-	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Translator)
+	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, getRawClient, cfg.Workspaces, cfg.Translator)
 	err = tsg.EnableToolsets(enabledToolsets)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
+		return nil, extensions, fmt.Errorf("failed to enable toolsets: %w", err)
 	}
 
 	// Register all mcp functionality with the server
@@ -145,7 +191,7 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		dynamic.RegisterTools(ghServer)
 	}
 
-	return ghServer, nil
+	return ghServer, extensions, nil
 }
 
 type StdioServerConfig struct {
@@ -173,6 +219,21 @@ type StdioServerConfig struct {
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
 
+	// Locales are the locales to translate tool descriptions to, in order of preference
+	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#localized-descriptions
+	Locales []string
+
+	// LocalesDir is an optional directory of locale files overriding the bundled ones key by key
+	LocalesDir string
+
+	// TranslationsConfigPath is the JSON file holding translation overrides, which is also the file
+	// translations are exported to, defaults to github-mcp-server-config.json
+	TranslationsConfigPath string
+
+	// ResourcePollInterval is how often the refs of subscribed resources are polled for changes,
+	// 0 disables resource subscriptions
+	ResourcePollInterval time.Duration
+
 	// EnableCommandLogging indicates if we should log commands
 	EnableCommandLogging bool
 
@@ -186,9 +247,33 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	t, dumpTranslations := translations.TranslationHelper()
+	logrusLogger := logrus.New()
+	if cfg.LogFilePath != "" {
+		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+
+		logrusLogger.SetLevel(logrus.DebugLevel)
+		logrusLogger.SetOutput(file)
+	}
+
+	t, dumpTranslations, err := translations.NewTranslationHelper(translations.Options{
+		ConfigPath: cfg.TranslationsConfigPath,
+		LocalesDir: cfg.LocalesDir,
+		Locales:    cfg.Locales,
+		Logger:     logrusLogger,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load translations: %w", err)
+	}
+
+	// The archives downloaded by tools are removed on shutdown, and once idle for their TTL
+	workspaces := workspace.NewManager(workspace.DefaultLimits)
+	defer func() { _ = workspaces.Close() }()
+	go workspaces.Run(ctx, workspace.DefaultExpiryInterval)
 
-	ghServer, err := NewMCPServer(MCPServerConfig{
+	ghServer, extensions, err := newMCPServer(MCPServerConfig{
 		Version:         cfg.Version,
 		Host:            cfg.Host,
 		Token:           cfg.Token,
@@ -196,29 +281,22 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		DynamicToolsets: cfg.DynamicToolsets,
 		ReadOnly:        cfg.ReadOnly,
 		Translator:      t,
-	})
+		Workspaces:      workspaces,
+	}, cfg.ResourcePollInterval)
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
 	}
 
 	stdioServer := server.NewStdioServer(ghServer)
 
-	logrusLogger := logrus.New()
-	if cfg.LogFilePath != "" {
-		file, err := os.OpenFile(cfg.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
-		if err != nil {
-			return fmt.Errorf("failed to open log file: %w", err)
-		}
-
-		logrusLogger.SetLevel(logrus.DebugLevel)
-		logrusLogger.SetOutput(file)
-	}
 	stdLogger := log.New(logrusLogger.Writer(), "stdioserver", 0)
 	stdioServer.SetErrorLogger(stdLogger)
 
 	if cfg.ExportTranslations {
 		// Once server is initialized, all translations are loaded
-		dumpTranslations()
+		if err := dumpTranslations(); err != nil {
+			return fmt.Errorf("failed to export translations: %w", err)
+		}
 	}
 
 	// Start listening for messages
@@ -230,9 +308,20 @@ func RunStdioServer(cfg StdioServerConfig) error {
 			loggedIO := mcplog.NewIOLogger(in, out, logrusLogger)
 			in, out = loggedIO, loggedIO
 		}
+		capabilities := &capabilitiesWriter{w: out, capabilities: map[string]any{"completions": struct{}{}}}
+		syncOut := &syncWriter{w: capabilities}
+		handlers := map[mcp.MCPMethod]messageHandler{"completion/complete": extensions.completions.HandleMessage}
+		if subscriptions := extensions.subscriptions; subscriptions != nil {
+			subscribe := func(ctx context.Context, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+				return subscriptions.HandleMessage(ctx, stdioSessionID, message)
+			}
+			handlers["resources/subscribe"], handlers["resources/unsubscribe"] = subscribe, subscribe
+			go subscriptions.Run(ctx)
+		}
+		in = interceptRequests(ctx, in, syncOut, interceptedRequestTimeout, handlers, capabilities.expect)
 		// enable GitHub errors in the context
 		ctx := errors.ContextWithGitHubErrors(ctx)
-		errC <- stdioServer.Listen(ctx, in, out)
+		errC <- stdioServer.Listen(ctx, in, syncOut)
 	}()
 
 	// Output github-mcp-server string
@@ -256,6 +345,7 @@ type apiHost struct {
 	graphqlURL  *url.URL
 	uploadURL   *url.URL
 	rawURL      *url.URL
+	lfsURL      *url.URL
 }
 
 func newDotcomHost() (apiHost, error) {
@@ -279,11 +369,17 @@ func newDotcomHost() (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse dotcom Raw URL: %w", err)
 	}
 
+	lfsURL, err := url.Parse("https://github.com/")
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse dotcom LFS URL: %w", err)
+	}
+
 	return apiHost{
 		baseRESTURL: baseRestURL,
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		lfsURL:      lfsURL,
 	}, nil
 }
 
@@ -318,11 +414,17 @@ func newGHECHost(hostname string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse GHEC Raw URL: %w", err)
 	}
 
+	lfsURL, err := url.Parse(fmt.Sprintf("https://%s/", u.Hostname()))
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse GHEC LFS URL: %w", err)
+	}
+
 	return apiHost{
 		baseRESTURL: restURL,
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		lfsURL:      lfsURL,
 	}, nil
 }
 
@@ -332,34 +434,40 @@ func newGHESHost(hostname string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse GHES URL: %w", err)
 	}
 
-	restURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Hostname()))
+	restURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES REST URL: %w", err)
 	}
 
-	gqlURL, err := url.Parse(fmt.Sprintf("%s://%s/api/graphql", u.Scheme, u.Hostname()))
+	gqlURL, err := url.Parse(fmt.Sprintf("%s://%s/api/graphql", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES GraphQL URL: %w", err)
 	}
 
-	uploadURL, err := url.Parse(fmt.Sprintf("%s://%s/api/uploads/", u.Scheme, u.Hostname()))
+	uploadURL, err := url.Parse(fmt.Sprintf("%s://%s/api/uploads/", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES Upload URL: %w", err)
 	}
-	rawURL, err := url.Parse(fmt.Sprintf("%s://%s/raw/", u.Scheme, u.Hostname()))
+	rawURL, err := url.Parse(fmt.Sprintf("%s://%s/raw/", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES Raw URL: %w", err)
 	}
 
+	lfsURL, err := url.Parse(fmt.Sprintf("%s://%s/", u.Scheme, u.Host))
+	if err != nil {
+		return apiHost{}, fmt.Errorf("failed to parse GHES LFS URL: %w", err)
+	}
+
 	return apiHost{
 		baseRESTURL: restURL,
 		graphqlURL:  gqlURL,
 		uploadURL:   uploadURL,
 		rawURL:      rawURL,
+		lfsURL:      lfsURL,
 	}, nil
 }
 
-// Note that this does not handle ports yet, so development environments are out.
+// Ports are kept for GHES style hosts, so a local stand-in API such as http://localhost:8080 can be used in development.
 func parseAPIHost(s string) (apiHost, error) {
 	if s == "" {
 		return newDotcomHost()
@@ -370,7 +478,8 @@ func parseAPIHost(s string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("could not parse host as URL: %s", s)
 	}
 
-	if u.Scheme == "" {
+	// Without a scheme, a host with a port such as ghes.example.com:8443 parses as the scheme ghes.example.com
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
 		return apiHost{}, fmt.Errorf("host must have a scheme (http or https): %s", s)
 	}
 
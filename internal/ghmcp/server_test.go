@@ -0,0 +1,179 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/workspace"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseAPIHost(t *testing.T) {
+	tests := []struct {
+		name           string
+		host           string
+		expectError    bool
+		expectedREST   string
+		expectedGQL    string
+		expectedUpload string
+		expectedRaw    string
+	}{
+		{
+			name:           "defaults to dotcom",
+			host:           "",
+			expectedREST:   "https://api.github.com/",
+			expectedGQL:    "https://api.github.com/graphql",
+			expectedUpload: "https://uploads.github.com",
+			expectedRaw:    "https://raw.githubusercontent.com/",
+		},
+		{
+			name:           "dotcom",
+			host:           "https://github.com",
+			expectedREST:   "https://api.github.com/",
+			expectedGQL:    "https://api.github.com/graphql",
+			expectedUpload: "https://uploads.github.com",
+			expectedRaw:    "https://raw.githubusercontent.com/",
+		},
+		{
+			name:           "GHEC",
+			host:           "https://tenant.ghe.com",
+			expectedREST:   "https://api.tenant.ghe.com/",
+			expectedGQL:    "https://api.tenant.ghe.com/graphql",
+			expectedUpload: "https://uploads.tenant.ghe.com",
+			expectedRaw:    "https://raw.tenant.ghe.com/",
+		},
+		{
+			name:           "GHES without a port",
+			host:           "https://ghes.example.com",
+			expectedREST:   "https://ghes.example.com/api/v3/",
+			expectedGQL:    "https://ghes.example.com/api/graphql",
+			expectedUpload: "https://ghes.example.com/api/uploads/",
+			expectedRaw:    "https://ghes.example.com/raw/",
+		},
+		{
+			name:           "GHES with a port",
+			host:           "https://ghes.example.com:8443",
+			expectedREST:   "https://ghes.example.com:8443/api/v3/",
+			expectedGQL:    "https://ghes.example.com:8443/api/graphql",
+			expectedUpload: "https://ghes.example.com:8443/api/uploads/",
+			expectedRaw:    "https://ghes.example.com:8443/raw/",
+		},
+		{
+			name:           "local stand-in API",
+			host:           "http://localhost:8080",
+			expectedREST:   "http://localhost:8080/api/v3/",
+			expectedGQL:    "http://localhost:8080/api/graphql",
+			expectedUpload: "http://localhost:8080/api/uploads/",
+			expectedRaw:    "http://localhost:8080/raw/",
+		},
+		{
+			name:        "host without a scheme",
+			host:        "ghes.example.com",
+			expectError: true,
+		},
+		{
+			name:        "host with a port without a scheme",
+			host:        "ghes.example.com:8443",
+			expectError: true,
+		},
+		{
+			name:        "GHEC over HTTP",
+			host:        "http://tenant.ghe.com",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			host, err := parseAPIHost(tc.host)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedREST, host.baseRESTURL.String())
+			assert.Equal(t, tc.expectedGQL, host.graphqlURL.String())
+			assert.Equal(t, tc.expectedUpload, host.uploadURL.String())
+			assert.Equal(t, tc.expectedRaw, host.rawURL.String())
+		})
+	}
+}
+
+func Test_NewGHESHost(t *testing.T) {
+	tests := []struct {
+		name         string
+		host         string
+		expectedREST string
+		expectedLFS  string
+	}{
+		{
+			name:         "without a port",
+			host:         "https://ghes.example.com",
+			expectedREST: "https://ghes.example.com/api/v3/",
+			expectedLFS:  "https://ghes.example.com/",
+		},
+		{
+			name:         "with a port",
+			host:         "https://ghes.example.com:8443",
+			expectedREST: "https://ghes.example.com:8443/api/v3/",
+			expectedLFS:  "https://ghes.example.com:8443/",
+		},
+		{
+			name:         "over HTTP with a port",
+			host:         "http://localhost:8080",
+			expectedREST: "http://localhost:8080/api/v3/",
+			expectedLFS:  "http://localhost:8080/",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			host, err := newGHESHost(tc.host)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedREST, host.baseRESTURL.String())
+			assert.Equal(t, tc.expectedLFS, host.lfsURL.String())
+		})
+	}
+}
+
+func Test_NewMCPServer_Workspaces(t *testing.T) {
+	// listTools lists the names of the tools of a server created with the given workspaces
+	listTools := func(workspaces *workspace.Manager) []string {
+		ghServer, err := NewMCPServer(MCPServerConfig{
+			Token:           "token",
+			EnabledToolsets: []string{"repos"},
+			Translator:      translations.NullTranslationHelper,
+			Workspaces:      workspaces,
+		})
+		require.NoError(t, err)
+
+		response := ghServer.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`))
+		result, ok := response.(mcp.JSONRPCResponse)
+		require.True(t, ok, "expected a response, got %#v", response)
+		tools, ok := result.Result.(mcp.ListToolsResult)
+		require.True(t, ok, "expected tools, got %#v", result.Result)
+		names := make([]string, len(tools.Tools))
+		for i, tool := range tools.Tools {
+			names[i] = tool.Name
+		}
+		return names
+	}
+
+	// Without a manager to remove them, no archives are downloaded
+	names := listTools(nil)
+	assert.Contains(t, names, "get_file_contents")
+	assert.NotContains(t, names, "download_repository_archive")
+	assert.NotContains(t, names, "get_workspace_file_contents")
+
+	workspaces := workspace.NewManager(workspace.DefaultLimits)
+	t.Cleanup(func() { _ = workspaces.Close() })
+	names = listTools(workspaces)
+	assert.Contains(t, names, "download_repository_archive")
+	assert.Contains(t, names, "get_workspace_file_contents")
+}
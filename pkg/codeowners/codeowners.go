@@ -0,0 +1,170 @@
+// Package codeowners parses CODEOWNERS files and finds the owners of paths the way GitHub does.
+package codeowners
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Locations are the paths where GitHub looks for a CODEOWNERS file, in order. The first one found is used.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Rule is a line of a CODEOWNERS file assigning owners to the paths matching a pattern.
+type Rule struct {
+	Pattern string
+	// Owners are users (@login), teams (@org/team) or email addresses. Rules without owners make paths unowned.
+	Owners []string
+	Line   int
+
+	re *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	Rules []*Rule
+}
+
+// Parse parses a CODEOWNERS file. Lines with invalid syntax are skipped, as GitHub does.
+func Parse(content string) *File {
+	f := &File{}
+	for i, line := range strings.Split(content, "\n") {
+		fields := splitFields(strings.TrimSuffix(line, "\r"))
+		if len(fields) == 0 {
+			continue
+		}
+		re, ok := patternRegexp(fields[0])
+		if !ok {
+			continue
+		}
+		f.Rules = append(f.Rules, &Rule{
+			Pattern: fields[0],
+			Owners:  fields[1:],
+			Line:    i + 1,
+			re:      re,
+		})
+	}
+	return f
+}
+
+// Match returns the rule applying to path, which is the last one matching it, or nil if no rule matches.
+func (f *File) Match(path string) *Rule {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].re.MatchString(path) {
+			return f.Rules[i]
+		}
+	}
+	return nil
+}
+
+// splitFields splits a line into whitespace separated fields, up to a comment. Backslashes escape spaces and #.
+func splitFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	inField := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			// Keep the escape, the pattern needs to tell escaped characters apart
+			field.WriteByte(c)
+			field.WriteByte(line[i+1])
+			i++
+			inField = true
+		case c == ' ' || c == '\t':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		case c == '#' && !inField:
+			i = len(line)
+		default:
+			field.WriteByte(c)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// patternRegexp converts a CODEOWNERS pattern to a regular expression matching the paths it applies to.
+// Patterns follow the rules of gitignore, except that negation and character ranges are not supported:
+//   - patterns containing a slash before their end are relative to the root of the repository, others match at any depth
+//   - a pattern matching a directory matches all files inside it, unless it ends with /*
+//   - * and ? match anything but a slash, and ** matches any number of directories
+func patternRegexp(pattern string) (*regexp.Regexp, bool) {
+	if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+		return nil, false
+	}
+	dirOnly := strings.HasSuffix(pattern, "/") && !strings.HasSuffix(pattern, "\\/")
+	p := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, false
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("(?:^|/)")
+	}
+	segments := strings.Split(p, "/")
+	last := len(segments) - 1
+	for i, segment := range segments {
+		switch {
+		case segment == "**" && i == last:
+			b.WriteString(".*")
+		case segment == "**":
+			b.WriteString("(?:.*/)?")
+			continue
+		default:
+			b.WriteString(globRegexp(segment))
+		}
+		if i < last {
+			b.WriteString("/")
+		}
+	}
+	switch {
+	case segments[last] == "**":
+		b.WriteString("$")
+	case dirOnly:
+		b.WriteString("/.*$")
+	case segments[last] == "*":
+		// docs/* matches the files of docs, not those of its subdirectories
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, false
+	}
+	return re, true
+}
+
+// globRegexp converts a path segment with wildcards to a regular expression
+func globRegexp(segment string) string {
+	var b strings.Builder
+	for i := 0; i < len(segment); i++ {
+		switch c := segment[i]; c {
+		case '*':
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(segment) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(segment[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
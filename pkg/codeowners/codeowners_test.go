@@ -0,0 +1,137 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	f := Parse("# Owners of everything\r\n*       @global-owner1 @global-owner2\n\n*.js    @js-owner # inline comment\n/build/logs/ @doctocat\n!negated @nobody\n[Tt]ests/ @nobody\nfile\\ with\\ spaces.txt @spacey\n\\#hash.md docs@example.com\n/apps/github\n")
+
+	require.Len(t, f.Rules, 6)
+	assert.Equal(t, &Rule{Pattern: "*", Owners: []string{"@global-owner1", "@global-owner2"}, Line: 2, re: f.Rules[0].re}, f.Rules[0])
+	assert.Equal(t, []string{"@js-owner"}, f.Rules[1].Owners)
+	assert.Equal(t, 5, f.Rules[2].Line)
+	assert.Equal(t, `file\ with\ spaces.txt`, f.Rules[3].Pattern)
+	assert.Equal(t, []string{"docs@example.com"}, f.Rules[4].Owners)
+	assert.Empty(t, f.Rules[5].Owners)
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		matches   []string
+		noMatches []string
+	}{
+		{
+			pattern: "*",
+			matches: []string{"README.md", "src/main.go", "a/b/c/d.txt"},
+		},
+		{
+			pattern:   "*.js",
+			matches:   []string{"app.js", "src/lib/util.js"},
+			noMatches: []string{"app.jsx", "js/app.ts"},
+		},
+		{
+			pattern:   "/build/logs/",
+			matches:   []string{"build/logs/out.log", "build/logs/2024/01.log"},
+			noMatches: []string{"src/build/logs/out.log", "build/logs"},
+		},
+		{
+			pattern:   "docs/*",
+			matches:   []string{"docs/getting-started.md"},
+			noMatches: []string{"docs/build-app/troubleshooting.md", "src/docs/index.md"},
+		},
+		{
+			pattern:   "apps/",
+			matches:   []string{"apps/web/index.ts", "src/apps/cli/main.go"},
+			noMatches: []string{"apps", "myapps/a.go"},
+		},
+		{
+			pattern:   "/docs/",
+			matches:   []string{"docs/a.md", "docs/deep/er/b.md"},
+			noMatches: []string{"src/docs/a.md"},
+		},
+		{
+			pattern:   "**/logs",
+			matches:   []string{"logs/a.log", "deployments/logs/b.log", "build/logs"},
+			noMatches: []string{"logs.txt", "mylogs/a.log"},
+		},
+		{
+			pattern:   "/scripts",
+			matches:   []string{"scripts/run.sh", "scripts"},
+			noMatches: []string{"tools/scripts/run.sh"},
+		},
+		{
+			pattern:   "docs/**/*.md",
+			matches:   []string{"docs/a.md", "docs/x/y/b.md"},
+			noMatches: []string{"docs/a.txt", "src/docs/a.md"},
+		},
+		{
+			pattern:   "src/**",
+			matches:   []string{"src/a.go", "src/x/y.go"},
+			noMatches: []string{"src", "lib/src/a.go"},
+		},
+		{
+			pattern:   "LICENSE?",
+			matches:   []string{"LICENSE1", "sub/LICENSE2"},
+			noMatches: []string{"LICENSE", "LICENSE/12"},
+		},
+		{
+			pattern: `file\ with\ spaces.txt`,
+			matches: []string{"file with spaces.txt"},
+		},
+		{
+			pattern:   "a.b",
+			matches:   []string{"a.b"},
+			noMatches: []string{"axb"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pattern, func(t *testing.T) {
+			f := Parse(tc.pattern + " @owner\n")
+			require.Len(t, f.Rules, 1)
+			for _, path := range tc.matches {
+				assert.NotNil(t, f.Match(path), "%s should match %s", tc.pattern, path)
+			}
+			for _, path := range tc.noMatches {
+				assert.Nil(t, f.Match(path), "%s should not match %s", tc.pattern, path)
+			}
+		})
+	}
+}
+
+func TestMatchPrecedence(t *testing.T) {
+	f := Parse(`* @default
+*.go @gophers
+/docs/ @writers
+/docs/internal/
+`)
+
+	tests := []struct {
+		path   string
+		owners []string
+		line   int
+	}{
+		{path: "README.md", owners: []string{"@default"}, line: 1},
+		{path: "cmd/main.go", owners: []string{"@gophers"}, line: 2},
+		// The last matching rule wins, even if an earlier rule is more specific
+		{path: "docs/example.go", owners: []string{"@writers"}, line: 3},
+		// Rules without owners make paths unowned
+		{path: "docs/internal/notes.md", owners: []string{}, line: 4},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			rule := f.Match(tc.path)
+			require.NotNil(t, rule)
+			assert.ElementsMatch(t, tc.owners, rule.Owners)
+			assert.Equal(t, tc.line, rule.Line)
+		})
+	}
+
+	assert.Nil(t, Parse("/docs/ @writers\n").Match("README.md"))
+}
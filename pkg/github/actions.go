@@ -1228,3 +1228,36 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// DiagnoseFailingRunPrompt creates a prompt to find the cause of a failing workflow run from the logs of its failed jobs.
+func DiagnoseFailingRunPrompt(t translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc) {
+	return mcp.NewPrompt("DiagnoseFailingRun",
+			mcp.WithPromptDescription(t("PROMPT_DIAGNOSE_FAILING_RUN_DESCRIPTION", "Diagnose why a GitHub Actions workflow run failed and suggest a fix.")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription(DescriptionRepositoryOwner+" (string)."), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription(DescriptionRepositoryName+" (string)."), mcp.RequiredArgument()),
+			mcp.WithArgument("run_id", mcp.ArgumentDescription("The unique identifier of the failed workflow run (number)."), mcp.RequiredArgument()),
+		), func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner, err := requiredPromptArg(request, "owner")
+			if err != nil {
+				return nil, err
+			}
+			repo, err := requiredPromptArg(request, "repo")
+			if err != nil {
+				return nil, err
+			}
+			runID, err := requiredPromptIntArg(request, "run_id")
+			if err != nil {
+				return nil, err
+			}
+
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Diagnosis of workflow run %d of %s/%s", runID, owner, repo),
+				Messages: promptMessages(
+					fmt.Sprintf("Workflow run %d in the %s/%s GitHub repository failed. Please find out why.", runID, owner, repo),
+					fmt.Sprintf("Sure! I will get the run with `get_workflow_run` to see which workflow, commit and event it ran for, and list its jobs with `list_workflow_jobs` to see which steps failed. Then I will read the end of the logs of every failed job at once with `get_job_logs`, passing run_id=%d, failed_only=true and return_content=true.", runID),
+					"Great. Quote the log lines showing the error, explain the root cause, and tell me whether it looks like a problem with the change itself, with the workflow, or a flaky failure. If the cause is in the code, read the relevant files at the commit of the run with `get_file_contents` and propose a concrete fix.",
+					"Understood. If the failure looks flaky, I will suggest rerunning the failed jobs with `rerun_failed_jobs`, but I will only do so once you confirm.",
+				),
+			}, nil
+		}
+}
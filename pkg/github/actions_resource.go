@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// runLogTailLines is the number of lines shown of the logs of every failed job of a workflow run resource
+const runLogTailLines = 200
+
+// GetWorkflowRunLogsResource defines the resource template and handler for getting a workflow run with the logs of its failed jobs.
+func GetWorkflowRunLogsResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"actions://{owner}/{repo}/runs/{run_id}/logs", // Resource template
+			t("RESOURCE_WORKFLOW_RUN_LOGS_DESCRIPTION", "Workflow run jobs and logs of failed jobs"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		WorkflowRunLogsResourceHandler(getClient)
+}
+
+// WorkflowRunLogsResourceHandler returns a handler function for workflow run log requests, which renders the jobs
+// of the run as Markdown, along with the end of the logs of the failed jobs.
+func WorkflowRunLogsResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, err := requiredResourceParam(request, "owner")
+		if err != nil {
+			return nil, err
+		}
+		repo, err := requiredResourceParam(request, "repo")
+		if err != nil {
+			return nil, err
+		}
+		runID, err := requiredResourceIntParam(request, "run_id")
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		run, _, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workflow run: %w", err)
+		}
+
+		var jobs []*github.WorkflowJob
+		opts := &github.ListWorkflowJobsOptions{Filter: "latest", ListOptions: github.ListOptions{PerPage: 100}}
+		for page := 0; page < maxResourcePages; page++ {
+			pageJobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list workflow jobs: %w", err)
+			}
+			jobs = append(jobs, pageJobs.Jobs...)
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+
+		var b strings.Builder
+		renderWorkflowRun(&b, run, jobs)
+
+		failed := 0
+		for _, job := range jobs {
+			if !isFailedJob(job) {
+				continue
+			}
+			failed++
+			fmt.Fprintf(&b, "\n## Logs of failed job %s\n\n", job.GetName())
+
+			logURL, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, job.GetID(), 1)
+			if err != nil {
+				fmt.Fprintf(&b, "_Failed to get the logs: %s_\n", err)
+				continue
+			}
+			_ = resp.Body.Close()
+			content, _, _, err := downloadLogContent(logURL.String(), runLogTailLines) //nolint:bodyclose // Response body is closed in downloadLogContent
+			if err != nil {
+				fmt.Fprintf(&b, "_Failed to download the logs: %s_\n", err)
+				continue
+			}
+			fmt.Fprintf(&b, "End of the log, up to %d lines:\n\n", runLogTailLines)
+			b.WriteString(markdownFence(content, "text"))
+		}
+		if failed == 0 {
+			b.WriteString("\n_No jobs failed, so no logs are included._\n")
+		}
+
+		return markdownResource(request.Params.URI, b.String()), nil
+	}
+}
+
+// isFailedJob reports whether a job concluded unsuccessfully
+func isFailedJob(job *github.WorkflowJob) bool {
+	switch job.GetConclusion() {
+	case "failure", "timed_out", "startup_failure":
+		return true
+	}
+	return false
+}
+
+// renderWorkflowRun renders a workflow run and its jobs as Markdown
+func renderWorkflowRun(b *strings.Builder, run *github.WorkflowRun, jobs []*github.WorkflowJob) {
+	result := run.GetStatus()
+	if run.GetConclusion() != "" {
+		result = run.GetConclusion()
+	}
+	fmt.Fprintf(b, "# %s #%d: %s\n\n", run.GetName(), run.GetRunNumber(), result)
+	if run.GetDisplayTitle() != "" {
+		fmt.Fprintf(b, "- **Title:** %s\n", run.GetDisplayTitle())
+	}
+	fmt.Fprintf(b, "- **Run ID:** %d (attempt %d)\n", run.GetID(), run.GetRunAttempt())
+	fmt.Fprintf(b, "- **Event:** %s\n", run.GetEvent())
+	fmt.Fprintf(b, "- **Branch:** %s\n", run.GetHeadBranch())
+	fmt.Fprintf(b, "- **Commit:** %s\n", run.GetHeadSHA())
+	fmt.Fprintf(b, "- **Actor:** %s\n", markdownUser(run.GetActor()))
+	fmt.Fprintf(b, "- **Started:** %s\n", markdownTime(run.RunStartedAt))
+	fmt.Fprintf(b, "- **URL:** %s\n", run.GetHTMLURL())
+
+	fmt.Fprintf(b, "\n## Jobs (%d)\n\n", len(jobs))
+	if len(jobs) == 0 {
+		b.WriteString("_No jobs._\n")
+		return
+	}
+	b.WriteString("| Job | Status | Conclusion | Failed step |\n| --- | --- | --- | --- |\n")
+	for _, job := range jobs {
+		failedStep := ""
+		for _, step := range job.Steps {
+			if step.GetConclusion() == "failure" {
+				failedStep = step.GetName()
+				break
+			}
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n", markdownTableCell(job.GetName()), job.GetStatus(), job.GetConclusion(), markdownTableCell(failedStep))
+	}
+}
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetWorkflowRunLogsResource(t *testing.T) {
+	tmpl, _ := GetWorkflowRunLogsResource(nil, translations.NullTranslationHelper)
+	require.Equal(t, "actions://{owner}/{repo}/runs/{run_id}/logs", tmpl.URITemplate.Raw())
+	require.Equal(t, "text/markdown", tmpl.MIMEType)
+}
+
+func Test_WorkflowRunLogsResourceHandler(t *testing.T) {
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("step 1\nError: tests failed\n"))
+	}))
+	defer logServer.Close()
+
+	mockRun := &github.WorkflowRun{
+		ID:         github.Ptr(int64(99)),
+		Name:       github.Ptr("CI"),
+		RunNumber:  github.Ptr(12),
+		RunAttempt: github.Ptr(1),
+		Status:     github.Ptr("completed"),
+		Conclusion: github.Ptr("failure"),
+		Event:      github.Ptr("push"),
+		HeadBranch: github.Ptr("main"),
+		HeadSHA:    github.Ptr("abc123"),
+		Actor:      &github.User{Login: github.Ptr("octocat")},
+		HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/99"),
+	}
+	header := "# CI #12: failure\n\n" +
+		"- **Run ID:** 99 (attempt 1)\n" +
+		"- **Event:** push\n" +
+		"- **Branch:** main\n" +
+		"- **Commit:** abc123\n" +
+		"- **Actor:** @octocat\n" +
+		"- **Started:** _unknown_\n" +
+		"- **URL:** https://github.com/owner/repo/actions/runs/99\n\n"
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		args           map[string]string
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "run with a failed job",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepoByRunId, mockRun),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					expectQueryParams(t, map[string]string{"filter": "latest", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, &github.Jobs{
+							TotalCount: github.Ptr(2),
+							Jobs: []*github.WorkflowJob{
+								{ID: github.Ptr(int64(1)), Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+								{
+									ID: github.Ptr(int64(2)), Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"),
+									Steps: []*github.TaskStep{
+										{Name: github.Ptr("Checkout"), Conclusion: github.Ptr("success")},
+										{Name: github.Ptr("Run tests"), Conclusion: github.Ptr("failure")},
+									},
+								},
+							},
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsJobsLogsByOwnerByRepoByJobId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Location", logServer.URL)
+						w.WriteHeader(http.StatusFound)
+					}),
+				),
+			),
+			args: map[string]string{"owner": "owner", "repo": "repo", "run_id": "99"},
+			expectedText: header +
+				"## Jobs (2)\n\n" +
+				"| Job | Status | Conclusion | Failed step |\n| --- | --- | --- | --- |\n" +
+				"| lint | completed | success |  |\n" +
+				"| test | completed | failure | Run tests |\n\n" +
+				"## Logs of failed job test\n\n" +
+				"End of the log, up to 200 lines:\n\n" +
+				"```text\nstep 1\nError: tests failed\n```\n",
+		},
+		{
+			name: "run without failed jobs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposActionsRunsByOwnerByRepoByRunId, mockRun),
+				mock.WithRequestMatch(mock.GetReposActionsRunsJobsByOwnerByRepoByRunId, &github.Jobs{TotalCount: github.Ptr(0)}),
+			),
+			args:         map[string]string{"owner": "owner", "repo": "repo", "run_id": "99"},
+			expectedText: header + "## Jobs (0)\n\n_No jobs._\n\n_No jobs failed, so no logs are included._\n",
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			args:           map[string]string{"owner": "owner", "repo": "repo", "run_id": "99"},
+			expectedErrMsg: "failed to get workflow run",
+		},
+		{
+			name:           "invalid run ID",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			args:           map[string]string{"owner": "owner", "repo": "repo", "run_id": "latest"},
+			expectedErrMsg: "invalid run_id: latest",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := WorkflowRunLogsResourceHandler(stubGetClientFn(github.NewClient(tc.mockedClient)))
+			uri := "actions://owner/repo/runs/99/logs"
+			contents, err := handler(context.Background(), readResourceRequest(uri, tc.args))
+			if tc.expectedErrMsg != "" {
+				require.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, markdownResource(uri, tc.expectedText), contents)
+		})
+	}
+}
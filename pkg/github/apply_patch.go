@@ -0,0 +1,196 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/patch"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultPatchFuzz is the number of context lines at the edges of a hunk that may be ignored by default
+	defaultPatchFuzz = 2
+	// maxPatchFuzz is the number of context lines at the edges of a hunk that may be ignored at most
+	maxPatchFuzz = 3
+)
+
+// ApplyPatch creates a tool to apply a unified diff to a branch in a single commit.
+func ApplyPatch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("apply_patch",
+			mcp.WithDescription(t("TOOL_APPLY_PATCH_DESCRIPTION", "Apply a unified diff, as produced by git diff or diff -u, to a branch of a GitHub repository in a single commit. Files can be changed, created, deleted, renamed and copied, and their mode changed. Hunks apply where their context is found near the lines given by their header, ignoring up to fuzz lines of context at their edges and differences in whitespace if needed. Nothing is committed if any hunk does not apply, and the hunks that don't are listed in the error")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_APPLY_PATCH_USER_TITLE", "Apply patch to repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to apply the patch to"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithString("patch",
+				mcp.Required(),
+				mcp.Description("Unified diff to apply, with paths relative to the root of the repository"),
+			),
+			mcp.WithString("expected_parent_sha",
+				mcp.Description("SHA of the commit the branch is expected to point to. The patch is not applied if the branch has moved since"),
+			),
+			mcp.WithNumber("fuzz",
+				mcp.Description(fmt.Sprintf("Number of lines of context at the start and end of hunks that may be ignored when they don't match the file. Defaults to %d, 0 requires hunks to match exactly", defaultPatchFuzz)),
+				mcp.Min(0),
+				mcp.Max(maxPatchFuzz),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			diff, err := RequiredParam[string](request, "patch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedParent, err := OptionalParam[string](request, "expected_parent_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			fuzz, err := OptionalIntParamWithDefault(request, "fuzz", defaultPatchFuzz)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if fuzz < 0 || fuzz > maxPatchFuzz {
+				return mcp.NewToolResultError(fmt.Sprintf("fuzz must be between 0 and %d", maxPatchFuzz)), nil
+			}
+
+			files, err := patch.Parse(diff)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to parse patch: %v", err)), nil
+			}
+			for _, f := range files {
+				if f.NewMode != "" && !slices.Contains(fileModes, f.NewMode) {
+					return mcp.NewToolResultError(fmt.Sprintf("mode %s of %s must be one of %s", f.NewMode, f.Path(), strings.Join(fileModes, ", "))), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			c := fileCommit{owner: owner, repo: repo, branch: branch, message: message, expectedParent: expectedParent}
+			return commitOperations(ctx, client, c, func(ctx context.Context, lookup *treeLookup, rootSHA string) ([]changeOperation, error) {
+				return patchOperations(ctx, lookup, rootSHA, files, fuzz)
+			})
+		}
+}
+
+// patchOperations applies the diffs of files to the tree rootSHA, and returns the operations committing the result.
+// If hunks do not apply, the returned error lists all of them.
+func patchOperations(ctx context.Context, lookup *treeLookup, rootSHA string, files []*patch.File, fuzz int) ([]changeOperation, error) {
+	var operations []changeOperation
+	var rejected []string
+	for _, f := range files {
+		// apply returns the content of the file after the change, or nil if the diff only renames or deletes it
+		apply := func(path string) (*string, error) {
+			if len(f.Hunks) == 0 {
+				return nil, nil
+			}
+			content := ""
+			if path != "" {
+				var err error
+				if content, err = lookup.content(ctx, rootSHA, path); err != nil {
+					return nil, err
+				}
+				if strings.ContainsRune(content, 0) {
+					return nil, fmt.Errorf("%s is a binary file", path)
+				}
+			}
+			result, err := patch.Apply(content, f.Hunks, fuzz)
+			if err != nil {
+				return nil, err
+			}
+			return &result, nil
+		}
+
+		var op changeOperation
+		var content *string
+		var err error
+		switch {
+		case f.IsNew():
+			content, err = apply("")
+			if content == nil {
+				// New empty files have no hunks
+				content = new(string)
+			}
+			op = changeOperation{Operation: "create", Path: f.NewPath, Content: content, Mode: f.NewMode}
+		case f.IsDelete():
+			// Check that the file matches the diff before deleting it
+			_, err = apply(f.OldPath)
+			op = changeOperation{Operation: "delete", Path: f.OldPath}
+		case f.IsCopy:
+			content, err = apply(f.OldPath)
+			if err == nil && content == nil {
+				var c string
+				c, err = lookup.content(ctx, rootSHA, f.OldPath)
+				content = &c
+			}
+			op = changeOperation{Operation: "create", Path: f.NewPath, Content: content, Mode: f.NewMode}
+		case f.IsRename:
+			content, err = apply(f.OldPath)
+			op = changeOperation{Operation: "rename", From: f.OldPath, Path: f.NewPath, Content: content, Mode: f.NewMode}
+		case len(f.Hunks) > 0:
+			content, err = apply(f.OldPath)
+			op = changeOperation{Operation: "update", Path: f.NewPath, Content: content, Mode: f.NewMode}
+		case f.NewMode != "":
+			op = changeOperation{Operation: "chmod", Path: f.NewPath, Mode: f.NewMode}
+		default:
+			err = errors.New("the diff has no changes")
+		}
+
+		var applyErr *patch.ApplyError
+		switch {
+		case errors.As(err, &applyErr):
+			rejected = append(rejected, fmt.Sprintf("%s: %v", f.Path(), applyErr))
+			continue
+		case err != nil:
+			if lookup.resp != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s: %w", f.Path(), err)
+		}
+		operations = append(operations, op)
+	}
+	if len(rejected) > 0 {
+		return nil, fmt.Errorf("patch does not apply, nothing was committed:\n%s", strings.Join(rejected, "\n"))
+	}
+	return operations, nil
+}
@@ -0,0 +1,226 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ApplyPatch(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ApplyPatch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "apply_patch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "patch")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_parent_sha")
+	assert.Contains(t, tool.InputSchema.Properties, "fuzz")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "message", "patch"})
+
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("head123")},
+	}
+	file := func(name, mode string) *github.TreeEntry {
+		return &github.TreeEntry{Path: github.Ptr(name), Mode: github.Ptr(mode), Type: github.Ptr("blob"), SHA: github.Ptr("sha-" + name)}
+	}
+	trees := map[string]*github.Tree{
+		"root": {SHA: github.Ptr("root"), Entries: []*github.TreeEntry{
+			file("README.md", "100644"),
+			file("old.txt", "100644"),
+			{Path: github.Ptr("scripts"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("scripts")},
+		}},
+		"scripts": {SHA: github.Ptr("scripts"), Entries: []*github.TreeEntry{
+			file("build.sh", "100644"),
+		}},
+	}
+	blobs := map[string]string{
+		"sha-README.md": "# Project\n\nSome text.\n\n## Usage\n\nRun it.\n",
+		"sha-old.txt":   "obsolete\n",
+		"sha-build.sh":  "#!/bin/sh\nmake\n",
+	}
+	lastSegment := func(r *http.Request) string {
+		return r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	}
+	mockedClient := func(handlers ...mock.MockBackendOption) *http.Client {
+		return mock.NewMockedHTTPClient(append([]mock.MockBackendOption{
+			mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+			mock.WithRequestMatch(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, &github.Commit{SHA: github.Ptr("head123"), Tree: &github.Tree{SHA: github.Ptr("root")}}),
+			mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(trees[lastSegment(r)])
+			})),
+			mock.WithRequestMatchHandler(mock.GetReposGitBlobsByOwnerByRepoByFileSha, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(blobs[lastSegment(r)]))
+			})),
+		}, handlers...)...)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg []string
+	}{
+		{
+			name: "applies a patch changing, creating, deleting and renaming files",
+			mockedClient: mockedClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "root",
+						"tree": []interface{}{
+							map[string]interface{}{"path": "README.md", "mode": "100644", "type": "blob", "content": "# Project\n\nSome text.\n\n## Usage\n\nRun `make`.\n"},
+							map[string]interface{}{"path": "docs/guide.md", "mode": "100644", "type": "blob", "content": "# Guide\n"},
+							map[string]interface{}{"path": "old.txt", "mode": "100644", "type": "blob", "sha": nil},
+							map[string]interface{}{"path": "scripts/build.sh", "mode": "100644", "type": "blob", "sha": nil},
+							map[string]interface{}{"path": "bin/build.sh", "mode": "100755", "type": "blob", "sha": "sha-build.sh"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("newtree")}),
+					),
+				),
+				mock.WithRequestMatch(mock.PostReposGitCommitsByOwnerByRepo, &github.Commit{SHA: github.Ptr("new456")}),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{"sha": "new456", "force": false}).andThen(
+						mockResponse(t, http.StatusOK, mockRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Document usage",
+				"patch": `diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -5,3 +5,3 @@
+ ## Usage
+
+-Run it.
++Run ` + "`make`" + `.
+diff --git a/docs/guide.md b/docs/guide.md
+new file mode 100644
+--- /dev/null
++++ b/docs/guide.md
+@@ -0,0 +1 @@
++# Guide
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-obsolete
+diff --git a/scripts/build.sh b/bin/build.sh
+old mode 100644
+new mode 100755
+similarity index 100%
+rename from scripts/build.sh
+rename to bin/build.sh
+`,
+				"expected_parent_sha": "head123",
+			},
+		},
+		{
+			name:         "lists the hunks that do not apply",
+			mockedClient: mockedClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"patch":   "--- a/README.md\n+++ b/README.md\n@@ -1 +1 @@\n-# Project\n+# Renamed\n@@ -7 +7 @@\n-Run that.\n+Run this.\n--- a/old.txt\n+++ b/old.txt\n@@ -1 +1 @@\n-current\n+new\n",
+				"fuzz":    float64(0),
+			},
+			expectError: true,
+			expectedErrMsg: []string{
+				"patch does not apply, nothing was committed",
+				"README.md: hunk #2 @@ -7,1 +7,1 @@ does not apply",
+				"old.txt: hunk #1 @@ -1,1 +1,1 @@ does not apply",
+			},
+		},
+		{
+			name:         "patch of a missing file",
+			mockedClient: mockedClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"patch":   "--- a/missing.txt\n+++ b/missing.txt\n@@ -1 +1 @@\n-a\n+b\n",
+			},
+			expectError:    true,
+			expectedErrMsg: []string{"missing.txt: file missing.txt does not exist"},
+		},
+		{
+			name:         "invalid patch",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"patch":   "not a diff",
+			},
+			expectError:    true,
+			expectedErrMsg: []string{"failed to parse patch: no file diffs found in patch"},
+		},
+		{
+			name:         "fuzz out of range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"patch":   "--- a/f\n+++ b/f\n@@ -1 +1 @@\n-a\n+b\n",
+				"fuzz":    float64(10),
+			},
+			expectError:    true,
+			expectedErrMsg: []string{"fuzz must be between 0 and 3"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ApplyPatch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				for _, msg := range tc.expectedErrMsg {
+					assert.Contains(t, errorContent.Text, msg)
+				}
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned commitChangesResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "new456", returned.SHA)
+			assert.Equal(t, "head123", returned.Parent)
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/workspace"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxManifestEntries is the number of entries returned in the manifest of a downloaded archive, the
+// remaining entries can be listed with get_workspace_file_contents
+const maxManifestEntries = 1000
+
+// workspaceManifest is the result of download_repository_archive
+type workspaceManifest struct {
+	Handle      string            `json:"handle"`
+	Source      workspace.Source  `json:"source"`
+	Files       int               `json:"files"`
+	Directories int               `json:"directories"`
+	Size        int64             `json:"size"`
+	Entries     []workspace.Entry `json:"entries"`
+	// Truncated is set when only the first maxManifestEntries entries are returned
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// DownloadRepositoryArchive creates a tool to download the archive of a repository into a local workspace.
+func DownloadRepositoryArchive(getClient GetClientFn, getRawClient raw.GetRawClientFn, workspaces *workspace.Manager, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("download_repository_archive",
+			mcp.WithDescription(t("TOOL_DOWNLOAD_REPOSITORY_ARCHIVE_DESCRIPTION", "Download the archive of a GitHub repository at a ref and extract it into a temporary workspace on the server. Returns a manifest of the files and a handle to read them with get_workspace_file_contents, without further API calls. Use this instead of many get_file_contents calls when exploring a repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DOWNLOAD_REPOSITORY_ARCHIVE_USER_TITLE", "Download repository archive"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to download. Defaults to the default branch"),
+			),
+			mcp.WithString("format",
+				mcp.Description("Archive format to download"),
+				mcp.Enum(string(raw.ArchiveTarball), string(raw.ArchiveZipball)),
+				mcp.DefaultString(string(raw.ArchiveTarball)),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if format == "" {
+				format = string(raw.ArchiveTarball)
+			}
+			if format != string(raw.ArchiveTarball) && format != string(raw.ArchiveZipball) {
+				return mcp.NewToolResultError(fmt.Sprintf("unsupported format %q, use tarball or zipball", format)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
+			}
+
+			// The workspace records the qualified ref, so its files have the repo:// URIs of the ref
+			qualifiedRef, resp, err := qualifyRef(ctx, client, owner, repo, ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to resolve ref", resp, err), nil
+			}
+
+			body, err := rawClient.GetArchive(ctx, owner, repo, ref, raw.ArchiveFormat(format), workspaces.Limits.MaxArchiveSize)
+			if err != nil {
+				return archiveErrorResult(owner, repo, ref, err), nil
+			}
+			defer func() { _ = body.Close() }()
+
+			ws, err := workspaces.Extract(workspaceSession(ctx), body, workspace.Source{Owner: owner, Repo: repo, Ref: qualifiedRef, Format: format})
+			if err != nil {
+				return archiveErrorResult(owner, repo, ref, err), nil
+			}
+
+			manifest := workspaceManifest{
+				Handle:  ws.Handle,
+				Source:  ws.Source,
+				Size:    ws.Size,
+				Entries: ws.Entries,
+			}
+			for _, entry := range ws.Entries {
+				switch entry.Type {
+				case "file":
+					manifest.Files++
+				case "dir":
+					manifest.Directories++
+				}
+			}
+			if len(manifest.Entries) > maxManifestEntries {
+				manifest.Entries = manifest.Entries[:maxManifestEntries]
+				manifest.Truncated = true
+			}
+
+			r, err := json.Marshal(manifest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+			}
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// workspaceSession returns the client session of a request, which workspaces belong to
+func workspaceSession(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+// archiveErrorResult describes an error downloading or extracting an archive
+func archiveErrorResult(owner, repo, ref string, err error) *mcp.CallToolResult {
+	var tooLarge *raw.TooLargeError
+	var statusErr *raw.StatusError
+	switch {
+	case errors.As(err, &tooLarge):
+		return mcp.NewToolResultError(fmt.Sprintf("archive %s, use get_file_contents to read individual files", tooLarge))
+	case raw.IsNotFound(err):
+		if ref != "" {
+			return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s or ref %s not found", owner, repo, ref))
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("repository %s/%s not found", owner, repo))
+	case errors.As(err, &statusErr):
+		return mcp.NewToolResultError(fmt.Sprintf("failed to download archive: %s", statusErr))
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("failed to download archive: %s", err))
+	}
+}
+
+// GetWorkspaceFileContents creates a tool to read the files of a workspace created by download_repository_archive.
+func GetWorkspaceFileContents(workspaces *workspace.Manager, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_workspace_file_contents",
+			mcp.WithDescription(t("TOOL_GET_WORKSPACE_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory of a repository archive downloaded with download_repository_archive, without calling the GitHub API")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WORKSPACE_FILE_CONTENTS_USER_TITLE", "Get workspace file or directory contents"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("handle",
+				mcp.Required(),
+				mcp.Description("Workspace handle returned by download_repository_archive"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Path to the file or directory in the repository, the root directory if empty"),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line to return, starting at 1. Use with end_line to read part of a large text file"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line to return, inclusive. Defaults to the end of the file"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			handle, err := RequiredParam[string](request, "handle")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filePath, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			startLine, err := OptionalIntParam(request, "start_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			endLine, err := OptionalIntParam(request, "end_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if endLine != 0 && startLine == 0 {
+				startLine = 1
+			}
+
+			ws, err := workspaces.Get(workspaceSession(ctx), handle)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			filePath = strings.Trim(filePath, "/")
+			if entry, ok := ws.Entry(filePath); filePath == "" || (ok && entry.Type == "dir") {
+				if startLine != 0 {
+					return mcp.NewToolResultError("start_line and end_line are only supported for files"), nil
+				}
+				entries, err := ws.List(filePath)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				r, err := json.Marshal(entries)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal directory listing: %w", err)
+				}
+				return mcp.NewToolResultText(string(r)), nil
+			}
+
+			data, err := ws.ReadFile(filePath)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			resourceURI, err := workspaceResourceURI(ws.Source, filePath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create resource URI: %w", err)
+			}
+
+			contentType := mime.TypeByExtension(path.Ext(filePath))
+			if contentType == "" {
+				contentType = http.DetectContentType(data)
+			}
+			isText := utf8.Valid(data) && bytes.IndexByte(data, 0) == -1
+			if isText && !strings.HasPrefix(contentType, "text") && !strings.HasPrefix(contentType, "application") {
+				contentType = "text/plain; charset=utf-8"
+			}
+
+			if startLine != 0 {
+				if !isText {
+					return mcp.NewToolResultError("start_line and end_line are only supported for text files"), nil
+				}
+				lines, lastLine, err := sliceLines(data, startLine, endLine)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				return mcp.NewToolResultResource(fmt.Sprintf("successfully read lines %d-%d of text file", startLine, lastLine), mcp.TextResourceContents{
+					URI:      resourceURI,
+					Text:     string(lines),
+					MIMEType: contentType,
+				}), nil
+			}
+
+			if isText {
+				return mcp.NewToolResultResource("successfully read text file", mcp.TextResourceContents{
+					URI:      resourceURI,
+					Text:     string(data),
+					MIMEType: contentType,
+				}), nil
+			}
+			return mcp.NewToolResultResource("successfully read binary file", mcp.BlobResourceContents{
+				URI:      resourceURI,
+				Blob:     base64.StdEncoding.EncodeToString(data),
+				MIMEType: contentType,
+			}), nil
+		}
+}
+
+// qualifyRef returns the fully qualified ref of a tag or branch name, preferring tags like git does. Qualified
+// refs and the default branch, which is empty, are returned as is, and other refs are taken for commit SHAs.
+func qualifyRef(ctx context.Context, client *github.Client, owner, repo, ref string) (string, *github.Response, error) {
+	if ref == "" || strings.HasPrefix(ref, "refs/") {
+		return ref, nil, nil
+	}
+	for _, prefix := range []string{"refs/tags/", "refs/heads/"} {
+		_, resp, err := client.Git.GetRef(ctx, owner, repo, prefix+ref)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return "", resp, err
+		}
+		_ = resp.Body.Close()
+		return prefix + ref, nil, nil
+	}
+	return ref, nil, nil
+}
+
+// workspaceResourceURI returns the repo:// URI of a file of a workspace, like get_file_contents does. The ref
+// of the source is qualified by qualifyRef, so refs not starting with refs/ are commit SHAs.
+func workspaceResourceURI(source workspace.Source, filePath string) (string, error) {
+	if source.Ref != "" && !strings.HasPrefix(source.Ref, "refs/") {
+		return repositoryResourceURI(source.Owner, source.Repo, "", source.Ref, filePath)
+	}
+	return repositoryResourceURI(source.Owner, source.Repo, source.Ref, "", filePath)
+}
+
+// sliceLines returns the lines startLine to endLine of data, both 1-based and inclusive, or to the end of
+// data if endLine is 0, along with the number of the last returned line
+func sliceLines(data []byte, startLine, endLine int) ([]byte, int, error) {
+	if endLine != 0 && endLine < startLine {
+		return nil, 0, fmt.Errorf("end_line %d is before start_line %d", endLine, startLine)
+	}
+
+	line, start := 1, -1
+	for i := 0; i <= len(data); i++ {
+		if line == startLine && start == -1 {
+			start = i
+		}
+		if i == len(data) {
+			break
+		}
+		if data[i] == '\n' {
+			if line == endLine {
+				return data[start : i+1], line, nil
+			}
+			line++
+		}
+	}
+
+	// A trailing newline does not start another line
+	lines := line
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		lines--
+	}
+	if start == -1 || startLine > lines {
+		return nil, 0, fmt.Errorf("start line %d is beyond the end of the file, which has %d lines", startLine, lines)
+	}
+	return data[start:], lines, nil
+}
@@ -0,0 +1,419 @@
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/workspace"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTarball returns a gzipped tarball of files, below a top level directory like the archives of GitHub
+func testTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "octocat-hello-abc123/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "octocat-hello-abc123/" + name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// newArchiveRawClient returns a raw client downloading archive for every ref but "missing"
+func newArchiveRawClient(archive []byte) *raw.Client {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposTarballByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/repos/octocat/hello/tarball/missing" {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				w.Header().Set("Location", "https://codeload.example.com/octocat/hello/legacy.tar.gz/refs/heads/main")
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.EndpointPattern{Pattern: "/octocat/hello/legacy.tar.gz/refs/heads/main", Method: "GET"},
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(archive)
+			}),
+		),
+	)
+	rawURL := &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"}
+	return raw.NewClient(github.NewClient(mockedClient), rawURL, raw.WithDownloadClient(mockedClient))
+}
+
+// newRefsClient returns a client knowing the tag v1.0 and the branch main, which fails to get the ref broken
+func newRefsClient(t *testing.T) *github.Client {
+	return github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitRefByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/octocat/hello/git/ref/tags/v1.0", "/repos/octocat/hello/git/ref/heads/main":
+					mockResponse(t, http.StatusOK, &github.Reference{Ref: github.Ptr(strings.TrimPrefix(r.URL.Path, "/repos/octocat/hello/git/ref/"))})(w, r)
+				case "/repos/octocat/hello/git/ref/tags/broken":
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Server Error"}`)(w, r)
+				default:
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+				}
+			}),
+		),
+	))
+}
+
+func Test_DownloadRepositoryArchive(t *testing.T) {
+	// Verify tool definition once
+	workspaces := workspace.NewManager(workspace.DefaultLimits)
+	t.Cleanup(func() { _ = workspaces.Close() })
+	tool, _ := DownloadRepositoryArchive(stubGetClientFn(newRefsClient(t)), stubGetRawClientFn(newArchiveRawClient(nil)), workspaces, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "download_repository_archive", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "format")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	archive := testTarball(t, map[string]string{
+		"README.md":   "# Hello\n",
+		"src/main.go": "package main\n",
+	})
+
+	tests := []struct {
+		name           string
+		limits         workspace.Limits
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedRef    string
+	}{
+		{
+			name:   "downloads and extracts the archive of a branch",
+			limits: workspace.DefaultLimits,
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"repo":  "hello",
+				"ref":   "main",
+			},
+			expectedRef: "refs/heads/main",
+		},
+		{
+			name:   "archive of a tag",
+			limits: workspace.DefaultLimits,
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"repo":  "hello",
+				"ref":   "v1.0",
+			},
+			expectedRef: "refs/tags/v1.0",
+		},
+		{
+			name:   "archive of a commit",
+			limits: workspace.DefaultLimits,
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"repo":  "hello",
+				"ref":   "abc123",
+			},
+			expectedRef: "abc123",
+		},
+		{
+			name:   "resolving the ref fails",
+			limits: workspace.DefaultLimits,
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"repo":  "hello",
+				"ref":   "broken",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to resolve ref",
+		},
+		{
+			name:   "unknown ref",
+			limits: workspace.DefaultLimits,
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"repo":  "hello",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "repository octocat/hello or ref missing not found",
+		},
+		{
+			name:   "archive too large",
+			limits: workspace.Limits{MaxArchiveSize: 10, MaxTotalSize: 1 << 20, MaxFileSize: 1 << 20, MaxFiles: 100},
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"repo":  "hello",
+				"ref":   "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "larger than the limit of 10 bytes, use get_file_contents to read individual files",
+		},
+		{
+			name:   "unsupported format",
+			limits: workspace.DefaultLimits,
+			requestArgs: map[string]interface{}{
+				"owner":  "octocat",
+				"repo":   "hello",
+				"format": "rar",
+			},
+			expectError:    true,
+			expectedErrMsg: `unsupported format "rar", use tarball or zipball`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			workspaces := workspace.NewManager(tc.limits)
+			t.Cleanup(func() { _ = workspaces.Close() })
+			_, handler := DownloadRepositoryArchive(stubGetClientFn(newRefsClient(t)), stubGetRawClientFn(newArchiveRawClient(archive)), workspaces, translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var manifest workspaceManifest
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &manifest))
+			assert.Regexp(t, `^ws_[0-9a-f]{16}$`, manifest.Handle)
+			assert.Equal(t, workspace.Source{Owner: "octocat", Repo: "hello", Ref: tc.expectedRef, Format: "tarball"}, manifest.Source)
+			assert.Equal(t, 2, manifest.Files)
+			assert.Equal(t, 1, manifest.Directories)
+			assert.Equal(t, int64(21), manifest.Size)
+			assert.False(t, manifest.Truncated)
+			assert.Equal(t, []workspace.Entry{
+				{Path: "README.md", Type: "file", Size: 8},
+				{Path: "src", Type: "dir"},
+				{Path: "src/main.go", Type: "file", Size: 13},
+			}, manifest.Entries)
+		})
+	}
+}
+
+func Test_GetWorkspaceFileContents(t *testing.T) {
+	// Verify tool definition once
+	workspaces := workspace.NewManager(workspace.DefaultLimits)
+	t.Cleanup(func() { _ = workspaces.Close() })
+	tool, _ := GetWorkspaceFileContents(workspaces, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_workspace_file_contents", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "handle")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "start_line")
+	assert.Contains(t, tool.InputSchema.Properties, "end_line")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"handle"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	archive := testTarball(t, map[string]string{
+		"README.md":     "# Hello\n\nWorld\n",
+		"src/main.go":   "package main\n",
+		"data.json":     `{"a": 1}`,
+		"image.bin":     "\x89PNG\x00\x01",
+		"notes/todo.md": "- one\n",
+	})
+	ws, err := workspaces.Extract("", bytes.NewReader(archive), workspace.Source{Owner: "octocat", Repo: "hello", Ref: "refs/heads/main", Format: "tarball"})
+	require.NoError(t, err)
+	_, handler := GetWorkspaceFileContents(workspaces, translations.NullTranslationHelper)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedResult interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:        "text file",
+			requestArgs: map[string]interface{}{"handle": ws.Handle, "path": "README.md"},
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://octocat/hello/refs/heads/main/contents/README.md",
+				Text:     "# Hello\n\nWorld\n",
+				MIMEType: "text/markdown; charset=utf-8",
+			},
+		},
+		{
+			name:        "line range",
+			requestArgs: map[string]interface{}{"handle": ws.Handle, "path": "/README.md", "start_line": float64(2), "end_line": float64(3)},
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://octocat/hello/refs/heads/main/contents/README.md",
+				Text:     "\nWorld\n",
+				MIMEType: "text/markdown; charset=utf-8",
+			},
+		},
+		{
+			name:        "json file",
+			requestArgs: map[string]interface{}{"handle": ws.Handle, "path": "data.json"},
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://octocat/hello/refs/heads/main/contents/data.json",
+				Text:     `{"a": 1}`,
+				MIMEType: "application/json",
+			},
+		},
+		{
+			name:        "binary file",
+			requestArgs: map[string]interface{}{"handle": ws.Handle, "path": "image.bin"},
+			expectedResult: mcp.BlobResourceContents{
+				URI:      "repo://octocat/hello/refs/heads/main/contents/image.bin",
+				Blob:     "iVBORwAB",
+				MIMEType: "application/octet-stream",
+			},
+		},
+		{
+			name:        "root directory",
+			requestArgs: map[string]interface{}{"handle": ws.Handle},
+			expectedResult: []workspace.Entry{
+				{Path: "README.md", Type: "file", Size: 15},
+				{Path: "data.json", Type: "file", Size: 8},
+				{Path: "image.bin", Type: "file", Size: 6},
+				{Path: "notes", Type: "dir"},
+				{Path: "src", Type: "dir"},
+			},
+		},
+		{
+			name:           "directory",
+			requestArgs:    map[string]interface{}{"handle": ws.Handle, "path": "src/"},
+			expectedResult: []workspace.Entry{{Path: "src/main.go", Type: "file", Size: 13}},
+		},
+		{
+			name:           "line range of a directory",
+			requestArgs:    map[string]interface{}{"handle": ws.Handle, "path": "src", "start_line": float64(1)},
+			expectedErrMsg: "start_line and end_line are only supported for files",
+		},
+		{
+			name:           "line range of a binary file",
+			requestArgs:    map[string]interface{}{"handle": ws.Handle, "path": "image.bin", "start_line": float64(1)},
+			expectedErrMsg: "start_line and end_line are only supported for text files",
+		},
+		{
+			name:           "start line beyond the end of the file",
+			requestArgs:    map[string]interface{}{"handle": ws.Handle, "path": "README.md", "start_line": float64(4)},
+			expectedErrMsg: "start line 4 is beyond the end of the file, which has 3 lines",
+		},
+		{
+			name:           "missing file",
+			requestArgs:    map[string]interface{}{"handle": ws.Handle, "path": "missing.go"},
+			expectedErrMsg: "file not found in workspace: missing.go",
+		},
+		{
+			name:           "path outside of the workspace",
+			requestArgs:    map[string]interface{}{"handle": ws.Handle, "path": "../secret"},
+			expectedErrMsg: "../secret",
+		},
+		{
+			name:           "unknown handle",
+			requestArgs:    map[string]interface{}{"handle": "ws_0000000000000000", "path": "README.md"},
+			expectedErrMsg: workspace.ErrNotFound.Error(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			switch expected := tc.expectedResult.(type) {
+			case mcp.TextResourceContents:
+				assert.Equal(t, expected, getTextResourceResult(t, result))
+			case mcp.BlobResourceContents:
+				assert.Equal(t, expected, getBlobResourceResult(t, result))
+			case []workspace.Entry:
+				var entries []workspace.Entry
+				require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &entries))
+				assert.Equal(t, expected, entries)
+			}
+		})
+	}
+}
+
+func Test_workspaceResourceURI(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      string
+		expected string
+	}{
+		{name: "branch", ref: "refs/heads/main", expected: "repo://octocat/hello/refs/heads/main/contents/src/main.go"},
+		{name: "tag", ref: "refs/tags/v1.0", expected: "repo://octocat/hello/refs/tags/v1.0/contents/src/main.go"},
+		{name: "commit", ref: "abc123", expected: "repo://octocat/hello/sha/abc123/contents/src/main.go"},
+		{name: "pull request", ref: "refs/pull/42/head", expected: "repo://octocat/hello/refs/pull/42/head/contents/src/main.go"},
+		{name: "default branch", ref: "", expected: "repo://octocat/hello/contents/src/main.go"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			uri, err := workspaceResourceURI(workspace.Source{Owner: "octocat", Repo: "hello", Ref: tc.ref, Format: "tarball"}, "src/main.go")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, uri)
+		})
+	}
+}
+
+func Test_sliceLines(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		start, end   int
+		expected     string
+		expectedLast int
+		expectedErr  string
+	}{
+		{name: "whole file", data: "a\nb\nc\n", start: 1, expected: "a\nb\nc\n", expectedLast: 3},
+		{name: "middle lines", data: "a\nb\nc\n", start: 2, end: 2, expected: "b\n", expectedLast: 2},
+		{name: "end beyond the last line", data: "a\nb\nc", start: 2, end: 10, expected: "b\nc", expectedLast: 3},
+		{name: "last line without newline", data: "a\nb", start: 2, expected: "b", expectedLast: 2},
+		{name: "start beyond the last line", data: "a\nb\n", start: 3, expectedErr: "start line 3 is beyond the end of the file, which has 2 lines"},
+		{name: "empty file", data: "", start: 1, expectedErr: "start line 1 is beyond the end of the file, which has 0 lines"},
+		{name: "end before start", data: "a\nb\n", start: 2, end: 1, expectedErr: "end_line 1 is before start_line 2"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lines, last, err := sliceLines([]byte(tc.data), tc.start, tc.end)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(lines))
+			assert.Equal(t, tc.expectedLast, last)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// blameQuery is the GraphQL query for the blame of a file at a commit
+type blameQuery struct {
+	Repository struct {
+		Object struct {
+			Commit struct {
+				OID   githubv4.GitObjectID
+				Blame struct {
+					Ranges []struct {
+						StartingLine githubv4.Int
+						EndingLine   githubv4.Int
+						Commit       struct {
+							OID     githubv4.GitObjectID
+							Message githubv4.String
+							URL     githubv4.URI `graphql:"url"`
+							Author  struct {
+								Name githubv4.String
+								Date githubv4.GitTimestamp
+								User struct {
+									Login githubv4.String
+								}
+							}
+						}
+					}
+				} `graphql:"blame(path: $path)"`
+			} `graphql:"... on Commit"`
+		} `graphql:"object(expression: $ref)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// blameRange is a range of lines last changed by the same commit
+type blameRange struct {
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Commit    string `json:"commit"`
+}
+
+// blameCommit is a commit referenced by the ranges of a blame
+type blameCommit struct {
+	SHA     string    `json:"sha"`
+	Author  string    `json:"author"`
+	Login   string    `json:"login,omitempty"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message"`
+	URL     string    `json:"url"`
+}
+
+// blameResult is the result of get_file_blame
+type blameResult struct {
+	Path    string        `json:"path"`
+	Ref     string        `json:"ref"`
+	SHA     string        `json:"sha"`
+	Ranges  []blameRange  `json:"ranges"`
+	Commits []blameCommit `json:"commits"`
+}
+
+// GetFileBlame creates a tool to get the commits that last changed the lines of a file.
+func GetFileBlame(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_blame",
+			mcp.WithDescription(t("TOOL_GET_FILE_BLAME_DESCRIPTION", "Get the blame of a file in a GitHub repository: the ranges of lines along with the commit that last changed them, including its author, date and message. Use start_line and end_line to only blame part of the file")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_BLAME_USER_TITLE", "Get file blame"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path of the file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to blame the file at. Defaults to the default branch of the repository"),
+			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line to blame"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line to blame"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := RequiredParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			startLine, err := OptionalIntParam(request, "start_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			endLine, err := OptionalIntParam(request, "end_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if startLine > 0 && endLine > 0 && endLine < startLine {
+				return mcp.NewToolResultError(fmt.Sprintf("end_line %d is before start_line %d", endLine, startLine)), nil
+			}
+			if ref == "" {
+				ref = "HEAD"
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q blameQuery
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"ref":   githubv4.String(ref),
+				"path":  githubv4.String(path),
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+					fmt.Sprintf("failed to get blame of %s at %s", path, ref),
+					err,
+				), nil
+			}
+			commit := q.Repository.Object.Commit
+			if commit.OID == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("%s does not refer to a commit", ref)), nil
+			}
+
+			result := blameResult{
+				Path:    path,
+				Ref:     ref,
+				SHA:     string(commit.OID),
+				Ranges:  []blameRange{},
+				Commits: []blameCommit{},
+			}
+			seen := make(map[string]bool)
+			for _, r := range commit.Blame.Ranges {
+				br := blameRange{
+					StartLine: int(r.StartingLine),
+					EndLine:   int(r.EndingLine),
+					Commit:    string(r.Commit.OID),
+				}
+				// Clip the range to the requested lines
+				if startLine > 0 {
+					if br.EndLine < startLine {
+						continue
+					}
+					br.StartLine = max(br.StartLine, startLine)
+				}
+				if endLine > 0 {
+					if br.StartLine > endLine {
+						continue
+					}
+					br.EndLine = min(br.EndLine, endLine)
+				}
+				result.Ranges = append(result.Ranges, br)
+
+				if seen[br.Commit] {
+					continue
+				}
+				seen[br.Commit] = true
+				c := blameCommit{
+					SHA:     br.Commit,
+					Author:  string(r.Commit.Author.Name),
+					Login:   string(r.Commit.Author.User.Login),
+					Date:    r.Commit.Author.Date.Time,
+					Message: string(r.Commit.Message),
+				}
+				if r.Commit.URL.URL != nil {
+					c.URL = r.Commit.URL.String()
+				}
+				result.Commits = append(result.Commits, c)
+			}
+
+			out, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(out)), nil
+		}
+}
@@ -0,0 +1,185 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetFileBlame(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetFileBlame(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_file_blame", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "start_line")
+	assert.Contains(t, tool.InputSchema.Properties, "end_line")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	commit := func(sha, name, login, message string) map[string]any {
+		return map[string]any{
+			"oid":     sha,
+			"message": message,
+			"url":     "https://github.com/owner/repo/commit/" + sha,
+			"author": map[string]any{
+				"name": name,
+				"date": "2025-01-02T03:04:05Z",
+				"user": map[string]any{"login": login},
+			},
+		}
+	}
+	blame := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"object": map[string]any{
+				"oid": "head",
+				"blame": map[string]any{
+					"ranges": []map[string]any{
+						{"startingLine": 1, "endingLine": 4, "commit": commit("aaa", "Mona", "octocat", "Initial commit")},
+						{"startingLine": 5, "endingLine": 5, "commit": commit("bbb", "Hubot", "", "Fix off by one\n\nThe loop skipped the last item.")},
+						{"startingLine": 6, "endingLine": 9, "commit": commit("aaa", "Mona", "octocat", "Initial commit")},
+					},
+				},
+			},
+		},
+	})
+	vars := func(ref string) map[string]any {
+		return map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"ref":   githubv4.String(ref),
+			"path":  githubv4.String("main.go"),
+		}
+	}
+
+	tests := []struct {
+		name            string
+		matcher         githubv4mock.Matcher
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedRanges  []blameRange
+		expectedCommits []string
+	}{
+		{
+			name:    "blames the whole file at the default branch",
+			matcher: githubv4mock.NewQueryMatcher(blameQuery{}, vars("HEAD"), blame),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+			},
+			expectedRanges: []blameRange{
+				{StartLine: 1, EndLine: 4, Commit: "aaa"},
+				{StartLine: 5, EndLine: 5, Commit: "bbb"},
+				{StartLine: 6, EndLine: 9, Commit: "aaa"},
+			},
+			expectedCommits: []string{"aaa", "bbb"},
+		},
+		{
+			name:    "clips ranges to the requested lines",
+			matcher: githubv4mock.NewQueryMatcher(blameQuery{}, vars("main"), blame),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"ref":        "main",
+				"start_line": float64(3),
+				"end_line":   float64(5),
+			},
+			expectedRanges: []blameRange{
+				{StartLine: 3, EndLine: 4, Commit: "aaa"},
+				{StartLine: 5, EndLine: 5, Commit: "bbb"},
+			},
+			expectedCommits: []string{"aaa", "bbb"},
+		},
+		{
+			name:    "ref does not refer to a commit",
+			matcher: githubv4mock.NewQueryMatcher(blameQuery{}, vars("missing"), githubv4mock.DataResponse(map[string]any{"repository": map[string]any{"object": nil}})),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing does not refer to a commit",
+		},
+		{
+			name:    "query fails",
+			matcher: githubv4mock.NewQueryMatcher(blameQuery{}, vars("HEAD"), githubv4mock.ErrorResponse("Could not resolve file for path 'main.go'.")),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get blame of main.go at HEAD",
+		},
+		{
+			name: "end line before start line",
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "main.go",
+				"start_line": float64(5),
+				"end_line":   float64(3),
+			},
+			expectError:    true,
+			expectedErrMsg: "end_line 3 is before start_line 5",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			var matchers []githubv4mock.Matcher
+			if tc.matcher.Request != "" {
+				matchers = append(matchers, tc.matcher)
+			}
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matchers...))
+			_, handler := GetFileBlame(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned blameResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "main.go", returned.Path)
+			assert.Equal(t, "head", returned.SHA)
+			assert.Equal(t, tc.expectedRanges, returned.Ranges)
+
+			shas := make([]string, 0, len(returned.Commits))
+			for _, c := range returned.Commits {
+				shas = append(shas, c.SHA)
+			}
+			assert.Equal(t, tc.expectedCommits, shas)
+			assert.Equal(t, "Mona", returned.Commits[0].Author)
+			assert.Equal(t, "octocat", returned.Commits[0].Login)
+			assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), returned.Commits[0].Date.UTC())
+			assert.Equal(t, "https://github.com/owner/repo/commit/aaa", returned.Commits[0].URL)
+			assert.Equal(t, "Fix off by one\n\nThe loop skipped the last item.", returned.Commits[1].Message)
+		})
+	}
+}
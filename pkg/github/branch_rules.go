@@ -0,0 +1,378 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// branchRules are the effective rules of a branch, combining its classic branch protection and the active rulesets
+// targeting it. A rule applies if either of them requires it.
+type branchRules struct {
+	Branch    string `json:"branch"`
+	Protected bool   `json:"protected"`
+	// ProtectionReadable is false if the details of the branch protection could not be read, which needs admin access
+	ProtectionReadable bool `json:"protection_readable"`
+
+	RequiredReviews               *requiredReviews      `json:"required_reviews,omitempty"`
+	RequiredStatusChecks          *requiredStatusChecks `json:"required_status_checks,omitempty"`
+	RequireLinearHistory          bool                  `json:"require_linear_history"`
+	RequireSignedCommits          bool                  `json:"require_signed_commits"`
+	RequireConversationResolution bool                  `json:"require_conversation_resolution"`
+	RequireMergeQueue             bool                  `json:"require_merge_queue"`
+	RequiredDeployments           []string              `json:"required_deployments,omitempty"`
+	AllowedMergeMethods           []string              `json:"allowed_merge_methods,omitempty"`
+	AllowForcePushes              bool                  `json:"allow_force_pushes"`
+	AllowDeletions                bool                  `json:"allow_deletions"`
+	Locked                        bool                  `json:"locked"`
+	EnforceAdmins                 bool                  `json:"enforce_admins"`
+	// PushAllowances lists who may push to the branch if pushes are restricted
+	PushAllowances []string `json:"push_allowances,omitempty"`
+
+	Rulesets []*rulesetSummary `json:"rulesets,omitempty"`
+}
+
+type requiredReviews struct {
+	ApprovingReviewCount    int  `json:"approving_review_count"`
+	RequireCodeOwnerReview  bool `json:"require_code_owner_review"`
+	DismissStaleReviews     bool `json:"dismiss_stale_reviews"`
+	RequireLastPushApproval bool `json:"require_last_push_approval"`
+	// BypassAllowances lists who may merge without the required reviews under the branch protection
+	BypassAllowances []string `json:"bypass_allowances,omitempty"`
+}
+
+type requiredStatusChecks struct {
+	Contexts []string `json:"contexts"`
+	// Strict requires the branch of a pull request to be up to date with the base branch before merging
+	Strict bool `json:"strict"`
+}
+
+// rulesetSummary describes a ruleset with rules applying to the branch
+type rulesetSummary struct {
+	ID                   int64          `json:"id"`
+	Name                 string         `json:"name,omitempty"`
+	Source               string         `json:"source"`
+	SourceType           string         `json:"source_type"`
+	Enforcement          string         `json:"enforcement,omitempty"`
+	Rules                []string       `json:"rules"`
+	BypassActors         []*bypassActor `json:"bypass_actors,omitempty"`
+	CurrentUserCanBypass string         `json:"current_user_can_bypass,omitempty"`
+}
+
+type bypassActor struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id,omitempty"`
+	Mode string `json:"mode"`
+}
+
+// GetBranchRules creates a tool to get the effective branch protection and rulesets of a branch.
+func GetBranchRules(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_branch_rules",
+			mcp.WithDescription(t("TOOL_GET_BRANCH_RULES_DESCRIPTION", "Get the rules that apply to pushes and merges to a branch of a GitHub repository, combining its branch protection and the rulesets targeting it: required reviews and status checks, linear history, signed commits, merge queue, push restrictions, and the actors that can bypass rulesets. Use this to find out why a push or merge was rejected")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_BRANCH_RULES_USER_TITLE", "Get branch protection and rulesets"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rules, resp, err := getBranchRules(ctx, client, owner, repo, branch)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get rules of branch %s", branch), resp, err), nil
+			}
+
+			r, err := json.Marshal(rules)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// getBranchRules reads the branch protection of branch and the rules of the rulesets targeting it.
+// The returned response is the one of the failed request if there is an error.
+func getBranchRules(ctx context.Context, client *github.Client, owner, repo, branch string) (*branchRules, *github.Response, error) {
+	rules := &branchRules{Branch: branch, ProtectionReadable: true}
+
+	protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+	switch {
+	case errors.Is(err, github.ErrBranchNotProtected):
+	case err != nil && resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden):
+		// Without admin access only the summary of the protection included in the branch can be read
+		rules.ProtectionReadable = false
+		b, resp, err := client.Repositories.GetBranch(ctx, owner, repo, branch, 1)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		rules.Protected = b.GetProtected()
+		if b.Protection != nil {
+			rules.addProtection(b.Protection)
+		}
+	case err != nil:
+		return nil, resp, err
+	default:
+		_ = resp.Body.Close()
+		rules.Protected = true
+		rules.addProtection(protection)
+	}
+	if !rules.Protected {
+		// Without protection and rulesets, anything goes
+		rules.AllowForcePushes = true
+		rules.AllowDeletions = true
+	}
+
+	rulesetRules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, branch, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+	rules.addRulesets(rulesetRules)
+
+	// Bypass actors are only part of the rulesets themselves, and only visible to users who can edit them
+	for _, rs := range rules.Rulesets {
+		ruleset, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, rs.ID, true)
+		if err != nil {
+			continue
+		}
+		_ = resp.Body.Close()
+		rs.Name = ruleset.Name
+		rs.Enforcement = string(ruleset.Enforcement)
+		if ruleset.CurrentUserCanBypass != nil {
+			rs.CurrentUserCanBypass = string(*ruleset.CurrentUserCanBypass)
+		}
+		for _, a := range ruleset.BypassActors {
+			actor := &bypassActor{ID: a.GetActorID()}
+			if a.ActorType != nil {
+				actor.Type = string(*a.ActorType)
+			}
+			if a.BypassMode != nil {
+				actor.Mode = string(*a.BypassMode)
+			}
+			rs.BypassActors = append(rs.BypassActors, actor)
+		}
+	}
+
+	return rules, nil, nil
+}
+
+// addProtection adds the rules of a classic branch protection
+func (r *branchRules) addProtection(p *github.Protection) {
+	if checks := p.RequiredStatusChecks; checks != nil {
+		var contexts []string
+		if checks.Checks != nil {
+			for _, c := range *checks.Checks {
+				contexts = append(contexts, c.Context)
+			}
+		} else if checks.Contexts != nil {
+			contexts = *checks.Contexts
+		}
+		r.addStatusChecks(contexts, checks.Strict)
+	}
+	if reviews := p.RequiredPullRequestReviews; reviews != nil {
+		var allowances []string
+		if b := reviews.BypassPullRequestAllowances; b != nil {
+			for _, u := range b.Users {
+				allowances = append(allowances, u.GetLogin())
+			}
+			for _, team := range b.Teams {
+				allowances = append(allowances, "team:"+team.GetSlug())
+			}
+			for _, app := range b.Apps {
+				allowances = append(allowances, "app:"+app.GetSlug())
+			}
+		}
+		r.addReviews(reviews.RequiredApprovingReviewCount, reviews.RequireCodeOwnerReviews, reviews.DismissStaleReviews, reviews.RequireLastPushApproval)
+		r.RequiredReviews.BypassAllowances = append(r.RequiredReviews.BypassAllowances, allowances...)
+	}
+	if restrictions := p.Restrictions; restrictions != nil {
+		r.PushAllowances = []string{}
+		for _, u := range restrictions.Users {
+			r.PushAllowances = append(r.PushAllowances, u.GetLogin())
+		}
+		for _, team := range restrictions.Teams {
+			r.PushAllowances = append(r.PushAllowances, "team:"+team.GetSlug())
+		}
+		for _, app := range restrictions.Apps {
+			r.PushAllowances = append(r.PushAllowances, "app:"+app.GetSlug())
+		}
+	}
+	r.RequireLinearHistory = r.RequireLinearHistory || p.RequireLinearHistory != nil && p.RequireLinearHistory.Enabled
+	r.RequireSignedCommits = r.RequireSignedCommits || p.RequiredSignatures != nil && p.RequiredSignatures.GetEnabled()
+	r.RequireConversationResolution = r.RequireConversationResolution || p.RequiredConversationResolution != nil && p.RequiredConversationResolution.Enabled
+	r.AllowForcePushes = p.AllowForcePushes != nil && p.AllowForcePushes.Enabled
+	r.AllowDeletions = p.AllowDeletions != nil && p.AllowDeletions.Enabled
+	r.Locked = p.LockBranch != nil && p.LockBranch.GetEnabled()
+	r.EnforceAdmins = p.EnforceAdmins != nil && p.EnforceAdmins.Enabled
+}
+
+// addRulesets adds the rules of the rulesets targeting the branch
+func (r *branchRules) addRulesets(b *github.BranchRules) {
+	rulesets := map[int64]*rulesetSummary{}
+	add := func(m github.BranchRuleMetadata, rule string) {
+		rs, ok := rulesets[m.RulesetID]
+		if !ok {
+			rs = &rulesetSummary{ID: m.RulesetID, Source: m.RulesetSource, SourceType: string(m.RulesetSourceType)}
+			rulesets[m.RulesetID] = rs
+			r.Rulesets = append(r.Rulesets, rs)
+		}
+		if !slices.Contains(rs.Rules, rule) {
+			rs.Rules = append(rs.Rules, rule)
+		}
+	}
+
+	for _, rule := range b.PullRequest {
+		add(rule.BranchRuleMetadata, "pull_request")
+		p := rule.Parameters
+		r.addReviews(p.RequiredApprovingReviewCount, p.RequireCodeOwnerReview, p.DismissStaleReviewsOnPush, p.RequireLastPushApproval)
+		r.RequireConversationResolution = r.RequireConversationResolution || p.RequiredReviewThreadResolution
+		if len(p.AllowedMergeMethods) > 0 {
+			// Every ruleset must allow the merge method
+			var methods []string
+			for _, m := range p.AllowedMergeMethods {
+				if r.AllowedMergeMethods == nil || slices.Contains(r.AllowedMergeMethods, string(m)) {
+					methods = append(methods, string(m))
+				}
+			}
+			r.AllowedMergeMethods = append([]string{}, methods...)
+		}
+	}
+	for _, rule := range b.RequiredStatusChecks {
+		add(rule.BranchRuleMetadata, "required_status_checks")
+		var contexts []string
+		for _, c := range rule.Parameters.RequiredStatusChecks {
+			contexts = append(contexts, c.Context)
+		}
+		r.addStatusChecks(contexts, rule.Parameters.StrictRequiredStatusChecksPolicy)
+	}
+	for _, rule := range b.RequiredLinearHistory {
+		add(*rule, "required_linear_history")
+		r.RequireLinearHistory = true
+	}
+	for _, rule := range b.RequiredSignatures {
+		add(*rule, "required_signatures")
+		r.RequireSignedCommits = true
+	}
+	for _, rule := range b.MergeQueue {
+		add(rule.BranchRuleMetadata, "merge_queue")
+		r.RequireMergeQueue = true
+	}
+	for _, rule := range b.RequiredDeployments {
+		add(rule.BranchRuleMetadata, "required_deployments")
+		for _, env := range rule.Parameters.RequiredDeploymentEnvironments {
+			if !slices.Contains(r.RequiredDeployments, env) {
+				r.RequiredDeployments = append(r.RequiredDeployments, env)
+			}
+		}
+	}
+	for _, rule := range b.NonFastForward {
+		add(*rule, "non_fast_forward")
+		r.AllowForcePushes = false
+	}
+	for _, rule := range b.Deletion {
+		add(*rule, "deletion")
+		r.AllowDeletions = false
+	}
+	for _, rule := range b.Update {
+		add(rule.BranchRuleMetadata, "update")
+		r.Locked = true
+	}
+	for _, rule := range b.Creation {
+		add(*rule, "creation")
+	}
+
+	// Rules restricting the content of pushes are listed by their ruleset only
+	for _, rule := range b.CommitMessagePattern {
+		add(rule.BranchRuleMetadata, "commit_message_pattern")
+	}
+	for _, rule := range b.CommitAuthorEmailPattern {
+		add(rule.BranchRuleMetadata, "commit_author_email_pattern")
+	}
+	for _, rule := range b.CommitterEmailPattern {
+		add(rule.BranchRuleMetadata, "committer_email_pattern")
+	}
+	for _, rule := range b.BranchNamePattern {
+		add(rule.BranchRuleMetadata, "branch_name_pattern")
+	}
+	for _, rule := range b.FilePathRestriction {
+		add(rule.BranchRuleMetadata, "file_path_restriction")
+	}
+	for _, rule := range b.MaxFilePathLength {
+		add(rule.BranchRuleMetadata, "max_file_path_length")
+	}
+	for _, rule := range b.FileExtensionRestriction {
+		add(rule.BranchRuleMetadata, "file_extension_restriction")
+	}
+	for _, rule := range b.MaxFileSize {
+		add(rule.BranchRuleMetadata, "max_file_size")
+	}
+	for _, rule := range b.Workflows {
+		add(rule.BranchRuleMetadata, "workflows")
+	}
+	for _, rule := range b.CodeScanning {
+		add(rule.BranchRuleMetadata, "code_scanning")
+	}
+}
+
+func (r *branchRules) addReviews(count int, codeOwners, dismissStale, lastPush bool) {
+	if r.RequiredReviews == nil {
+		r.RequiredReviews = &requiredReviews{}
+	}
+	reviews := r.RequiredReviews
+	reviews.ApprovingReviewCount = max(reviews.ApprovingReviewCount, count)
+	reviews.RequireCodeOwnerReview = reviews.RequireCodeOwnerReview || codeOwners
+	reviews.DismissStaleReviews = reviews.DismissStaleReviews || dismissStale
+	reviews.RequireLastPushApproval = reviews.RequireLastPushApproval || lastPush
+}
+
+func (r *branchRules) addStatusChecks(contexts []string, strict bool) {
+	if r.RequiredStatusChecks == nil {
+		r.RequiredStatusChecks = &requiredStatusChecks{Contexts: []string{}}
+	}
+	checks := r.RequiredStatusChecks
+	for _, c := range contexts {
+		if !slices.Contains(checks.Contexts, c) {
+			checks.Contexts = append(checks.Contexts, c)
+		}
+	}
+	checks.Strict = checks.Strict || strict
+}
@@ -0,0 +1,206 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mockProtection = `{
+		"required_status_checks": {"strict": false, "checks": [{"context": "build"}]},
+		"required_pull_request_reviews": {
+			"required_approving_review_count": 1,
+			"dismiss_stale_reviews": true,
+			"bypass_pull_request_allowances": {"users": [{"login": "octocat"}], "teams": [{"slug": "admins"}], "apps": []}
+		},
+		"enforce_admins": {"enabled": true},
+		"required_signatures": {"enabled": true},
+		"allow_force_pushes": {"enabled": false},
+		"allow_deletions": {"enabled": false}
+	}`
+	mockBranchRules = `[
+		{"type": "pull_request", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 7, "parameters": {
+			"required_approving_review_count": 2, "require_code_owner_review": true, "dismiss_stale_reviews_on_push": false,
+			"require_last_push_approval": false, "required_review_thread_resolution": true, "allowed_merge_methods": ["squash", "rebase"]
+		}},
+		{"type": "required_status_checks", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 7, "parameters": {
+			"strict_required_status_checks_policy": true, "required_status_checks": [{"context": "build"}, {"context": "lint"}]
+		}},
+		{"type": "required_linear_history", "ruleset_source_type": "Organization", "ruleset_source": "owner", "ruleset_id": 9},
+		{"type": "non_fast_forward", "ruleset_source_type": "Organization", "ruleset_source": "owner", "ruleset_id": 9}
+	]`
+)
+
+func Test_GetBranchRules(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBranchRules(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_branch_rules", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch"})
+
+	rulesets := mock.WithRequestMatchHandler(
+		mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "true", r.URL.Query().Get("includes_parents"))
+			if r.URL.Path == "/repos/owner/repo/rulesets/9" {
+				mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+				return
+			}
+			mockResponse(t, http.StatusOK, `{
+				"id": 7, "name": "main", "source": "owner/repo", "enforcement": "active", "current_user_can_bypass": "never",
+				"bypass_actors": [{"actor_id": 5, "actor_type": "RepositoryRole", "bypass_mode": "always"}]
+			}`)(w, r)
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       *branchRules
+	}{
+		{
+			name: "branch protection and rulesets are combined",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusOK, mockProtection),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusOK, mockBranchRules),
+				),
+				rulesets,
+			),
+			expected: &branchRules{
+				Branch:             "main",
+				Protected:          true,
+				ProtectionReadable: true,
+				RequiredReviews: &requiredReviews{
+					ApprovingReviewCount:   2,
+					RequireCodeOwnerReview: true,
+					DismissStaleReviews:    true,
+					BypassAllowances:       []string{"octocat", "team:admins"},
+				},
+				RequiredStatusChecks:          &requiredStatusChecks{Contexts: []string{"build", "lint"}, Strict: true},
+				RequireLinearHistory:          true,
+				RequireSignedCommits:          true,
+				RequireConversationResolution: true,
+				AllowedMergeMethods:           []string{"squash", "rebase"},
+				EnforceAdmins:                 true,
+				Rulesets: []*rulesetSummary{
+					{
+						ID: 7, Name: "main", Source: "owner/repo", SourceType: "Repository", Enforcement: "active",
+						Rules:                []string{"pull_request", "required_status_checks"},
+						BypassActors:         []*bypassActor{{Type: "RepositoryRole", ID: 5, Mode: "always"}},
+						CurrentUserCanBypass: "never",
+					},
+					{ID: 9, Source: "owner", SourceType: "Organization", Rules: []string{"required_linear_history", "non_fast_forward"}},
+				},
+			},
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusOK, `[]`),
+				),
+			),
+			expected: &branchRules{
+				Branch:             "main",
+				ProtectionReadable: true,
+				AllowForcePushes:   true,
+				AllowDeletions:     true,
+			},
+		},
+		{
+			name: "branch protection without admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					&github.Branch{
+						Name:      github.Ptr("main"),
+						Protected: github.Ptr(true),
+						Protection: &github.Protection{
+							RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: &[]string{"ci"}},
+						},
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusOK, `[]`),
+				),
+			),
+			expected: &branchRules{
+				Branch:               "main",
+				Protected:            true,
+				RequiredStatusChecks: &requiredStatusChecks{Contexts: []string{"ci"}},
+			},
+		},
+		{
+			name: "rules request fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Internal Server Error"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get rules of branch main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchRules(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned branchRules
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, &returned)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/codeowners"
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// codeOwnersResult lists the owners of paths according to the CODEOWNERS file of a ref
+type codeOwnersResult struct {
+	Ref string `json:"ref,omitempty"`
+	// Path is the location of the CODEOWNERS file, empty if the repository has none
+	Path  string        `json:"path"`
+	Files []*pathOwners `json:"files"`
+	// Owners lists the paths owned by each owner
+	Owners  map[string][]string `json:"owners"`
+	Unowned []string            `json:"unowned,omitempty"`
+	// Truncated is true if the pull request changes more files than listed
+	Truncated bool                      `json:"truncated,omitempty"`
+	Errors    []*github.CodeownersError `json:"errors,omitempty"`
+}
+
+type pathOwners struct {
+	Path   string   `json:"path"`
+	Owners []string `json:"owners"`
+	// Pattern and Line locate the rule of the CODEOWNERS file assigning the owners
+	Pattern string `json:"pattern,omitempty"`
+	Line    int    `json:"line,omitempty"`
+}
+
+// GetCodeOwners creates a tool to find the code owners of paths or of the files changed by a pull request.
+func GetCodeOwners(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_code_owners",
+			mcp.WithDescription(t("TOOL_GET_CODE_OWNERS_DESCRIPTION", "Get the code owners of paths in a GitHub repository, or of all files changed by a pull request, from its CODEOWNERS file. The owners of a path are those of the last matching line of the file. Syntax errors of the CODEOWNERS file are returned as well")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CODE_OWNERS_USER_TITLE", "Get code owners"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithArray("paths",
+				mcp.Description("Paths of files to get the owners of. Required unless pullNumber is given"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Description("Pull request to get the owners of the changed files of"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to read the CODEOWNERS file from. Defaults to the base branch of the pull request, or the default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := OptionalIntParam(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(paths) == 0 && pullNumber == 0 {
+				return mcp.NewToolResultError("paths or pullNumber is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := &codeOwnersResult{Ref: ref, Files: []*pathOwners{}, Owners: map[string][]string{}}
+			if pullNumber != 0 {
+				if ref == "" {
+					pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+					}
+					_ = resp.Body.Close()
+					result.Ref = pr.GetBase().GetRef()
+				}
+				files, truncated, resp, err := listPullRequestFiles(ctx, client, owner, repo, pullNumber, maxResourcePages)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request files", resp, err), nil
+				}
+				for _, f := range files {
+					paths = append(paths, f.GetFilename())
+				}
+				result.Truncated = truncated
+			}
+
+			file, path, resp, err := getCodeOwnersFile(ctx, client, owner, repo, result.Ref)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get CODEOWNERS file", resp, err), nil
+			}
+			result.Path = path
+			if path != "" {
+				errs, resp, err := client.Repositories.GetCodeownersErrors(ctx, owner, repo, &github.GetCodeownersErrorsOptions{Ref: result.Ref})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get CODEOWNERS errors", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				result.Errors = errs.Errors
+			}
+
+			for _, p := range paths {
+				owners := &pathOwners{Path: p, Owners: []string{}}
+				if rule := file.Match(p); rule != nil {
+					owners.Owners = append(owners.Owners, rule.Owners...)
+					owners.Pattern = rule.Pattern
+					owners.Line = rule.Line
+				}
+				if len(owners.Owners) == 0 {
+					result.Unowned = append(result.Unowned, p)
+				}
+				for _, o := range owners.Owners {
+					result.Owners[o] = append(result.Owners[o], p)
+				}
+				result.Files = append(result.Files, owners)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// getCodeOwnersFile reads and parses the CODEOWNERS file used by GitHub at ref, and returns its path.
+// The file has no rules and its path is empty if there is none.
+func getCodeOwnersFile(ctx context.Context, client *github.Client, owner, repo, ref string) (*codeowners.File, string, *github.Response, error) {
+	for _, path := range codeowners.Locations {
+		content, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, "", resp, err
+		}
+		_ = resp.Body.Close()
+		if content == nil {
+			// A directory named CODEOWNERS
+			continue
+		}
+		if content.GetEncoding() == "none" {
+			// The contents API leaves out the content of files over 1 MB, so it is read from the blob
+			b, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, content.GetSHA())
+			if err != nil {
+				return nil, "", resp, err
+			}
+			_ = resp.Body.Close()
+			return codeowners.Parse(string(b)), path, nil, nil
+		}
+		text, err := content.GetContent()
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		return codeowners.Parse(text), path, nil, nil
+	}
+	return &codeowners.File{}, "", nil, nil
+}
@@ -0,0 +1,260 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCodeOwners(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCodeOwners(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_code_owners", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "paths")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	codeOwners := "* @org/maintainers\n*.go @gophers @alice\n/docs/ @writers\n/docs/generated/\n"
+	// contents serves the CODEOWNERS file at path, and checks the ref it is read at
+	contents := func(path, ref string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposContentsByOwnerByRepoByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, ref, r.URL.Query().Get("ref"))
+				if r.URL.Path != "/repos/owner/repo/contents/"+path {
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`)(w, r)
+					return
+				}
+				mockResponse(t, http.StatusOK, &github.RepositoryContent{
+					Type:     github.Ptr("file"),
+					Path:     github.Ptr(path),
+					Encoding: github.Ptr("base64"),
+					Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(codeOwners))),
+				})(w, r)
+			}),
+		)
+	}
+	codeOwnersErrors := mock.WithRequestMatch(
+		mock.GetReposCodeownersErrorsByOwnerByRepo,
+		&github.CodeownersErrors{Errors: []*github.CodeownersError{
+			{Line: 5, Column: 7, Kind: "Unknown owner", Source: "*.md @ghost", Message: "Unknown owner on line 5", Path: ".github/CODEOWNERS"},
+		}},
+	)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       *codeOwnersResult
+	}{
+		{
+			name:         "owners of paths",
+			mockedClient: mock.NewMockedHTTPClient(contents(".github/CODEOWNERS", "v1.0"), codeOwnersErrors),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"paths": []interface{}{"README.md", "cmd/main.go", "docs/guide.go", "docs/generated/api.md"},
+				"ref":   "v1.0",
+			},
+			expected: &codeOwnersResult{
+				Ref:  "v1.0",
+				Path: ".github/CODEOWNERS",
+				Files: []*pathOwners{
+					{Path: "README.md", Owners: []string{"@org/maintainers"}, Pattern: "*", Line: 1},
+					{Path: "cmd/main.go", Owners: []string{"@gophers", "@alice"}, Pattern: "*.go", Line: 2},
+					{Path: "docs/guide.go", Owners: []string{"@writers"}, Pattern: "/docs/", Line: 3},
+					{Path: "docs/generated/api.md", Owners: []string{}, Pattern: "/docs/generated/", Line: 4},
+				},
+				Owners: map[string][]string{
+					"@org/maintainers": {"README.md"},
+					"@gophers":         {"cmd/main.go"},
+					"@alice":           {"cmd/main.go"},
+					"@writers":         {"docs/guide.go"},
+				},
+				Unowned: []string{"docs/generated/api.md"},
+				Errors: []*github.CodeownersError{
+					{Line: 5, Column: 7, Kind: "Unknown owner", Source: "*.md @ghost", Message: "Unknown owner on line 5", Path: ".github/CODEOWNERS"},
+				},
+			},
+		},
+		{
+			name: "owners of the files of a pull request at its base branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					&github.PullRequest{Number: github.Ptr(42), Base: &github.PullRequestBranch{Ref: github.Ptr("release")}},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposPullsFilesByOwnerByRepoByPullNumber,
+					[]*github.CommitFile{{Filename: github.Ptr("main.go")}, {Filename: github.Ptr("docs/index.md")}},
+				),
+				contents("docs/CODEOWNERS", "release"),
+				mock.WithRequestMatch(mock.GetReposCodeownersErrorsByOwnerByRepo, &github.CodeownersErrors{}),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expected: &codeOwnersResult{
+				Ref:  "release",
+				Path: "docs/CODEOWNERS",
+				Files: []*pathOwners{
+					{Path: "main.go", Owners: []string{"@gophers", "@alice"}, Pattern: "*.go", Line: 2},
+					{Path: "docs/index.md", Owners: []string{"@writers"}, Pattern: "/docs/", Line: 3},
+				},
+				Owners: map[string][]string{
+					"@gophers": {"main.go"},
+					"@alice":   {"main.go"},
+					"@writers": {"docs/index.md"},
+				},
+			},
+		},
+		{
+			name: "repository without CODEOWNERS",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"paths": []interface{}{"README.md"},
+			},
+			expected: &codeOwnersResult{
+				Files:   []*pathOwners{{Path: "README.md", Owners: []string{}}},
+				Owners:  map[string][]string{},
+				Unowned: []string{"README.md"},
+			},
+		},
+		{
+			name: "CODEOWNERS over 1 MB",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						Path:     github.Ptr(".github/CODEOWNERS"),
+						SHA:      github.Ptr("large"),
+						Size:     github.Ptr(2 << 20),
+						Encoding: github.Ptr("none"),
+						Content:  github.Ptr(""),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					expectPath(t, "/repos/owner/repo/git/blobs/large").andThen(
+						mockResponse(t, http.StatusOK, codeOwners),
+					),
+				),
+				mock.WithRequestMatch(mock.GetReposCodeownersErrorsByOwnerByRepo, &github.CodeownersErrors{}),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"paths": []interface{}{"main.go"},
+			},
+			expected: &codeOwnersResult{
+				Path:  ".github/CODEOWNERS",
+				Files: []*pathOwners{{Path: "main.go", Owners: []string{"@gophers", "@alice"}, Pattern: "*.go", Line: 2}},
+				Owners: map[string][]string{
+					"@gophers": {"main.go"},
+					"@alice":   {"main.go"},
+				},
+			},
+		},
+		{
+			name: "reading CODEOWNERS over 1 MB fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					&github.RepositoryContent{
+						Type:     github.Ptr("file"),
+						Path:     github.Ptr(".github/CODEOWNERS"),
+						SHA:      github.Ptr("large"),
+						Size:     github.Ptr(2 << 20),
+						Encoding: github.Ptr("none"),
+					},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"paths": []interface{}{"main.go"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get CODEOWNERS file",
+		},
+		{
+			name:         "neither paths nor pull request",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "paths or pullNumber is required",
+		},
+		{
+			name: "reading CODEOWNERS fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"paths": []interface{}{"README.md"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get CODEOWNERS file",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetCodeOwners(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned codeOwnersResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, &returned)
+		})
+	}
+}
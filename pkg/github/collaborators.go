@@ -0,0 +1,596 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// repositoryPermissions are the base repository roles, from the highest to the lowest
+var repositoryPermissions = []string{"admin", "maintain", "push", "triage", "pull"}
+
+// collaborator is a user with access to a repository
+type collaborator struct {
+	Login string `json:"login"`
+	ID    int64  `json:"id"`
+	// Permission is the highest base role of the user, and RoleName their role, which may be a custom one
+	Permission string `json:"permission"`
+	RoleName   string `json:"role_name,omitempty"`
+	// Affiliation is the affiliation the collaborators were filtered by. Without a filter, it is outside for those
+	// who are not members of the organization owning the repository, and member for everyone else
+	Affiliation string `json:"affiliation,omitempty"`
+	HTMLURL     string `json:"html_url"`
+}
+
+type repositoryTeam struct {
+	ID          int64  `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Permission  string `json:"permission"`
+	Privacy     string `json:"privacy,omitempty"`
+	Parent      string `json:"parent,omitempty"`
+	Description string `json:"description,omitempty"`
+	HTMLURL     string `json:"html_url"`
+}
+
+type repositoryInvitation struct {
+	ID          int64             `json:"id"`
+	Invitee     string            `json:"invitee"`
+	Inviter     string            `json:"inviter"`
+	Permissions string            `json:"permissions"`
+	CreatedAt   *github.Timestamp `json:"created_at,omitempty"`
+	Expired     bool              `json:"expired"`
+	HTMLURL     string            `json:"html_url"`
+}
+
+func highestPermission(permissions map[string]bool) string {
+	for _, p := range repositoryPermissions {
+		if permissions[p] {
+			return p
+		}
+	}
+	return ""
+}
+
+// permissionRank ranks the base repository roles from 1 for pull to 5 for admin, custom roles rank 0
+func permissionRank(permission string) int {
+	if i := slices.Index(repositoryPermissions, permission); i >= 0 {
+		return len(repositoryPermissions) - i
+	}
+	return 0
+}
+
+// ListCollaborators creates a tool to list the users with access to a repository.
+func ListCollaborators(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_collaborators",
+			mcp.WithDescription(t("TOOL_LIST_COLLABORATORS_DESCRIPTION", "List the users with access to a GitHub repository, with their permission and whether they are outside collaborators or members of the organization. Filter by the direct affiliation for the users added to the repository itself, and use list_repository_teams for the teams with access")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COLLABORATORS_USER_TITLE", "List repository collaborators"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("affiliation",
+				mcp.Description("Filter collaborators by affiliation. Defaults to all"),
+				mcp.Enum("all", "direct", "outside"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Only list collaborators with this permission"),
+				mcp.Enum(repositoryPermissions...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			affiliation, err := OptionalParam[string](request, "affiliation")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if affiliation == "" {
+				affiliation = "all"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			users, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, &github.ListCollaboratorsOptions{
+				Affiliation: affiliation,
+				Permission:  permission,
+				ListOptions: github.ListOptions{Page: pagination.page, PerPage: pagination.perPage},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list collaborators", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			// The affiliation of users is only known by listing the outside collaborators
+			var outside map[string]bool
+			if affiliation == "all" {
+				logins := make([]string, len(users))
+				for i, u := range users {
+					logins[i] = u.GetLogin()
+				}
+				outside, resp, err = findOutsideCollaborators(ctx, client, owner, repo, logins)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list outside collaborators", resp, err), nil
+				}
+			}
+
+			collaborators := make([]*collaborator, 0, len(users))
+			for _, u := range users {
+				c := &collaborator{
+					Login:       u.GetLogin(),
+					ID:          u.GetID(),
+					Permission:  highestPermission(u.Permissions),
+					RoleName:    u.GetRoleName(),
+					Affiliation: affiliation,
+					HTMLURL:     u.GetHTMLURL(),
+				}
+				if affiliation == "all" {
+					c.Affiliation = "member"
+					if outside[c.Login] {
+						c.Affiliation = "outside"
+					}
+				}
+				collaborators = append(collaborators, c)
+			}
+
+			r, err := json.Marshal(collaborators)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// findOutsideCollaborators returns which of logins are outside collaborators of a repository, listing them until
+// every login is found
+func findOutsideCollaborators(ctx context.Context, client *github.Client, owner, repo string, logins []string) (map[string]bool, *github.Response, error) {
+	outside := map[string]bool{}
+	if len(logins) == 0 {
+		return outside, nil, nil
+	}
+	opts := &github.ListCollaboratorsOptions{Affiliation: "outside", ListOptions: github.ListOptions{PerPage: 100}}
+	for range maxResourcePages {
+		users, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		for _, u := range users {
+			if slices.Contains(logins, u.GetLogin()) {
+				outside[u.GetLogin()] = true
+			}
+		}
+		if len(outside) == len(logins) || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return outside, nil, nil
+}
+
+func ListRepositoryTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_teams",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_TEAMS_DESCRIPTION", "List the teams with access to a GitHub repository and their permission")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_TEAMS_USER_TITLE", "List repository teams"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			teams, resp, err := client.Repositories.ListTeams(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list repository teams", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]*repositoryTeam, 0, len(teams))
+			for _, team := range teams {
+				result = append(result, &repositoryTeam{
+					ID:          team.GetID(),
+					Slug:        team.GetSlug(),
+					Name:        team.GetName(),
+					Permission:  team.GetPermission(),
+					Privacy:     team.GetPrivacy(),
+					Parent:      team.GetParent().GetSlug(),
+					Description: team.GetDescription(),
+					HTMLURL:     team.GetHTMLURL(),
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListRepositoryInvitations creates a tool to list the pending invitations to collaborate on a repository.
+func ListRepositoryInvitations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repository_invitations",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORY_INVITATIONS_DESCRIPTION", "List the pending invitations to collaborate on a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORY_INVITATIONS_USER_TITLE", "List repository invitations"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			invitations, resp, err := client.Repositories.ListInvitations(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list repository invitations", resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]*repositoryInvitation, 0, len(invitations))
+			for _, i := range invitations {
+				result = append(result, &repositoryInvitation{
+					ID:          i.GetID(),
+					Invitee:     i.GetInvitee().GetLogin(),
+					Inviter:     i.GetInviter().GetLogin(),
+					Permissions: i.GetPermissions(),
+					CreatedAt:   i.CreatedAt,
+					Expired:     i.GetExpired(),
+					HTMLURL:     i.GetHTMLURL(),
+				})
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// AddCollaborator creates a tool to give a user access to a repository.
+func AddCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_collaborator",
+			mcp.WithDescription(t("TOOL_ADD_COLLABORATOR_DESCRIPTION", "Give a user access to a GitHub repository. Users who are not collaborators yet are sent an invitation they must accept, and the permission of existing collaborators is changed. Lowering the permission of a user requires allow_downgrade")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_ADD_COLLABORATOR_USER_TITLE", "Add repository collaborator"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Login of the user"),
+			),
+			mcp.WithString("permission",
+				mcp.Description("Permission to grant: pull, triage, push, maintain, admin, or the name of a custom repository role. Defaults to push"),
+			),
+			mcp.WithBoolean("allow_downgrade",
+				mcp.Description("Allow lowering the permission of a user who has more access already, or replacing it with a custom role"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := OptionalParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if permission == "" {
+				permission = "push"
+			}
+			allowDowngrade, err := OptionalParam[bool](request, "allow_downgrade")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Existing collaborators get the requested permission even when it is lower than theirs
+			level, resp, err := client.Repositories.GetPermissionLevel(ctx, owner, repo, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get permission of %s", username), resp, err), nil
+			}
+			_ = resp.Body.Close()
+			current := highestPermission(level.GetUser().Permissions)
+			if current != "" && !allowDowngrade && permissionRank(permission) < permissionRank(current) {
+				return mcp.NewToolResultError(fmt.Sprintf("%s has %s access to %s/%s already, which %s may lower. Set allow_downgrade to change it anyway", username, current, owner, repo, permission)), nil
+			}
+
+			invitation, resp, err := client.Repositories.AddCollaborator(ctx, owner, repo, username, &github.RepositoryAddCollaboratorOptions{
+				Permission: permission,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to add collaborator %s", username), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// GitHub answers with an invitation for new collaborators, and without content for existing ones
+			if resp.StatusCode == http.StatusNoContent || invitation.GetID() == 0 {
+				return mcp.NewToolResultText(fmt.Sprintf("%s has %s access to %s/%s", username, permission, owner, repo)), nil
+			}
+
+			r, err := json.Marshal(&repositoryInvitation{
+				ID:          invitation.GetID(),
+				Invitee:     invitation.GetInvitee().GetLogin(),
+				Inviter:     invitation.GetInviter().GetLogin(),
+				Permissions: invitation.GetPermissions(),
+				CreatedAt:   invitation.CreatedAt,
+				HTMLURL:     invitation.GetHTMLURL(),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RemoveCollaborator creates a tool to remove the access of a user to a repository.
+func RemoveCollaborator(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_collaborator",
+			mcp.WithDescription(t("TOOL_REMOVE_COLLABORATOR_DESCRIPTION", "Remove a collaborator from a GitHub repository, and cancel their pending invitations. Access through the organization or its teams is not removed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_COLLABORATOR_USER_TITLE", "Remove repository collaborator"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("Login of the user"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := RequiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Invitations are not collaborators yet, and are cancelled separately
+			cancelled := 0
+			opts := &github.ListOptions{PerPage: 100}
+			for range maxResourcePages {
+				invitations, resp, err := client.Repositories.ListInvitations(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list repository invitations", resp, err), nil
+				}
+				_ = resp.Body.Close()
+				for _, i := range invitations {
+					if !strings.EqualFold(i.GetInvitee().GetLogin(), username) {
+						continue
+					}
+					resp, err := client.Repositories.DeleteInvitation(ctx, owner, repo, i.GetID())
+					if err != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to cancel invitation %d", i.GetID()), resp, err), nil
+					}
+					_ = resp.Body.Close()
+					cancelled++
+				}
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			resp, err := client.Repositories.RemoveCollaborator(ctx, owner, repo, username)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to remove collaborator %s", username), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			message := fmt.Sprintf("%s has been removed from the collaborators of %s/%s", username, owner, repo)
+			if cancelled > 0 {
+				message += fmt.Sprintf(", and %d pending invitations have been cancelled", cancelled)
+			}
+			return mcp.NewToolResultText(message), nil
+		}
+}
+
+// SetTeamRepositoryPermission creates a tool to give a team access to a repository, change it or remove it.
+func SetTeamRepositoryPermission(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_team_permission",
+			mcp.WithDescription(t("TOOL_SET_TEAM_PERMISSION_DESCRIPTION", "Set the permission of an organization team on a GitHub repository, giving the team access, changing it, or removing it with the permission none")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_SET_TEAM_PERMISSION_USER_TITLE", "Set team repository permission"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Slug of the team"),
+			),
+			mcp.WithString("permission",
+				mcp.Required(),
+				mcp.Description("Permission to set: pull, triage, push, maintain, admin, the name of a custom repository role, or none to remove the access of the team"),
+			),
+			mcp.WithString("org",
+				mcp.Description("Organization of the team. Defaults to the repository owner"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			slug, err := RequiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := RequiredParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			org, err := OptionalParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if org == "" {
+				org = owner
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if permission == "none" {
+				resp, err := client.Teams.RemoveTeamRepoBySlug(ctx, org, slug, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to remove access of team %s", slug), resp, err), nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				return mcp.NewToolResultText(fmt.Sprintf("Team %s/%s no longer has access to %s/%s", org, slug, owner, repo)), nil
+			}
+
+			resp, err := client.Teams.AddTeamRepoBySlug(ctx, org, slug, owner, repo, &github.TeamAddTeamRepoOptions{Permission: permission})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to set permission of team %s", slug), resp, err), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			return mcp.NewToolResultText(fmt.Sprintf("Team %s/%s has %s access to %s/%s", org, slug, permission, owner, repo)), nil
+		}
+}
@@ -0,0 +1,697 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCollaborators(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCollaborators(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_collaborators", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "affiliation")
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	alice := &github.User{
+		Login:       github.Ptr("alice"),
+		ID:          github.Ptr(int64(1)),
+		Permissions: map[string]bool{"admin": false, "maintain": false, "push": true, "triage": true, "pull": true},
+		RoleName:    github.Ptr("write"),
+		HTMLURL:     github.Ptr("https://github.com/alice"),
+	}
+	bob := &github.User{
+		Login:       github.Ptr("bob"),
+		ID:          github.Ptr(int64(2)),
+		Permissions: map[string]bool{"pull": true},
+		RoleName:    github.Ptr("read"),
+		HTMLURL:     github.Ptr("https://github.com/bob"),
+	}
+	carol := &github.User{
+		Login:       github.Ptr("carol"),
+		ID:          github.Ptr(int64(3)),
+		Permissions: map[string]bool{"admin": true, "maintain": true, "push": true, "triage": true, "pull": true},
+		RoleName:    github.Ptr("admin"),
+		HTMLURL:     github.Ptr("https://github.com/carol"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expected       []*collaborator
+	}{
+		{
+			name: "all collaborators with their affiliation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						switch r.URL.Query().Get("affiliation") {
+						case "all":
+							assert.Equal(t, "2", r.URL.Query().Get("page"))
+							mockResponse(t, http.StatusOK, []*github.User{alice, bob, carol})(w, r)
+						case "outside":
+							mockResponse(t, http.StatusOK, []*github.User{bob})(w, r)
+						default:
+							t.Errorf("unexpected affiliation %q", r.URL.Query().Get("affiliation"))
+						}
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"page":  float64(2),
+			},
+			expected: []*collaborator{
+				{Login: "alice", ID: 1, Permission: "push", RoleName: "write", Affiliation: "member", HTMLURL: "https://github.com/alice"},
+				{Login: "bob", ID: 2, Permission: "pull", RoleName: "read", Affiliation: "outside", HTMLURL: "https://github.com/bob"},
+				{Login: "carol", ID: 3, Permission: "admin", RoleName: "admin", Affiliation: "member", HTMLURL: "https://github.com/carol"},
+			},
+		},
+		{
+			name: "stops listing outside collaborators once every user is found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						switch r.URL.Query().Get("affiliation") {
+						case "all":
+							mockResponse(t, http.StatusOK, []*github.User{bob})(w, r)
+						case "outside":
+							assert.Empty(t, r.URL.Query().Get("page"), "listed outside collaborators past the one found")
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/collaborators?affiliation=outside&page=2>; rel="next"`)
+							mockResponse(t, http.StatusOK, []*github.User{bob})(w, r)
+						default:
+							t.Errorf("unexpected affiliation %q", r.URL.Query().Get("affiliation"))
+						}
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expected: []*collaborator{
+				{Login: "bob", ID: 2, Permission: "pull", RoleName: "read", Affiliation: "outside", HTMLURL: "https://github.com/bob"},
+			},
+		},
+		{
+			name: "outside collaborators with a permission",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"affiliation": "outside",
+						"permission":  "pull",
+						"page":        "1",
+						"per_page":    "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, []*github.User{bob}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"affiliation": "outside",
+				"permission":  "pull",
+			},
+			expected: []*collaborator{
+				{Login: "bob", ID: 2, Permission: "pull", RoleName: "read", Affiliation: "outside", HTMLURL: "https://github.com/bob"},
+			},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have push access to view repository collaborators."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list collaborators",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCollaborators(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned []*collaborator
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_ListRepositoryTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_teams", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       []*repositoryTeam
+	}{
+		{
+			name: "teams with access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposTeamsByOwnerByRepo,
+					[]*github.Team{
+						{
+							ID:         github.Ptr(int64(7)),
+							Slug:       github.Ptr("maintainers"),
+							Name:       github.Ptr("Maintainers"),
+							Permission: github.Ptr("maintain"),
+							Privacy:    github.Ptr("closed"),
+							Parent:     &github.Team{Slug: github.Ptr("engineering")},
+							HTMLURL:    github.Ptr("https://github.com/orgs/owner/teams/maintainers"),
+						},
+					},
+				),
+			),
+			expected: []*repositoryTeam{
+				{ID: 7, Slug: "maintainers", Name: "Maintainers", Permission: "maintain", Privacy: "closed", Parent: "engineering", HTMLURL: "https://github.com/orgs/owner/teams/maintainers"},
+			},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTeamsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list repository teams",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositoryTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned []*repositoryTeam
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, returned)
+		})
+	}
+}
+
+func Test_ListRepositoryInvitations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositoryInvitations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repository_invitations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposInvitationsByOwnerByRepo,
+			[]*github.RepositoryInvitation{
+				{
+					ID:          github.Ptr(int64(11)),
+					Invitee:     &github.User{Login: github.Ptr("dave")},
+					Inviter:     &github.User{Login: github.Ptr("carol")},
+					Permissions: github.Ptr("write"),
+					Expired:     github.Ptr(true),
+					HTMLURL:     github.Ptr("https://github.com/owner/repo/invitations"),
+				},
+			},
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := ListRepositoryInvitations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	var returned []*repositoryInvitation
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, []*repositoryInvitation{
+		{ID: 11, Invitee: "dave", Inviter: "carol", Permissions: "write", Expired: true, HTMLURL: "https://github.com/owner/repo/invitations"},
+	}, returned)
+}
+
+func Test_AddCollaborator(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_collaborator", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.Contains(t, tool.InputSchema.Properties, "allow_downgrade")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	permissionLevel := func(permissions map[string]bool) mock.MockBackendOption {
+		return mock.WithRequestMatch(
+			mock.GetReposCollaboratorsPermissionByOwnerByRepoByUsername,
+			&github.RepositoryPermissionLevel{User: &github.User{Permissions: permissions}},
+		)
+	}
+	admin := map[string]bool{"admin": true, "maintain": true, "push": true, "triage": true, "pull": true}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+		expected       *repositoryInvitation
+	}{
+		{
+			name: "invites a new collaborator",
+			mockedClient: mock.NewMockedHTTPClient(
+				permissionLevel(nil),
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{"permission": "triage"}).andThen(
+						mockResponse(t, http.StatusCreated, &github.CollaboratorInvitation{
+							ID:          github.Ptr(int64(12)),
+							Invitee:     &github.User{Login: github.Ptr("dave")},
+							Inviter:     &github.User{Login: github.Ptr("carol")},
+							Permissions: github.Ptr("triage"),
+							HTMLURL:     github.Ptr("https://github.com/owner/repo/invitations"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"username":   "dave",
+				"permission": "triage",
+			},
+			expected: &repositoryInvitation{ID: 12, Invitee: "dave", Inviter: "carol", Permissions: "triage", HTMLURL: "https://github.com/owner/repo/invitations"},
+		},
+		{
+			name: "raises the permission of a collaborator",
+			mockedClient: mock.NewMockedHTTPClient(
+				permissionLevel(map[string]bool{"triage": true, "pull": true}),
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{"permission": "push"}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "alice",
+			},
+			expectedText: "alice has push access to owner/repo",
+		},
+		{
+			name: "refuses to lower the permission of an admin",
+			mockedClient: mock.NewMockedHTTPClient(
+				permissionLevel(admin),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "alice",
+			},
+			expectError:    true,
+			expectedErrMsg: "alice has admin access to owner/repo already, which push may lower",
+		},
+		{
+			name: "refuses to replace the permission of an admin with a custom role",
+			mockedClient: mock.NewMockedHTTPClient(
+				permissionLevel(admin),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"username":   "alice",
+				"permission": "security-reviewer",
+			},
+			expectError:    true,
+			expectedErrMsg: "which security-reviewer may lower",
+		},
+		{
+			name: "lowers the permission of an admin when allowed",
+			mockedClient: mock.NewMockedHTTPClient(
+				permissionLevel(admin),
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					expectRequestBody(t, map[string]any{"permission": "pull"}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"username":        "alice",
+				"permission":      "pull",
+				"allow_downgrade": true,
+			},
+			expectedText: "alice has pull access to owner/repo",
+		},
+		{
+			name: "getting the permission fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCollaboratorsPermissionByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "ghost",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get permission of ghost",
+		},
+		{
+			name: "adding fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				permissionLevel(nil),
+				mock.WithRequestMatchHandler(
+					mock.PutReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": "ghost",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add collaborator ghost",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			if tc.expectedText != "" {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+				return
+			}
+			var returned repositoryInvitation
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, &returned)
+		})
+	}
+}
+
+func Test_RemoveCollaborator(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveCollaborator(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "remove_collaborator", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "username"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	// Mocked responses are consumed, each client needs its own
+	invitations := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(
+			mock.GetReposInvitationsByOwnerByRepo,
+			[]*github.RepositoryInvitation{
+				{ID: github.Ptr(int64(11)), Invitee: &github.User{Login: github.Ptr("Dave")}},
+				{ID: github.Ptr(int64(12)), Invitee: &github.User{Login: github.Ptr("erin")}},
+			},
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		username       string
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "removes a collaborator and cancels their invitations",
+			mockedClient: mock.NewMockedHTTPClient(
+				invitations(),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposInvitationsByOwnerByRepoByInvitationId,
+					expectPath(t, "/repos/owner/repo/invitations/11").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposCollaboratorsByOwnerByRepoByUsername,
+					expectPath(t, "/repos/owner/repo/collaborators/dave").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			username:     "dave",
+			expectedText: "dave has been removed from the collaborators of owner/repo, and 1 pending invitations have been cancelled",
+		},
+		{
+			name: "removes a collaborator without invitations",
+			mockedClient: mock.NewMockedHTTPClient(
+				invitations(),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+			username:     "alice",
+			expectedText: "alice has been removed from the collaborators of owner/repo",
+		},
+		{
+			name: "removing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				invitations(),
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposCollaboratorsByOwnerByRepoByUsername,
+					mockResponse(t, http.StatusForbidden, `{"message": "Forbidden"}`),
+				),
+			),
+			username:       "alice",
+			expectError:    true,
+			expectedErrMsg: "failed to remove collaborator alice",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveCollaborator(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"username": tc.username,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_SetTeamRepositoryPermission(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetTeamRepositoryPermission(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_team_permission", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "team_slug", "permission"})
+	assert.True(t, *tool.Annotations.DestructiveHint)
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "sets the permission of a team of the owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expect(t, expectations{
+						path:        "/orgs/owner/teams/docs/repos/owner/repo",
+						requestBody: map[string]any{"permission": "maintain"},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"team_slug":  "docs",
+				"permission": "maintain",
+			},
+			expectedText: "Team owner/docs has maintain access to owner/repo",
+		},
+		{
+			name: "removes the access of a team of another organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectPath(t, "/orgs/other/teams/docs/repos/owner/repo").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"team_slug":  "docs",
+				"permission": "none",
+				"org":        "other",
+			},
+			expectedText: "Team other/docs no longer has access to owner/repo",
+		},
+		{
+			name: "setting fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"team_slug":  "ghosts",
+				"permission": "pull",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to set permission of team ghosts",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetTeamRepositoryPermission(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
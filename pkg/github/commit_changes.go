@@ -0,0 +1,484 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// fileModes are the modes files can be given by commit_changes
+var fileModes = []string{"100644", "100755", "120000"}
+
+// changeOperation is an operation of commit_changes
+type changeOperation struct {
+	Operation string  `mapstructure:"operation"`
+	Path      string  `mapstructure:"path"`
+	From      string  `mapstructure:"from"`
+	Content   *string `mapstructure:"content"`
+	Mode      string  `mapstructure:"mode"`
+}
+
+// commitChangesResult is the result of commit_changes
+type commitChangesResult struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Branch  string `json:"branch"`
+	Parent  string `json:"parent"`
+	// Previous is the commit the branch pointed to before, when it was not the parent of the new commit
+	Previous string `json:"previous,omitempty"`
+	Forced   bool   `json:"forced,omitempty"`
+}
+
+// CommitChanges creates a tool to create, update, delete, rename and change the mode of files in a single commit.
+func CommitChanges(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("commit_changes",
+			mcp.WithDescription(t("TOOL_COMMIT_CHANGES_DESCRIPTION", "Commit a set of file operations to a branch of a GitHub repository in a single atomic commit: create, update, delete and rename files, and change their mode, for instance to make them executable. Pass expected_parent_sha to fail instead of committing if the branch has moved, and force_with_lease to replace the history of the branch")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_COMMIT_CHANGES_USER_TITLE", "Commit changes to repository"),
+				ReadOnlyHint:    ToBoolPtr(false),
+				DestructiveHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to commit to"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			mcp.WithArray("operations",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"operation", "path"},
+						"properties": map[string]interface{}{
+							"operation": map[string]interface{}{
+								"type":        "string",
+								"enum":        []string{"create", "update", "delete", "rename", "chmod"},
+								"description": "create a new file, update an existing file, delete a file, rename a file from another path or change the mode of a file",
+							},
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "path of the file, or its new path when renaming it",
+							},
+							"from": map[string]interface{}{
+								"type":        "string",
+								"description": "path of the file to rename",
+							},
+							"content": map[string]interface{}{
+								"type":        "string",
+								"description": "content of the file, required to create or update it, and optional when renaming it",
+							},
+							"mode": map[string]interface{}{
+								"type":        "string",
+								"enum":        fileModes,
+								"description": "mode of the file: 100644 for a regular file, 100755 for an executable and 120000 for a symlink whose content is its target. Required to change the mode of a file, and defaults to the current mode of the file otherwise",
+							},
+						},
+					}),
+				mcp.Description("Operations to commit, each applying to a different file"),
+			),
+			mcp.WithString("expected_parent_sha",
+				mcp.Description("SHA of the commit to create the new commit on top of. Without force_with_lease, the commit fails if the branch no longer points to it. Defaults to the commit the branch points to"),
+			),
+			mcp.WithString("force_with_lease",
+				mcp.Description("SHA of the commit the branch must point to for it to be overwritten with the new commit, even if the new commit does not descend from it. Use it with expected_parent_sha to rewrite the history of the branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := RequiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := RequiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedParent, err := OptionalParam[string](request, "expected_parent_sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			lease, err := OptionalParam[string](request, "force_with_lease")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var operations []changeOperation
+			if err := mapstructure.Decode(request.GetArguments()["operations"], &operations); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("operations must be an array of objects: %v", err)), nil
+			}
+			if len(operations) == 0 {
+				return mcp.NewToolResultError("missing required parameter: operations"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			c := fileCommit{owner: owner, repo: repo, branch: branch, message: message, expectedParent: expectedParent, lease: lease}
+			return commitOperations(ctx, client, c, func(context.Context, *treeLookup, string) ([]changeOperation, error) {
+				return operations, nil
+			})
+		}
+}
+
+// fileCommit describes a commit of file operations to a branch
+type fileCommit struct {
+	owner, repo, branch, message string
+	// expectedParent is the commit to create the new commit on top of, defaulting to the head of the branch
+	expectedParent string
+	// lease is the commit the branch must point to for it to be overwritten, if set
+	lease string
+}
+
+// commitOperations commits the operations returned by operations, given a lookup of the tree of the parent commit,
+// to a branch, and returns the result of the commit as a tool result
+func commitOperations(ctx context.Context, client *github.Client, c fileCommit, operations func(ctx context.Context, lookup *treeLookup, rootSHA string) ([]changeOperation, error)) (*mcp.CallToolResult, error) {
+	// Get the reference for the branch
+	ref, resp, err := client.Git.GetRef(ctx, c.owner, c.repo, "refs/heads/"+c.branch)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get branch reference",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	head := ref.GetObject().GetSHA()
+	if c.lease != "" && head != c.lease {
+		return mcp.NewToolResultError(fmt.Sprintf("branch %s points to %s instead of %s given as force_with_lease, fetch its changes and try again", c.branch, head, c.lease)), nil
+	}
+	if c.lease == "" && c.expectedParent != "" && head != c.expectedParent {
+		return mcp.NewToolResultError(fmt.Sprintf("branch %s points to %s instead of %s given as expected_parent_sha, fetch its changes and try again", c.branch, head, c.expectedParent)), nil
+	}
+	parent := cmp.Or(c.expectedParent, head)
+
+	// Get the commit to create the new commit on top of
+	baseCommit, resp, err := client.Git.GetCommit(ctx, c.owner, c.repo, parent)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to get base commit",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	lookup := &treeLookup{client: client, owner: c.owner, repo: c.repo, trees: make(map[string]*github.Tree)}
+	ops, err := operations(ctx, lookup, baseCommit.GetTree().GetSHA())
+	var entries []*github.TreeEntry
+	if err == nil {
+		entries, err = changeTreeEntries(ctx, lookup, baseCommit.GetTree().GetSHA(), ops)
+	}
+	if err != nil {
+		if lookup.resp != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				lookup.failure,
+				lookup.resp,
+				err,
+			), nil
+		}
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Create a new tree with the changes
+	newTree, resp, err := client.Git.CreateTree(ctx, c.owner, c.repo, baseCommit.GetTree().GetSHA(), entries)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create tree",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Create a new commit
+	commit := &github.Commit{
+		Message: github.Ptr(c.message),
+		Tree:    newTree,
+		Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+	}
+	newCommit, resp, err := client.Git.CreateCommit(ctx, c.owner, c.repo, commit, nil)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to create commit",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Update the reference to point to the new commit. Without a lease the update must be a fast forward,
+	// which fails if the branch moved since it was read. The API cannot compare and swap references, so a
+	// forced update can still overwrite a commit pushed after the lease was checked.
+	force := c.lease != ""
+	ref.Object.SHA = newCommit.SHA
+	_, resp, err = client.Git.UpdateRef(ctx, c.owner, c.repo, ref, force)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			"failed to update reference",
+			resp,
+			err,
+		), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	result := commitChangesResult{
+		SHA:     newCommit.GetSHA(),
+		HTMLURL: newCommit.GetHTMLURL(),
+		Branch:  c.branch,
+		Parent:  parent,
+		Forced:  force,
+	}
+	if head != parent {
+		result.Previous = head
+	}
+
+	r, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// changeTreeEntries validates operations against the tree rootSHA and returns the tree entries applying them
+func changeTreeEntries(ctx context.Context, lookup *treeLookup, rootSHA string, operations []changeOperation) ([]*github.TreeEntry, error) {
+	var entries []*github.TreeEntry
+	touched := make(map[string]bool)
+	touch := func(path string) error {
+		if path == "" {
+			return fmt.Errorf("each operation must have a path")
+		}
+		if touched[path] {
+			return fmt.Errorf("%s is changed by more than one operation", path)
+		}
+		touched[path] = true
+		return nil
+	}
+	// file returns the blob at path, or an error if it does not exist
+	file := func(path string) (*github.TreeEntry, error) {
+		entry, err := lookup.entry(ctx, rootSHA, path)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil || entry.GetType() != "blob" {
+			return nil, fmt.Errorf("file %s does not exist", path)
+		}
+		return entry, nil
+	}
+	// absent returns an error if something exists at path
+	absent := func(path string) error {
+		entry, err := lookup.entry(ctx, rootSHA, path)
+		if err != nil {
+			return err
+		}
+		if entry != nil {
+			return fmt.Errorf("%s already exists", path)
+		}
+		return nil
+	}
+	// blob returns a tree entry for a file with the given content, or the given blob SHA if content is nil
+	blob := func(path, mode string, content *string, sha string) (*github.TreeEntry, error) {
+		entry := &github.TreeEntry{Path: github.Ptr(path), Mode: github.Ptr(mode), Type: github.Ptr("blob")}
+		switch {
+		case content == nil:
+			entry.SHA = github.Ptr(sha)
+		case *content == "":
+			// The trees API ignores empty content, so empty files are created from the SHA of an empty blob
+			sha, err := lookup.emptyBlob(ctx)
+			if err != nil {
+				return nil, err
+			}
+			entry.SHA = github.Ptr(sha)
+		default:
+			entry.Content = content
+		}
+		return entry, nil
+	}
+
+	for _, op := range operations {
+		path := strings.Trim(op.Path, "/")
+		if err := touch(path); err != nil {
+			return nil, err
+		}
+		if op.Mode != "" && !slices.Contains(fileModes, op.Mode) {
+			return nil, fmt.Errorf("mode of %s must be one of %s", path, strings.Join(fileModes, ", "))
+		}
+		if op.Content == nil && (op.Operation == "create" || op.Operation == "update") {
+			return nil, fmt.Errorf("content is required to %s %s", op.Operation, path)
+		}
+
+		switch op.Operation {
+		case "create":
+			if err := absent(path); err != nil {
+				return nil, fmt.Errorf("cannot create %s: %w", path, err)
+			}
+			entry, err := blob(path, cmp.Or(op.Mode, "100644"), op.Content, "")
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case "update":
+			existing, err := file(path)
+			if err != nil {
+				return nil, fmt.Errorf("cannot update %s: %w", path, err)
+			}
+			entry, err := blob(path, cmp.Or(op.Mode, existing.GetMode()), op.Content, "")
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		case "delete":
+			existing, err := file(path)
+			if err != nil {
+				return nil, fmt.Errorf("cannot delete %s: %w", path, err)
+			}
+			// A nil SHA deletes the file
+			entries = append(entries, &github.TreeEntry{Path: github.Ptr(path), Mode: existing.Mode, Type: github.Ptr("blob")})
+		case "rename":
+			from := strings.Trim(op.From, "/")
+			if from == "" {
+				return nil, fmt.Errorf("from is required to rename %s", path)
+			}
+			if err := touch(from); err != nil {
+				return nil, err
+			}
+			existing, err := file(from)
+			if err != nil {
+				return nil, fmt.Errorf("cannot rename %s: %w", from, err)
+			}
+			if err := absent(path); err != nil {
+				return nil, fmt.Errorf("cannot rename %s to %s: %w", from, path, err)
+			}
+			entry, err := blob(path, cmp.Or(op.Mode, existing.GetMode()), op.Content, existing.GetSHA())
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, &github.TreeEntry{Path: github.Ptr(from), Mode: existing.Mode, Type: github.Ptr("blob")}, entry)
+		case "chmod":
+			if op.Mode == "" {
+				return nil, fmt.Errorf("mode is required to change the mode of %s", path)
+			}
+			existing, err := file(path)
+			if err != nil {
+				return nil, fmt.Errorf("cannot change the mode of %s: %w", path, err)
+			}
+			entry, _ := blob(path, op.Mode, nil, existing.GetSHA())
+			entries = append(entries, entry)
+		default:
+			return nil, fmt.Errorf("unknown operation %q on %s, use one of: create, update, delete, rename, chmod", op.Operation, path)
+		}
+	}
+	return entries, nil
+}
+
+// treeLookup finds the entries of trees by path, fetching each tree once
+type treeLookup struct {
+	client      *github.Client
+	owner, repo string
+	trees       map[string]*github.Tree
+	// emptyBlobSHA is the SHA of the blob of an empty file, once created
+	emptyBlobSHA string
+	// resp is the response of the last failed request, and failure describes that request
+	resp    *github.Response
+	failure string
+}
+
+// entry returns the entry at path in the tree rootSHA, or nil if there is none
+func (l *treeLookup) entry(ctx context.Context, rootSHA, path string) (*github.TreeEntry, error) {
+	sha := rootSHA
+	names := strings.Split(path, "/")
+	for i, name := range names {
+		tree, ok := l.trees[sha]
+		if !ok {
+			var resp *github.Response
+			var err error
+			tree, resp, err = l.client.Git.GetTree(ctx, l.owner, l.repo, sha, false)
+			if err != nil {
+				l.resp, l.failure = resp, "failed to get tree"
+				return nil, err
+			}
+			_ = resp.Body.Close()
+			l.trees[sha] = tree
+		}
+
+		var found *github.TreeEntry
+		for _, entry := range tree.Entries {
+			if entry.GetPath() == name {
+				found = entry
+				break
+			}
+		}
+		if found == nil || i == len(names)-1 {
+			return found, nil
+		}
+		if found.GetType() != "tree" {
+			return nil, nil
+		}
+		sha = found.GetSHA()
+	}
+	return nil, nil
+}
+
+// content returns the content of the file at path in the tree rootSHA
+func (l *treeLookup) content(ctx context.Context, rootSHA, path string) (string, error) {
+	entry, err := l.entry(ctx, rootSHA, path)
+	if err != nil {
+		return "", err
+	}
+	if entry == nil || entry.GetType() != "blob" {
+		return "", fmt.Errorf("file %s does not exist", path)
+	}
+	b, resp, err := l.client.Git.GetBlobRaw(ctx, l.owner, l.repo, entry.GetSHA())
+	if err != nil {
+		l.resp, l.failure = resp, fmt.Sprintf("failed to get content of %s", path)
+		return "", err
+	}
+	_ = resp.Body.Close()
+	return string(b), nil
+}
+
+// emptyBlob returns the SHA of the blob of an empty file, creating it once as the repository may not have it yet
+func (l *treeLookup) emptyBlob(ctx context.Context) (string, error) {
+	if l.emptyBlobSHA != "" {
+		return l.emptyBlobSHA, nil
+	}
+	blob, resp, err := l.client.Git.CreateBlob(ctx, l.owner, l.repo, &github.Blob{Content: github.Ptr(""), Encoding: github.Ptr("utf-8")})
+	if err != nil {
+		l.resp, l.failure = resp, "failed to create empty blob"
+		return "", err
+	}
+	_ = resp.Body.Close()
+	l.emptyBlobSHA = blob.GetSHA()
+	return l.emptyBlobSHA, nil
+}
@@ -0,0 +1,372 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CommitChanges(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CommitChanges(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "commit_changes", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "message")
+	assert.Contains(t, tool.InputSchema.Properties, "operations")
+	assert.Contains(t, tool.InputSchema.Properties, "expected_parent_sha")
+	assert.Contains(t, tool.InputSchema.Properties, "force_with_lease")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "branch", "message", "operations"})
+
+	mockRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/main"),
+		Object: &github.GitObject{SHA: github.Ptr("head123")},
+	}
+	file := func(name, mode string) *github.TreeEntry {
+		return &github.TreeEntry{Path: github.Ptr(name), Mode: github.Ptr(mode), Type: github.Ptr("blob"), SHA: github.Ptr("sha-" + name)}
+	}
+	trees := map[string]*github.Tree{
+		"root": {SHA: github.Ptr("root"), Entries: []*github.TreeEntry{
+			file("README.md", "100644"),
+			file("old.txt", "100644"),
+			{Path: github.Ptr("scripts"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("scripts")},
+		}},
+		"scripts": {SHA: github.Ptr("scripts"), Entries: []*github.TreeEntry{
+			file("build.sh", "100755"),
+			file("deploy.sh", "100644"),
+		}},
+	}
+	getTreeHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tree, ok := trees[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(tree)
+	})
+	getCommitHandler := func(sha string) http.HandlerFunc {
+		return expectPath(t, "/repos/owner/repo/git/commits/"+sha).andThen(
+			mockResponse(t, http.StatusOK, &github.Commit{SHA: github.Ptr(sha), Tree: &github.Tree{SHA: github.Ptr("root")}}),
+		)
+	}
+	mockNewCommit := &github.Commit{
+		SHA:     github.Ptr("new456"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/new456"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult commitChangesResult
+		expectedErrMsg string
+	}{
+		{
+			name: "commits every kind of operation at once",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatchHandler(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, getCommitHandler("head123")),
+				mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, getTreeHandler),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"content":  "",
+						"encoding": "utf-8",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("empty-blob")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "root",
+						"tree": []interface{}{
+							map[string]interface{}{"path": "docs/guide.md", "mode": "100644", "type": "blob", "content": "# Guide"},
+							map[string]interface{}{"path": "docs/.keep", "mode": "100644", "type": "blob", "sha": "empty-blob"},
+							map[string]interface{}{"path": "README.md", "mode": "100644", "type": "blob", "content": "# Updated"},
+							map[string]interface{}{"path": "old.txt", "mode": "100644", "type": "blob", "sha": nil},
+							map[string]interface{}{"path": "scripts/build.sh", "mode": "100755", "type": "blob", "sha": nil},
+							map[string]interface{}{"path": "bin/build.sh", "mode": "100755", "type": "blob", "sha": "sha-build.sh"},
+							map[string]interface{}{"path": "scripts/deploy.sh", "mode": "100755", "type": "blob", "sha": "sha-deploy.sh"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr("newtree")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Reorganize scripts",
+						"tree":    "newtree",
+						"parents": []interface{}{"head123"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockNewCommit),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{
+						"sha":   "new456",
+						"force": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Reorganize scripts",
+				"operations": []interface{}{
+					map[string]interface{}{"operation": "create", "path": "docs/guide.md", "content": "# Guide"},
+					map[string]interface{}{"operation": "create", "path": "docs/.keep", "content": ""},
+					map[string]interface{}{"operation": "update", "path": "README.md", "content": "# Updated"},
+					map[string]interface{}{"operation": "delete", "path": "old.txt"},
+					map[string]interface{}{"operation": "rename", "from": "scripts/build.sh", "path": "bin/build.sh"},
+					map[string]interface{}{"operation": "chmod", "path": "scripts/deploy.sh", "mode": "100755"},
+				},
+				"expected_parent_sha": "head123",
+			},
+			expectedResult: commitChangesResult{
+				SHA:     "new456",
+				HTMLURL: "https://github.com/owner/repo/commit/new456",
+				Branch:  "main",
+				Parent:  "head123",
+			},
+		},
+		{
+			name: "force with lease rewrites the branch on top of an older commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatchHandler(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, getCommitHandler("old789")),
+				mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, getTreeHandler),
+				mock.WithRequestMatch(mock.PostReposGitTreesByOwnerByRepo, &github.Tree{SHA: github.Ptr("newtree")}),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Redo",
+						"tree":    "newtree",
+						"parents": []interface{}{"old789"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockNewCommit),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{
+						"sha":   "new456",
+						"force": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Redo",
+				"operations": []interface{}{
+					map[string]interface{}{"operation": "update", "path": "README.md", "content": "# Redone"},
+				},
+				"expected_parent_sha": "old789",
+				"force_with_lease":    "head123",
+			},
+			expectedResult: commitChangesResult{
+				SHA:      "new456",
+				HTMLURL:  "https://github.com/owner/repo/commit/new456",
+				Branch:   "main",
+				Parent:   "old789",
+				Previous: "head123",
+				Forced:   true,
+			},
+		},
+		{
+			name:         "branch moved since the expected parent",
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef)),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"operations": []interface{}{
+					map[string]interface{}{"operation": "update", "path": "README.md", "content": "# Updated"},
+				},
+				"expected_parent_sha": "old789",
+			},
+			expectError:    true,
+			expectedErrMsg: "branch main points to head123 instead of old789 given as expected_parent_sha",
+		},
+		{
+			name:         "branch moved since the lease",
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef)),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"operations": []interface{}{
+					map[string]interface{}{"operation": "update", "path": "README.md", "content": "# Updated"},
+				},
+				"force_with_lease": "old789",
+			},
+			expectError:    true,
+			expectedErrMsg: "branch main points to head123 instead of old789 given as force_with_lease",
+		},
+		{
+			name: "update of a missing file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatchHandler(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, getCommitHandler("head123")),
+				mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, getTreeHandler),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"operations": []interface{}{
+					map[string]interface{}{"operation": "update", "path": "scripts/missing.sh", "content": "echo"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "cannot update scripts/missing.sh: file scripts/missing.sh does not exist",
+		},
+		{
+			name: "rename onto an existing path",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatchHandler(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, getCommitHandler("head123")),
+				mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, getTreeHandler),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Rename",
+				"operations": []interface{}{
+					map[string]interface{}{"operation": "rename", "from": "old.txt", "path": "scripts"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "cannot rename old.txt to scripts: scripts already exists",
+		},
+		{
+			name: "path changed by more than one operation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatchHandler(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, getCommitHandler("head123")),
+				mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, getTreeHandler),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"operations": []interface{}{
+					map[string]interface{}{"operation": "delete", "path": "old.txt"},
+					map[string]interface{}{"operation": "rename", "from": "old.txt", "path": "new.txt"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "old.txt is changed by more than one operation",
+		},
+		{
+			name: "creating the empty blob fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatchHandler(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, getCommitHandler("head123")),
+				mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, getTreeHandler),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Add placeholder",
+				"operations": []interface{}{
+					map[string]interface{}{"operation": "create", "path": "docs/.keep", "content": ""},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create empty blob",
+		},
+		{
+			name: "chmod without a mode",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, mockRef),
+				mock.WithRequestMatchHandler(mock.GetReposGitCommitsByOwnerByRepoByCommitSha, getCommitHandler("head123")),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"message": "Update",
+				"operations": []interface{}{
+					map[string]interface{}{"operation": "chmod", "path": "README.md"},
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "mode is required to change the mode of README.md",
+		},
+		{
+			name:         "no operations",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"branch":     "main",
+				"message":    "Update",
+				"operations": []interface{}{},
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: operations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CommitChanges(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned commitChangesResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
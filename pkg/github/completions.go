@@ -0,0 +1,402 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// DefaultCompletionCacheTTL is how long the values fetched to complete an argument are reused by default.
+const DefaultCompletionCacheTTL = 5 * time.Minute
+
+// maxCompletionValues is the number of values a completion/complete response may hold
+const maxCompletionValues = 100
+
+// maxCompletionCacheEntries is the number of lists of values cached by default, beyond which the lists
+// expiring first are evicted
+const maxCompletionCacheEntries = 1000
+
+// invalidParamsError is an error caused by the parameters of a request the MCP server does not handle
+// itself, as opposed to a failure to answer it
+type invalidParamsError struct {
+	message string
+}
+
+func (e *invalidParamsError) Error() string {
+	return e.message
+}
+
+// jsonRPCError returns the error response of a request, with the invalid params code for errors caused by
+// the parameters of the request and the internal error code for others, such as failed API calls
+func jsonRPCError(id mcp.RequestId, err error) mcp.JSONRPCError {
+	var invalidParams *invalidParamsError
+	if errors.As(err, &invalidParams) {
+		return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, err.Error(), nil)
+	}
+	return mcp.NewJSONRPCError(id, mcp.INTERNAL_ERROR, err.Error(), nil)
+}
+
+// completionEntry is a cached list of completion values
+type completionEntry struct {
+	values  []string
+	expires time.Time
+}
+
+// Completions completes the arguments of the repo:// resource templates and of the prompts from the GitHub API:
+// owners and repositories from the repositories of the authenticated user, branches, tags and the numbers of
+// open pull requests. The values fetched are cached for a while, so completing as the user types only costs a
+// request for the first character, and the entries expiring first are evicted once the cache is full.
+// Completions is safe for concurrent use.
+type Completions struct {
+	getClient  GetClientFn
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]completionEntry
+}
+
+// NewCompletions creates completions caching the values fetched for ttl.
+func NewCompletions(getClient GetClientFn, ttl time.Duration) *Completions {
+	return &Completions{
+		getClient:  getClient,
+		ttl:        ttl,
+		maxEntries: maxCompletionCacheEntries,
+		now:        time.Now,
+		cache:      map[string]completionEntry{},
+	}
+}
+
+// completeParams are the parameters of a completion/complete request. The context holding the values of
+// the arguments resolved already is not part of mcp.CompleteParams yet.
+type completeParams struct {
+	Ref struct {
+		Type string `json:"type"`
+		URI  string `json:"uri"`
+		Name string `json:"name"`
+	} `json:"ref"`
+	Argument struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"argument"`
+	Context struct {
+		Arguments map[string]string `json:"arguments"`
+	} `json:"context"`
+}
+
+// HandleMessage answers completion/complete requests, which the MCP server does not handle itself. It returns
+// false for any other message, which is left to the server.
+func (c *Completions) HandleMessage(ctx context.Context, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+	var request struct {
+		ID     any             `json:"id"`
+		Method mcp.MCPMethod   `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil || request.Method != "completion/complete" {
+		return nil, false
+	}
+	id := mcp.NewRequestId(request.ID)
+
+	var params completeParams
+	if err := json.Unmarshal(request.Params, &params); err != nil {
+		return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, fmt.Sprintf("invalid params: %s", err), nil), true
+	}
+	values, err := c.values(ctx, params)
+	if err != nil {
+		return jsonRPCError(id, err), true
+	}
+
+	return mcp.JSONRPCResponse{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      id,
+		Result:  filterCompletions(values, params.Argument.Value),
+	}, true
+}
+
+// values returns every value of the argument of a completion request, before filtering
+func (c *Completions) values(ctx context.Context, params completeParams) ([]string, error) {
+	arguments := params.Context.Arguments
+	switch params.Ref.Type {
+	case "ref/resource":
+		if !strings.HasPrefix(params.Ref.URI, "repo://") {
+			return nil, nil
+		}
+		return c.argumentValues(ctx, params.Argument.Name, arguments)
+	case "ref/prompt":
+		// The repo argument of AssignCodingAgent is a full owner/repo name
+		if params.Ref.Name == "AssignCodingAgent" && params.Argument.Name == "repo" {
+			return c.userRepositories(ctx)
+		}
+		return c.argumentValues(ctx, params.Argument.Name, arguments)
+	default:
+		return nil, &invalidParamsError{message: fmt.Sprintf("unsupported reference type %q", params.Ref.Type)}
+	}
+}
+
+// argumentValues returns the values of a resource template variable or prompt argument by its name
+func (c *Completions) argumentValues(ctx context.Context, name string, arguments map[string]string) ([]string, error) {
+	owner, repo := arguments["owner"], arguments["repo"]
+	switch name {
+	case "owner":
+		return c.owners(ctx)
+	case "repo":
+		return c.repositoryNames(ctx, owner)
+	case "branch":
+		return c.branches(ctx, owner, repo)
+	case "tag", "from_tag", "to_tag":
+		return c.tags(ctx, owner, repo)
+	case "prNumber", "pullNumber":
+		return c.pullRequestNumbers(ctx, owner, repo)
+	}
+	return nil, nil
+}
+
+// filterCompletions returns the values starting with prefix, ignoring case, capped to the size of a response
+func filterCompletions(values []string, prefix string) *mcp.CompleteResult {
+	result := &mcp.CompleteResult{}
+	result.Completion.Values = []string{}
+	prefix = strings.ToLower(prefix)
+	for _, value := range values {
+		if !strings.HasPrefix(strings.ToLower(value), prefix) {
+			continue
+		}
+		result.Completion.Total++
+		if len(result.Completion.Values) < maxCompletionValues {
+			result.Completion.Values = append(result.Completion.Values, value)
+		}
+	}
+	result.Completion.HasMore = result.Completion.Total > len(result.Completion.Values)
+	return result
+}
+
+// cached returns the values cached under key, or fetches and caches them
+func (c *Completions) cached(key string, fetch func() ([]string, error)) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.values, nil
+	}
+
+	values, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evict()
+	c.cache[key] = completionEntry{values: values, expires: c.now().Add(c.ttl)}
+	return values, nil
+}
+
+// evict removes the expired entries, and the entries expiring first until there is room for another one,
+// c.mu must be held
+func (c *Completions) evict() {
+	now := c.now()
+	for key, entry := range c.cache {
+		if !now.Before(entry.expires) {
+			delete(c.cache, key)
+		}
+	}
+	for len(c.cache) >= c.maxEntries {
+		var first string
+		for key, entry := range c.cache {
+			if first == "" || entry.expires.Before(c.cache[first].expires) {
+				first = key
+			}
+		}
+		delete(c.cache, first)
+	}
+}
+
+// userRepositories returns the full names of the repositories of the authenticated user, most recently pushed first
+func (c *Completions) userRepositories(ctx context.Context) ([]string, error) {
+	return c.cached("repos", func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		var names []string
+		opts := &github.RepositoryListByAuthenticatedUserOptions{Sort: "pushed", ListOptions: github.ListOptions{PerPage: 100}}
+		for page := 0; page < maxResourcePages; page++ {
+			repos, resp, err := client.Repositories.ListByAuthenticatedUser(ctx, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repositories: %w", err)
+			}
+			for _, repo := range repos {
+				names = append(names, repo.GetFullName())
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+		return names, nil
+	})
+}
+
+// owners completes owner arguments with the owners of the repositories of the authenticated user, sorted by name
+func (c *Completions) owners(ctx context.Context) ([]string, error) {
+	fullNames, err := c.userRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var owners []string
+	for _, fullName := range fullNames {
+		owner, _, _ := strings.Cut(fullName, "/")
+		if !seen[owner] {
+			seen[owner] = true
+			owners = append(owners, owner)
+		}
+	}
+	sort.Strings(owners)
+	return owners, nil
+}
+
+// repositoryNames completes repo arguments with the repositories of the authenticated user owned by owner, or
+// with all of them if the owner is not known yet. Repositories of other owners are listed through the API.
+func (c *Completions) repositoryNames(ctx context.Context, owner string) ([]string, error) {
+	fullNames, err := c.userRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var names []string
+	for _, fullName := range fullNames {
+		repoOwner, name, _ := strings.Cut(fullName, "/")
+		if (owner == "" || strings.EqualFold(repoOwner, owner)) && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 || owner == "" {
+		return names, nil
+	}
+
+	return c.cached("repos/"+strings.ToLower(owner), func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		var names []string
+		opts := &github.RepositoryListByUserOptions{Sort: "pushed", ListOptions: github.ListOptions{PerPage: 100}}
+		for page := 0; page < maxResourcePages; page++ {
+			repos, resp, err := client.Repositories.ListByUser(ctx, owner, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repositories of %s: %w", owner, err)
+			}
+			for _, repo := range repos {
+				names = append(names, repo.GetName())
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+		return names, nil
+	})
+}
+
+// branches completes branch arguments, once the repository is known
+func (c *Completions) branches(ctx context.Context, owner, repo string) ([]string, error) {
+	if owner == "" || repo == "" {
+		return nil, nil
+	}
+	return c.cached(repositoryCacheKey("branches", owner, repo), func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		var names []string
+		opts := &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		for page := 0; page < maxResourcePages; page++ {
+			branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list branches: %w", err)
+			}
+			for _, branch := range branches {
+				names = append(names, branch.GetName())
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+		return names, nil
+	})
+}
+
+// tags completes tag arguments, once the repository is known
+func (c *Completions) tags(ctx context.Context, owner, repo string) ([]string, error) {
+	if owner == "" || repo == "" {
+		return nil, nil
+	}
+	return c.cached(repositoryCacheKey("tags", owner, repo), func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		var names []string
+		opts := &github.ListOptions{PerPage: 100}
+		for page := 0; page < maxResourcePages; page++ {
+			tags, resp, err := client.Repositories.ListTags(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list tags: %w", err)
+			}
+			for _, tag := range tags {
+				names = append(names, tag.GetName())
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+		return names, nil
+	})
+}
+
+// pullRequestNumbers completes prNumber arguments with the numbers of open pull requests, newest first,
+// once the repository is known
+func (c *Completions) pullRequestNumbers(ctx context.Context, owner, repo string) ([]string, error) {
+	if owner == "" || repo == "" {
+		return nil, nil
+	}
+	return c.cached(repositoryCacheKey("pulls", owner, repo), func() ([]string, error) {
+		client, err := c.getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		var numbers []string
+		opts := &github.PullRequestListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+		for page := 0; page < maxResourcePages; page++ {
+			pulls, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pull requests: %w", err)
+			}
+			for _, pull := range pulls {
+				numbers = append(numbers, strconv.Itoa(pull.GetNumber()))
+			}
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+		return numbers, nil
+	})
+}
+
+// repositoryCacheKey returns the cache key of a kind of values of a repository, whose names are case insensitive
+func repositoryCacheKey(kind, owner, repo string) string {
+	return kind + "/" + strings.ToLower(owner) + "/" + strings.ToLower(repo)
+}
@@ -0,0 +1,266 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// completeRequest returns a completion/complete request for an argument of a reference
+func completeRequest(t *testing.T, ref map[string]string, name, value string, arguments map[string]string) json.RawMessage {
+	params := map[string]any{
+		"ref":      ref,
+		"argument": map[string]string{"name": name, "value": value},
+	}
+	if arguments != nil {
+		params["context"] = map[string]any{"arguments": arguments}
+	}
+	message, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "completion/complete", "params": params})
+	require.NoError(t, err)
+	return message
+}
+
+// completeResult returns the result of a completion/complete response
+func completeResult(t *testing.T, response mcp.JSONRPCMessage) *mcp.CompleteResult {
+	resp, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a response, got %#v", response)
+	result, ok := resp.Result.(*mcp.CompleteResult)
+	require.True(t, ok)
+	return result
+}
+
+func Test_Completions_HandleMessage(t *testing.T) {
+	branchTemplate := map[string]string{"type": "ref/resource", "uri": "repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}"}
+	repoTemplate := map[string]string{"type": "ref/resource", "uri": "repo://{owner}/{repo}/contents{/path*}"}
+	mockRepos := []*github.Repository{
+		{FullName: github.Ptr("octocat/hello-world")},
+		{FullName: github.Ptr("github/github-mcp-server")},
+		{FullName: github.Ptr("octocat/Spoon-Knife")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		request        json.RawMessage
+		expectedValues []string
+		expectedErrMsg string
+		expectedCode   int
+	}{
+		{
+			name:           "owners of the repositories of the user",
+			mockedClient:   mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetUserRepos, mockRepos)),
+			request:        completeRequest(t, repoTemplate, "owner", "", nil),
+			expectedValues: []string{"github", "octocat"},
+		},
+		{
+			name:           "repositories of an owner, ignoring case",
+			mockedClient:   mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetUserRepos, mockRepos)),
+			request:        completeRequest(t, repoTemplate, "repo", "s", map[string]string{"owner": "OctoCat"}),
+			expectedValues: []string{"Spoon-Knife"},
+		},
+		{
+			name: "repositories of another owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUserRepos, mockRepos),
+				mock.WithRequestMatch(mock.GetUsersReposByUsername, []*github.Repository{{Name: github.Ptr("linux")}}),
+			),
+			request:        completeRequest(t, repoTemplate, "repo", "", map[string]string{"owner": "torvalds"}),
+			expectedValues: []string{"linux"},
+		},
+		{
+			name: "branches",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepo, []*github.Branch{
+					{Name: github.Ptr("main")}, {Name: github.Ptr("feature")}, {Name: github.Ptr("maint")},
+				}),
+			),
+			request:        completeRequest(t, branchTemplate, "branch", "ma", map[string]string{"owner": "owner", "repo": "repo"}),
+			expectedValues: []string{"main", "maint"},
+		},
+		{
+			name:           "branches of an unknown repository",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			request:        completeRequest(t, branchTemplate, "branch", "", map[string]string{"owner": "owner"}),
+			expectedValues: []string{},
+		},
+		{
+			name: "tags",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposTagsByOwnerByRepo, []*github.RepositoryTag{{Name: github.Ptr("v1.0.0")}, {Name: github.Ptr("v2.0.0")}}),
+			),
+			request:        completeRequest(t, map[string]string{"type": "ref/resource", "uri": "repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}"}, "tag", "v2", map[string]string{"owner": "owner", "repo": "repo"}),
+			expectedValues: []string{"v2.0.0"},
+		},
+		{
+			name: "open pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"state": "open", "per_page": "100"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.PullRequest{{Number: github.Ptr(12)}, {Number: github.Ptr(3)}}),
+					),
+				),
+			),
+			request:        completeRequest(t, map[string]string{"type": "ref/resource", "uri": "repo://{owner}/{repo}/refs/pull/{prNumber}/head/contents{/path*}"}, "prNumber", "1", map[string]string{"owner": "owner", "repo": "repo"}),
+			expectedValues: []string{"12"},
+		},
+		{
+			name:           "repository of the AssignCodingAgent prompt",
+			mockedClient:   mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetUserRepos, mockRepos)),
+			request:        completeRequest(t, map[string]string{"type": "ref/prompt", "name": "AssignCodingAgent"}, "repo", "octocat/", nil),
+			expectedValues: []string{"octocat/hello-world", "octocat/Spoon-Knife"},
+		},
+		{
+			name: "tags of the DraftReleaseNotes prompt",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposTagsByOwnerByRepo, []*github.RepositoryTag{{Name: github.Ptr("v1.0.0")}, {Name: github.Ptr("v2.0.0")}}),
+			),
+			request:        completeRequest(t, map[string]string{"type": "ref/prompt", "name": "DraftReleaseNotes"}, "from_tag", "v1", map[string]string{"owner": "owner", "repo": "repo"}),
+			expectedValues: []string{"v1.0.0"},
+		},
+		{
+			name:           "other resource templates have no completions",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			request:        completeRequest(t, map[string]string{"type": "ref/resource", "uri": "issue://{owner}/{repo}/{number}"}, "owner", "", nil),
+			expectedValues: []string{},
+		},
+		{
+			name: "API error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetUserRepos, mockResponse(t, http.StatusUnauthorized, `{"message": "Bad credentials"}`)),
+			),
+			request:        completeRequest(t, repoTemplate, "owner", "", nil),
+			expectedErrMsg: "failed to list repositories",
+			expectedCode:   mcp.INTERNAL_ERROR,
+		},
+		{
+			name:           "unsupported reference type",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			request:        completeRequest(t, map[string]string{"type": "ref/tool"}, "owner", "", nil),
+			expectedErrMsg: `unsupported reference type "ref/tool"`,
+			expectedCode:   mcp.INVALID_PARAMS,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			completions := NewCompletions(stubGetClientFn(github.NewClient(tc.mockedClient)), DefaultCompletionCacheTTL)
+			response, ok := completions.HandleMessage(context.Background(), tc.request)
+			require.True(t, ok)
+
+			if tc.expectedErrMsg != "" {
+				errResp, ok := response.(mcp.JSONRPCError)
+				require.True(t, ok, "expected an error, got %#v", response)
+				assert.Equal(t, tc.expectedCode, errResp.Error.Code)
+				assert.Contains(t, errResp.Error.Message, tc.expectedErrMsg)
+				return
+			}
+			result := completeResult(t, response)
+			assert.Equal(t, tc.expectedValues, result.Completion.Values)
+			assert.Equal(t, len(tc.expectedValues), result.Completion.Total)
+			assert.False(t, result.Completion.HasMore)
+		})
+	}
+}
+
+func Test_Completions_HandleMessage_OtherMessages(t *testing.T) {
+	completions := NewCompletions(stubGetClientFn(github.NewClient(nil)), DefaultCompletionCacheTTL)
+	for _, message := range []string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "resources/list"}`,
+		`{"jsonrpc": "2.0", "method": "completion/complete"}`,
+		`not json`,
+	} {
+		_, ok := completions.HandleMessage(context.Background(), json.RawMessage(message))
+		assert.False(t, ok, message)
+	}
+}
+
+func Test_Completions_Cache(t *testing.T) {
+	requests := 0
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposBranchesByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests++
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`[{"name": "main"}]`))
+			}),
+		),
+	)
+	completions := NewCompletions(stubGetClientFn(github.NewClient(mockedClient)), time.Minute)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	completions.now = func() time.Time { return now }
+
+	ref := map[string]string{"type": "ref/resource", "uri": "repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}"}
+	arguments := map[string]string{"owner": "owner", "repo": "repo"}
+	// Completing as the user types reuses the branches fetched for the first character
+	for _, value := range []string{"", "m", "ma"} {
+		response, _ := completions.HandleMessage(context.Background(), completeRequest(t, ref, "branch", value, arguments))
+		assert.Equal(t, []string{"main"}, completeResult(t, response).Completion.Values)
+	}
+	assert.Equal(t, 1, requests)
+
+	now = now.Add(2 * time.Minute)
+	_, _ = completions.HandleMessage(context.Background(), completeRequest(t, ref, "branch", "", arguments))
+	assert.Equal(t, 2, requests)
+}
+
+func Test_Completions_CacheEviction(t *testing.T) {
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposBranchesByOwnerByRepo,
+			mockResponse(t, http.StatusOK, []*github.Branch{{Name: github.Ptr("main")}}),
+		),
+	)
+	completions := NewCompletions(stubGetClientFn(github.NewClient(mockedClient)), time.Minute)
+	completions.maxEntries = 2
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	completions.now = func() time.Time { return now }
+
+	ref := map[string]string{"type": "ref/resource", "uri": "repo://{owner}/{repo}/refs/heads/{branch}/contents{/path*}"}
+	complete := func(repo string) {
+		t.Helper()
+		response, _ := completions.HandleMessage(context.Background(), completeRequest(t, ref, "branch", "", map[string]string{"owner": "owner", "repo": repo}))
+		assert.Equal(t, []string{"main"}, completeResult(t, response).Completion.Values)
+		now = now.Add(time.Second)
+	}
+
+	// The entries expiring first make room for new ones
+	complete("first")
+	complete("second")
+	complete("third")
+	assert.Len(t, completions.cache, 2)
+	assert.NotContains(t, completions.cache, repositoryCacheKey("branches", "owner", "first"))
+
+	// Expired entries are removed
+	now = now.Add(2 * time.Minute)
+	complete("fourth")
+	assert.Len(t, completions.cache, 1)
+	assert.Contains(t, completions.cache, repositoryCacheKey("branches", "owner", "fourth"))
+}
+
+func Test_filterCompletions(t *testing.T) {
+	values := make([]string, 0, 150)
+	for i := 0; i < 150; i++ {
+		values = append(values, fmt.Sprintf("branch-%d", i))
+	}
+	values = append(values, "main")
+
+	result := filterCompletions(values, "BRANCH-")
+	assert.Len(t, result.Completion.Values, maxCompletionValues)
+	assert.Equal(t, 150, result.Completion.Total)
+	assert.True(t, result.Completion.HasMore)
+
+	result = filterCompletions(values, "branch-14")
+	assert.Equal(t, []string{"branch-14", "branch-140", "branch-141", "branch-142", "branch-143", "branch-144", "branch-145", "branch-146", "branch-147", "branch-148", "branch-149"}, result.Completion.Values)
+	assert.False(t, result.Completion.HasMore)
+}
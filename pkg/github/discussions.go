@@ -381,3 +381,36 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 			return mcp.NewToolResultText(string(out)), nil
 		}
 }
+
+// SummarizeDiscussionPrompt creates a prompt to summarize a discussion and its comments.
+func SummarizeDiscussionPrompt(t translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc) {
+	return mcp.NewPrompt("SummarizeDiscussion",
+			mcp.WithPromptDescription(t("PROMPT_SUMMARIZE_DISCUSSION_DESCRIPTION", "Summarize a discussion, its conclusions and open questions.")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner (string)."), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name (string)."), mcp.RequiredArgument()),
+			mcp.WithArgument("discussionNumber", mcp.ArgumentDescription("Discussion number (number)."), mcp.RequiredArgument()),
+		), func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner, err := requiredPromptArg(request, "owner")
+			if err != nil {
+				return nil, err
+			}
+			repo, err := requiredPromptArg(request, "repo")
+			if err != nil {
+				return nil, err
+			}
+			number, err := requiredPromptIntArg(request, "discussionNumber")
+			if err != nil {
+				return nil, err
+			}
+
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Summary of discussion #%d of %s/%s", number, owner, repo),
+				Messages: promptMessages(
+					fmt.Sprintf("Please summarize discussion #%d in the %s/%s GitHub repository.", number, owner, repo),
+					fmt.Sprintf("Sure! I will read the discussion with `get_discussion` and all of its comments with `get_discussion_comments`, passing discussionNumber=%d.", number),
+					"Great. Start with a two sentence summary of the question or proposal. Then list the main positions with who holds them, what was decided or answered, and the questions that are still open. Quote comments sparingly, and only where the exact wording matters.",
+					"Understood. I will keep the summary neutral, and say so if the discussion has not reached a conclusion yet.",
+				),
+			}, nil
+		}
+}
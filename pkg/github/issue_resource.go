@@ -0,0 +1,107 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetIssueResource defines the resource template and handler for getting an issue with its comments.
+func GetIssueResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"issue://{owner}/{repo}/{number}", // Resource template
+			t("RESOURCE_ISSUE_DESCRIPTION", "Issue with its comments"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		IssueResourceHandler(getClient)
+}
+
+// IssueResourceHandler returns a handler function for issue requests, which renders the issue as Markdown.
+func IssueResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, err := requiredResourceParam(request, "owner")
+		if err != nil {
+			return nil, err
+		}
+		repo, err := requiredResourceParam(request, "repo")
+		if err != nil {
+			return nil, err
+		}
+		number, err := requiredResourceIntParam(request, "number")
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		issue, _, err := client.Issues.Get(ctx, owner, repo, int(number))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+
+		var comments []*github.IssueComment
+		opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		for page := 0; page < maxResourcePages; page++ {
+			pageComments, resp, err := client.Issues.ListComments(ctx, owner, repo, int(number), opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue comments: %w", err)
+			}
+			comments = append(comments, pageComments...)
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+
+		return markdownResource(request.Params.URI, renderIssue(owner, repo, issue, comments)), nil
+	}
+}
+
+// renderIssue renders an issue and its comments as Markdown
+func renderIssue(owner, repo string, issue *github.Issue, comments []*github.IssueComment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s (#%d)\n\n", issue.GetTitle(), issue.GetNumber())
+
+	state := issue.GetState()
+	if issue.GetStateReason() != "" {
+		state += " (" + issue.GetStateReason() + ")"
+	}
+	fmt.Fprintf(&b, "- **State:** %s\n", state)
+	fmt.Fprintf(&b, "- **Author:** %s\n", markdownUser(issue.GetUser()))
+	fmt.Fprintf(&b, "- **Created:** %s\n", markdownTime(issue.CreatedAt))
+	fmt.Fprintf(&b, "- **Updated:** %s\n", markdownTime(issue.UpdatedAt))
+	if len(issue.Labels) > 0 {
+		fmt.Fprintf(&b, "- **Labels:** %s\n", markdownLabels(issue.Labels))
+	}
+	if len(issue.Assignees) > 0 {
+		fmt.Fprintf(&b, "- **Assignees:** %s\n", markdownUsers(issue.Assignees))
+	}
+	if issue.GetMilestone().GetTitle() != "" {
+		fmt.Fprintf(&b, "- **Milestone:** %s\n", issue.GetMilestone().GetTitle())
+	}
+	if issue.IsPullRequest() {
+		fmt.Fprintf(&b, "- **Pull request:** pr://%s/%s/%d\n", owner, repo, issue.GetNumber())
+	}
+	fmt.Fprintf(&b, "- **URL:** %s\n\n", issue.GetHTMLURL())
+	fmt.Fprintf(&b, "%s\n", markdownBody(issue.GetBody()))
+
+	fmt.Fprintf(&b, "\n## Comments (%d)\n", issue.GetComments())
+	if len(comments) == 0 {
+		b.WriteString("\n_No comments._\n")
+	}
+	for _, comment := range comments {
+		fmt.Fprintf(&b, "\n### %s commented on %s\n\n%s\n", markdownUser(comment.GetUser()), markdownTime(comment.CreatedAt), strings.TrimSpace(comment.GetBody()))
+	}
+	if len(comments) < issue.GetComments() {
+		fmt.Fprintf(&b, "\n_Only the first %d comments are shown, see %s for the others._\n", len(comments), issue.GetHTMLURL())
+	}
+	return b.String()
+}
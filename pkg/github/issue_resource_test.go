@@ -0,0 +1,126 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readResourceRequest returns a request for uri with the given template variables
+func readResourceRequest(uri string, args map[string]string) mcp.ReadResourceRequest {
+	request := mcp.ReadResourceRequest{}
+	request.Params.URI = uri
+	request.Params.Arguments = map[string]any{}
+	for name, value := range args {
+		request.Params.Arguments[name] = []string{value}
+	}
+	return request
+}
+
+func Test_GetIssueResource(t *testing.T) {
+	tmpl, _ := GetIssueResource(nil, translations.NullTranslationHelper)
+	require.Equal(t, "issue://{owner}/{repo}/{number}", tmpl.URITemplate.Raw())
+	require.Equal(t, "text/markdown", tmpl.MIMEType)
+}
+
+func Test_IssueResourceHandler(t *testing.T) {
+	created := github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	mockIssue := &github.Issue{
+		Number:    github.Ptr(42),
+		Title:     github.Ptr("Crash on startup"),
+		Body:      github.Ptr("The server crashes.\n"),
+		State:     github.Ptr("open"),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/issues/42"),
+		User:      &github.User{Login: github.Ptr("octocat")},
+		Labels:    []*github.Label{{Name: github.Ptr("bug")}},
+		Assignees: []*github.User{{Login: github.Ptr("hubot")}},
+		Comments:  github.Ptr(1),
+		CreatedAt: &created,
+		UpdatedAt: &created,
+	}
+	mockComments := []*github.IssueComment{
+		{User: &github.User{Login: github.Ptr("hubot")}, Body: github.Ptr("Fixed in #43"), CreatedAt: &created},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		args           map[string]string
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "issue with comments",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, mockIssue),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{"per_page": "100"}).andThen(mockResponse(t, http.StatusOK, mockComments)),
+				),
+			),
+			args: map[string]string{"owner": "owner", "repo": "repo", "number": "42"},
+			expectedText: "# Crash on startup (#42)\n\n" +
+				"- **State:** open\n" +
+				"- **Author:** @octocat\n" +
+				"- **Created:** 2025-01-02T03:04:05Z\n" +
+				"- **Updated:** 2025-01-02T03:04:05Z\n" +
+				"- **Labels:** `bug`\n" +
+				"- **Assignees:** @hubot\n" +
+				"- **URL:** https://github.com/owner/repo/issues/42\n\n" +
+				"The server crashes.\n\n" +
+				"## Comments (1)\n\n" +
+				"### @hubot commented on 2025-01-02T03:04:05Z\n\n" +
+				"Fixed in #43\n",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			args:           map[string]string{"owner": "owner", "repo": "repo", "number": "42"},
+			expectedErrMsg: "failed to get issue",
+		},
+		{
+			name:           "invalid number",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			args:           map[string]string{"owner": "owner", "repo": "repo", "number": "abc"},
+			expectedErrMsg: "invalid number: abc",
+		},
+		{
+			name:           "missing repo",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			args:           map[string]string{"owner": "owner", "number": "42"},
+			expectedErrMsg: "repo is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := IssueResourceHandler(stubGetClientFn(github.NewClient(tc.mockedClient)))
+			uri := "issue://owner/repo/42"
+			contents, err := handler(context.Background(), readResourceRequest(uri, tc.args))
+			if tc.expectedErrMsg != "" {
+				require.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, markdownResource(uri, tc.expectedText), contents)
+		})
+	}
+}
+
+func Test_markdownFence(t *testing.T) {
+	assert.Equal(t, "```diff\n+a\n```\n", markdownFence("+a\n", "diff"))
+	assert.Equal(t, "````md\n```go\n```\n````\n", markdownFence("```go\n```", "md"))
+}
@@ -0,0 +1,112 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxResourcePages is the number of pages of 100 items that are fetched for list parts of resources
+const maxResourcePages = 10
+
+// requiredResourceParam returns a variable of the URI template of a resource request.
+func requiredResourceParam(request mcp.ReadResourceRequest, p string) (string, error) {
+	// the matcher will give []string with one element
+	// https://github.com/mark3labs/mcp-go/pull/54
+	v, ok := request.Params.Arguments[p].([]string)
+	if !ok || len(v) == 0 || v[0] == "" {
+		return "", fmt.Errorf("%s is required", p)
+	}
+	return v[0], nil
+}
+
+// requiredResourceIntParam returns a numeric variable of the URI template of a resource request.
+func requiredResourceIntParam(request mcp.ReadResourceRequest, p string) (int64, error) {
+	v, err := requiredResourceParam(request, p)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s: %s", p, v)
+	}
+	return n, nil
+}
+
+// markdownResource returns Markdown text as the contents of a resource
+func markdownResource(uri, text string) []mcp.ResourceContents {
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "text/markdown",
+			Text:     text,
+		},
+	}
+}
+
+// markdownFence wraps content in a fenced code block, with a fence longer than any run of backticks in content
+func markdownFence(content, lang string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fence + lang + "\n" + strings.TrimSuffix(content, "\n") + "\n" + fence + "\n"
+}
+
+// markdownTableCell escapes text for a cell of a Markdown table
+func markdownTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// markdownBody returns the body of an issue or pull request, or a placeholder if it is empty
+func markdownBody(body string) string {
+	if strings.TrimSpace(body) == "" {
+		return "_No description provided._"
+	}
+	return strings.TrimSpace(body)
+}
+
+// markdownUser returns the mention of a user
+func markdownUser(user *github.User) string {
+	if user.GetLogin() == "" {
+		return "_unknown_"
+	}
+	return "@" + user.GetLogin()
+}
+
+// markdownUsers returns the mentions of users, separated by commas
+func markdownUsers(users []*github.User) string {
+	mentions := make([]string, 0, len(users))
+	for _, user := range users {
+		mentions = append(mentions, markdownUser(user))
+	}
+	return strings.Join(mentions, ", ")
+}
+
+// markdownLabels returns the names of labels, separated by commas
+func markdownLabels(labels []*github.Label) string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, "`"+label.GetName()+"`")
+	}
+	return strings.Join(names, ", ")
+}
+
+// markdownTime formats a timestamp, which may be unset
+func markdownTime(ts *github.Timestamp) string {
+	if ts == nil || ts.IsZero() {
+		return "_unknown_"
+	}
+	return ts.UTC().Format(time.RFC3339)
+}
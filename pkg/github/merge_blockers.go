@@ -0,0 +1,375 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxMergeBlockerPages is the number of pages of reviews and commits read at most to explain merge blockers
+const maxMergeBlockerPages = 5
+
+// mergeBlockers explains whether and why a pull request cannot be merged
+type mergeBlockers struct {
+	PullNumber     int    `json:"pull_number"`
+	Base           string `json:"base"`
+	HeadSHA        string `json:"head_sha"`
+	MergeMethod    string `json:"merge_method"`
+	Mergeable      *bool  `json:"mergeable"`
+	MergeableState string `json:"mergeable_state"`
+	CanMerge       bool   `json:"can_merge"`
+	// Blockers are the reasons the pull request cannot be merged
+	Blockers []string `json:"blockers"`
+	// Notes are requirements that could not be checked, and ways around the blockers
+	Notes             []string               `json:"notes,omitempty"`
+	RequiredApprovals int                    `json:"required_approvals"`
+	Approvals         int                    `json:"approvals"`
+	RequiredChecks    []*requiredCheckStatus `json:"required_checks,omitempty"`
+	Rules             *branchRules           `json:"rules"`
+}
+
+type requiredCheckStatus struct {
+	Context string `json:"context"`
+	// State is success, pending, failure or missing
+	State string `json:"state"`
+}
+
+// GetPullRequestMergeBlockers creates a tool to explain why a pull request cannot be merged.
+func GetPullRequestMergeBlockers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_merge_blockers",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_MERGE_BLOCKERS_DESCRIPTION", "Explain why a pull request cannot be merged with merge_pull_request: conflicts, a draft or closed state, missing approvals or requested changes, failing, pending or missing required status checks, an out of date branch, a disallowed merge method, unsigned commits or a required merge queue, according to the branch protection and rulesets of its base branch")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_MERGE_BLOCKERS_USER_TITLE", "Explain why a pull request cannot be merged"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("merge_method",
+				mcp.Description("Merge method that would be used. Defaults to merge"),
+				mcp.Enum("merge", "squash", "rebase"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			method, err := OptionalParam[string](request, "merge_method")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if method == "" {
+				method = "merge"
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil
+			}
+			_ = resp.Body.Close()
+
+			base := pr.GetBase().GetRef()
+			rules, resp, err := getBranchRules(ctx, client, owner, repo, base)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get rules of branch %s", base), resp, err), nil
+			}
+
+			result := &mergeBlockers{
+				PullNumber:     pullNumber,
+				Base:           base,
+				HeadSHA:        pr.GetHead().GetSHA(),
+				MergeMethod:    method,
+				Mergeable:      pr.Mergeable,
+				MergeableState: pr.GetMergeableState(),
+				Blockers:       []string{},
+				Rules:          rules,
+			}
+			if resp, err := result.check(ctx, client, owner, repo, pr); err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to check pull request", resp, err), nil
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+func (m *mergeBlockers) block(format string, a ...any) {
+	m.Blockers = append(m.Blockers, fmt.Sprintf(format, a...))
+}
+
+func (m *mergeBlockers) note(format string, a ...any) {
+	m.Notes = append(m.Notes, fmt.Sprintf(format, a...))
+}
+
+// check finds the blockers of pr according to the rules of its base branch
+func (m *mergeBlockers) check(ctx context.Context, client *github.Client, owner, repo string, pr *github.PullRequest) (*github.Response, error) {
+	rules := m.Rules
+
+	switch {
+	case pr.GetMerged():
+		m.block("the pull request is already merged")
+	case pr.GetState() == "closed":
+		m.block("the pull request is closed")
+	case pr.GetDraft():
+		m.block("the pull request is a draft, mark it as ready for review first")
+	}
+
+	switch {
+	case pr.Mergeable == nil:
+		m.note("GitHub has not finished checking whether the pull request has conflicts, check again in a few seconds")
+	case !*pr.Mergeable || m.MergeableState == "dirty":
+		m.block("the pull request has conflicts with %s that must be resolved", m.Base)
+	}
+
+	if rules.Locked {
+		m.block("branch %s is locked and cannot be changed", m.Base)
+	}
+	if rules.RequireMergeQueue {
+		m.block("branch %s requires merging through the merge queue, merge_pull_request cannot merge it directly", m.Base)
+	}
+	if len(rules.AllowedMergeMethods) > 0 && !slices.Contains(rules.AllowedMergeMethods, m.MergeMethod) {
+		m.block("merge method %s is not allowed on %s, use one of %s", m.MergeMethod, m.Base, strings.Join(rules.AllowedMergeMethods, ", "))
+	}
+	if rules.RequireLinearHistory && m.MergeMethod == "merge" {
+		m.block("branch %s requires a linear history, use the squash or rebase merge method", m.Base)
+	}
+
+	if reviews := rules.RequiredReviews; reviews != nil {
+		resp, err := m.checkReviews(ctx, client, owner, repo, reviews)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	if checks := rules.RequiredStatusChecks; checks != nil {
+		if checks.Strict && m.MergeableState == "behind" {
+			m.block("the head branch is not up to date with %s, update it with update_pull_request_branch", m.Base)
+		}
+		if len(checks.Contexts) > 0 {
+			resp, err := m.checkStatuses(ctx, client, owner, repo, checks.Contexts)
+			if err != nil {
+				return resp, err
+			}
+		}
+	}
+
+	if rules.RequireSignedCommits {
+		if m.MergeMethod == "rebase" {
+			m.block("branch %s requires signed commits, which rebase merges cannot create, use the squash or merge method", m.Base)
+		} else if m.MergeMethod == "merge" {
+			resp, err := m.checkSignatures(ctx, client, owner, repo)
+			if err != nil {
+				return resp, err
+			}
+		}
+	}
+
+	if rules.RequireConversationResolution {
+		m.note("all review conversations must be resolved before merging")
+	}
+	if len(rules.RequiredDeployments) > 0 {
+		m.note("the pull request must be deployed successfully to %s before merging", strings.Join(rules.RequiredDeployments, ", "))
+	}
+	if !rules.ProtectionReadable {
+		m.note("the details of the branch protection of %s need admin access, some of its requirements may be missing", m.Base)
+	}
+	for _, rs := range rules.Rulesets {
+		if rs.CurrentUserCanBypass == "always" || rs.CurrentUserCanBypass == "pull_requests_only" {
+			m.note("you can bypass the rules of ruleset %q", rs.Name)
+		}
+	}
+
+	if len(m.Blockers) == 0 && m.MergeableState == "blocked" {
+		m.block("GitHub reports the pull request as blocked by a requirement of %s that could not be checked, such as code owner reviews, unresolved conversations or deployments", m.Base)
+	}
+	m.CanMerge = len(m.Blockers) == 0
+	return nil, nil
+}
+
+// checkReviews compares the latest reviews of each reviewer with the required reviews
+func (m *mergeBlockers) checkReviews(ctx context.Context, client *github.Client, owner, repo string, required *requiredReviews) (*github.Response, error) {
+	latest := map[string]string{}
+	var reviewers []string
+	opts := &github.ListOptions{PerPage: 100}
+	for range maxMergeBlockerPages {
+		reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, m.PullNumber, opts)
+		if err != nil {
+			return resp, err
+		}
+		_ = resp.Body.Close()
+		for _, r := range reviews {
+			login := r.GetUser().GetLogin()
+			switch state := r.GetState(); state {
+			case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+				if _, ok := latest[login]; !ok {
+					reviewers = append(reviewers, login)
+				}
+				latest[login] = state
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	var changesRequested []string
+	for _, login := range reviewers {
+		switch latest[login] {
+		case "APPROVED":
+			m.Approvals++
+		case "CHANGES_REQUESTED":
+			changesRequested = append(changesRequested, login)
+		}
+	}
+	m.RequiredApprovals = required.ApprovingReviewCount
+
+	if m.Approvals < required.ApprovingReviewCount {
+		m.block("%d approving reviews are required, the pull request has %d", required.ApprovingReviewCount, m.Approvals)
+	}
+	if len(changesRequested) > 0 {
+		m.block("changes were requested by %s", strings.Join(changesRequested, ", "))
+	}
+	if required.RequireCodeOwnerReview {
+		m.note("changed files with code owners need the approval of one of their owners")
+	}
+	if required.RequireLastPushApproval {
+		m.note("the most recent push must be approved by someone other than its author")
+	}
+	if len(required.BypassAllowances) > 0 {
+		m.note("%s can merge without the required reviews", strings.Join(required.BypassAllowances, ", "))
+	}
+	return nil, nil
+}
+
+// checkStatuses finds the state of the required status checks of the head commit, from its commit statuses and check runs
+func (m *mergeBlockers) checkStatuses(ctx context.Context, client *github.Client, owner, repo string, contexts []string) (*github.Response, error) {
+	states := map[string]string{}
+
+	status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, m.HeadSHA, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+	for _, s := range status.Statuses {
+		switch s.GetState() {
+		case "success":
+			states[s.GetContext()] = "success"
+		case "pending":
+			states[s.GetContext()] = "pending"
+		default:
+			states[s.GetContext()] = "failure"
+		}
+	}
+
+	runs, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, m.HeadSHA, &github.ListCheckRunsOptions{
+		Filter:      github.Ptr("latest"),
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+	for _, run := range runs.CheckRuns {
+		switch {
+		case run.GetStatus() != "completed":
+			states[run.GetName()] = "pending"
+		case slices.Contains([]string{"success", "neutral", "skipped"}, run.GetConclusion()):
+			states[run.GetName()] = "success"
+		default:
+			states[run.GetName()] = "failure"
+		}
+	}
+
+	var failing, pending, missing []string
+	for _, c := range contexts {
+		state, ok := states[c]
+		if !ok {
+			state = "missing"
+		}
+		m.RequiredChecks = append(m.RequiredChecks, &requiredCheckStatus{Context: c, State: state})
+		switch state {
+		case "failure":
+			failing = append(failing, c)
+		case "pending":
+			pending = append(pending, c)
+		case "missing":
+			missing = append(missing, c)
+		}
+	}
+	if len(failing) > 0 {
+		m.block("required status checks failed: %s", strings.Join(failing, ", "))
+	}
+	if len(pending) > 0 {
+		m.block("required status checks have not completed yet: %s", strings.Join(pending, ", "))
+	}
+	if len(missing) > 0 {
+		m.block("required status checks have not been reported for the head commit: %s", strings.Join(missing, ", "))
+	}
+	return nil, nil
+}
+
+// checkSignatures finds the commits of the pull request without a verified signature
+func (m *mergeBlockers) checkSignatures(ctx context.Context, client *github.Client, owner, repo string) (*github.Response, error) {
+	var unsigned []string
+	opts := &github.ListOptions{PerPage: 100}
+	for range maxMergeBlockerPages {
+		commits, resp, err := client.PullRequests.ListCommits(ctx, owner, repo, m.PullNumber, opts)
+		if err != nil {
+			return resp, err
+		}
+		_ = resp.Body.Close()
+		for _, c := range commits {
+			if !c.GetCommit().GetVerification().GetVerified() {
+				unsigned = append(unsigned, shortSHA(c.GetSHA()))
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	if len(unsigned) > 0 {
+		m.block("branch %s requires signed commits, but commits %s are not signed, use the squash method or sign them", m.Base, strings.Join(unsigned, ", "))
+	}
+	return nil, nil
+}
+
+func shortSHA(sha string) string {
+	return sha[:min(len(sha), 7)]
+}
@@ -0,0 +1,216 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPullRequestMergeBlockers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestMergeBlockers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_merge_blockers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "merge_method")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	pullRequest := func(draft bool, mergeable bool, state string) *github.PullRequest {
+		return &github.PullRequest{
+			Number:         github.Ptr(42),
+			State:          github.Ptr("open"),
+			Draft:          github.Ptr(draft),
+			Mergeable:      github.Ptr(mergeable),
+			MergeableState: github.Ptr(state),
+			Base:           &github.PullRequestBranch{Ref: github.Ptr("main")},
+			Head:           &github.PullRequestBranch{Ref: github.Ptr("feature"), SHA: github.Ptr("abcdef1234567890")},
+		}
+	}
+	review := func(login, state string) *github.PullRequestReview {
+		return &github.PullRequestReview{User: &github.User{Login: github.Ptr(login)}, State: github.Ptr(state)}
+	}
+	// mockedClient serves the rules of branch main: 2 approvals and the build and lint checks, up to date, are required,
+	// with a linear history and signed commits
+	mockedClient := func(pr *github.PullRequest, reviews []*github.PullRequestReview, statuses []*github.RepoStatus, runs []*github.CheckRun, commits []*github.RepositoryCommit) *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pr),
+			mock.WithRequestMatchHandler(
+				mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+				mockResponse(t, http.StatusNotFound, `{"message": "Branch not protected"}`),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+				mockResponse(t, http.StatusOK, `[
+					{"type": "pull_request", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 7, "parameters": {"required_approving_review_count": 2}},
+					{"type": "required_status_checks", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 7, "parameters": {
+						"strict_required_status_checks_policy": true, "required_status_checks": [{"context": "build"}, {"context": "lint"}, {"context": "test"}]
+					}},
+					{"type": "required_linear_history", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 7},
+					{"type": "required_signatures", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 7}
+				]`),
+			),
+			mock.WithRequestMatch(
+				mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+				&github.RepositoryRuleset{ID: github.Ptr(int64(7)), Name: "main", Enforcement: "active"},
+			),
+			mock.WithRequestMatch(mock.GetReposPullsReviewsByOwnerByRepoByPullNumber, reviews),
+			mock.WithRequestMatchHandler(
+				mock.GetReposCommitsStatusByOwnerByRepoByRef,
+				expectPath(t, "/repos/owner/repo/commits/abcdef1234567890/status").andThen(
+					mockResponse(t, http.StatusOK, &github.CombinedStatus{Statuses: statuses}),
+				),
+			),
+			mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, &github.ListCheckRunsResults{CheckRuns: runs}),
+			mock.WithRequestMatch(mock.GetReposPullsCommitsByOwnerByRepoByPullNumber, commits),
+		)
+	}
+	signed := func(sha string, verified bool) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:    github.Ptr(sha),
+			Commit: &github.Commit{Verification: &github.SignatureVerification{Verified: github.Ptr(verified)}},
+		}
+	}
+	passing := []*github.CheckRun{
+		{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+		{Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("skipped")},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedBlockers []string
+		expectedChecks   []*requiredCheckStatus
+	}{
+		{
+			name: "mergeable pull request",
+			mockedClient: mockedClient(
+				pullRequest(false, true, "clean"),
+				[]*github.PullRequestReview{review("alice", "CHANGES_REQUESTED"), review("alice", "APPROVED"), review("bob", "APPROVED"), review("carol", "COMMENTED")},
+				[]*github.RepoStatus{{Context: github.Ptr("test"), State: github.Ptr("success")}},
+				passing,
+				nil,
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"merge_method": "squash",
+			},
+			expectedBlockers: []string{},
+			expectedChecks: []*requiredCheckStatus{
+				{Context: "build", State: "success"},
+				{Context: "lint", State: "success"},
+				{Context: "test", State: "success"},
+			},
+		},
+		{
+			name: "every blocker is explained",
+			mockedClient: mockedClient(
+				pullRequest(true, false, "dirty"),
+				[]*github.PullRequestReview{review("alice", "APPROVED"), review("bob", "CHANGES_REQUESTED")},
+				[]*github.RepoStatus{{Context: github.Ptr("test"), State: github.Ptr("error")}},
+				[]*github.CheckRun{{Name: github.Ptr("build"), Status: github.Ptr("in_progress")}},
+				[]*github.RepositoryCommit{signed("1111111aaaa", true), signed("2222222bbbb", false)},
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedBlockers: []string{
+				"the pull request is a draft, mark it as ready for review first",
+				"the pull request has conflicts with main that must be resolved",
+				"branch main requires a linear history, use the squash or rebase merge method",
+				"2 approving reviews are required, the pull request has 1",
+				"changes were requested by bob",
+				"required status checks failed: test",
+				"required status checks have not completed yet: build",
+				"required status checks have not been reported for the head commit: lint",
+				"branch main requires signed commits, but commits 2222222 are not signed, use the squash method or sign them",
+			},
+			expectedChecks: []*requiredCheckStatus{
+				{Context: "build", State: "pending"},
+				{Context: "lint", State: "missing"},
+				{Context: "test", State: "failure"},
+			},
+		},
+		{
+			name: "out of date branch and rebase of signed commits",
+			mockedClient: mockedClient(
+				pullRequest(false, true, "behind"),
+				[]*github.PullRequestReview{review("alice", "APPROVED"), review("bob", "APPROVED")},
+				[]*github.RepoStatus{{Context: github.Ptr("test"), State: github.Ptr("success")}},
+				passing,
+				nil,
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"merge_method": "rebase",
+			},
+			expectedBlockers: []string{
+				"the head branch is not up to date with main, update it with update_pull_request_branch",
+				"branch main requires signed commits, which rebase merges cannot create, use the squash or merge method",
+			},
+			expectedChecks: []*requiredCheckStatus{
+				{Context: "build", State: "success"},
+				{Context: "lint", State: "success"},
+				{Context: "test", State: "success"},
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestMergeBlockers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned mergeBlockers
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedBlockers, returned.Blockers)
+			assert.Equal(t, len(tc.expectedBlockers) == 0, returned.CanMerge)
+			assert.Equal(t, tc.expectedChecks, returned.RequiredChecks)
+		})
+	}
+}
@@ -523,3 +523,57 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// TriageNotificationsPrompt creates a prompt to triage the notifications of the authenticated user.
+func TriageNotificationsPrompt(t translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc) {
+	return mcp.NewPrompt("TriageNotifications",
+			mcp.WithPromptDescription(t("PROMPT_TRIAGE_NOTIFICATIONS_DESCRIPTION", "Triage my unread notifications into what needs action, what to read and what to dismiss.")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Only triage notifications of repositories of this owner (string, optional).")),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Only triage notifications of this repository, requires owner (string, optional).")),
+			mcp.WithArgument("since", mcp.ArgumentDescription("Only triage notifications updated after this time (ISO 8601 timestamp, optional).")),
+		), func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner := request.Params.Arguments["owner"]
+			repo := request.Params.Arguments["repo"]
+			since := request.Params.Arguments["since"]
+			if repo != "" && owner == "" {
+				return nil, fmt.Errorf("argument repo requires owner")
+			}
+			if since != "" {
+				if _, err := parseISOTimestamp(since); err != nil {
+					return nil, fmt.Errorf("invalid since argument: %w", err)
+				}
+			}
+
+			scope := "my unread GitHub notifications"
+			switch {
+			case repo != "":
+				scope += fmt.Sprintf(" of the %s/%s repository", owner, repo)
+			case owner != "":
+				scope += fmt.Sprintf(" of repositories owned by %s", owner)
+			}
+			if since != "" {
+				scope += " updated since " + since
+			}
+
+			listing := "I will list them with `list_notifications`"
+			if since != "" {
+				listing += fmt.Sprintf(", passing since=%s", since)
+			}
+			switch {
+			case repo != "":
+				listing += fmt.Sprintf(", passing owner=%s and repo=%s", owner, repo)
+			case owner != "":
+				listing += fmt.Sprintf(", keeping those of repositories owned by %s", owner)
+			}
+
+			return &mcp.GetPromptResult{
+				Description: "Triage of " + scope,
+				Messages: promptMessages(
+					fmt.Sprintf("Please triage %s.", scope),
+					listing+". For notifications whose reason or title is not enough to decide, I will read them with `get_notification_details` and the issue or pull request they are about.",
+					"Sort them into three groups: needs my action (review requests, mentions, assignments and failing checks of my pull requests), worth reading, and safe to dismiss. Give every notification a one-line summary and a link, and put the most urgent ones first.",
+					"Understood. I will not change any notification on my own. Once you confirm which ones to dismiss, I will mark them as done or read with `dismiss_notification`, and unsubscribe from noisy threads with `manage_notification_subscription` if you ask me to.",
+				),
+			}, nil
+		}
+}
@@ -0,0 +1,43 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// requiredPromptArg returns a required argument of a prompt request.
+func requiredPromptArg(request mcp.GetPromptRequest, name string) (string, error) {
+	v := request.Params.Arguments[name]
+	if v == "" {
+		return "", fmt.Errorf("missing required argument: %s", name)
+	}
+	return v, nil
+}
+
+// requiredPromptIntArg returns a required argument of a prompt request holding a positive number.
+func requiredPromptIntArg(request mcp.GetPromptRequest, name string) (int, error) {
+	v, err := requiredPromptArg(request, name)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("argument %s must be a positive number, got %q", name, v)
+	}
+	return n, nil
+}
+
+// promptMessages returns prompt messages alternating between the user and the assistant, starting with the user.
+func promptMessages(texts ...string) []mcp.PromptMessage {
+	messages := make([]mcp.PromptMessage, 0, len(texts))
+	for i, text := range texts {
+		role := mcp.RoleUser
+		if i%2 == 1 {
+			role = mcp.RoleAssistant
+		}
+		messages = append(messages, mcp.PromptMessage{Role: role, Content: mcp.NewTextContent(text)})
+	}
+	return messages
+}
@@ -0,0 +1,151 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Prompts(t *testing.T) {
+	tests := []struct {
+		name             string
+		prompt           func(translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc)
+		expectedName     string
+		requiredArgs     []string
+		args             map[string]string
+		expectedErrMsg   string
+		expectedContains []string
+	}{
+		{
+			name:             "review pull request",
+			prompt:           ReviewPullRequestPrompt,
+			expectedName:     "ReviewPullRequest",
+			requiredArgs:     []string{"owner", "repo", "pullNumber"},
+			args:             map[string]string{"owner": "owner", "repo": "repo", "pullNumber": "42", "focus": "security"},
+			expectedContains: []string{"pull request #42 in the owner/repo GitHub repository, focusing on security", "`get_pull_request_diff`", "`get_pull_request_reviews`", "refs/pull/42/head"},
+		},
+		{
+			name:           "review pull request with an invalid number",
+			prompt:         ReviewPullRequestPrompt,
+			expectedName:   "ReviewPullRequest",
+			requiredArgs:   []string{"owner", "repo", "pullNumber"},
+			args:           map[string]string{"owner": "owner", "repo": "repo", "pullNumber": "abc"},
+			expectedErrMsg: `argument pullNumber must be a positive number, got "abc"`,
+		},
+		{
+			name:             "triage notifications of a repository",
+			prompt:           TriageNotificationsPrompt,
+			expectedName:     "TriageNotifications",
+			args:             map[string]string{"owner": "owner", "repo": "repo", "since": "2025-01-01"},
+			expectedContains: []string{"my unread GitHub notifications of the owner/repo repository updated since 2025-01-01", "passing since=2025-01-01, passing owner=owner and repo=repo", "`dismiss_notification`"},
+		},
+		{
+			name:             "triage all notifications",
+			prompt:           TriageNotificationsPrompt,
+			expectedName:     "TriageNotifications",
+			args:             map[string]string{},
+			expectedContains: []string{"Please triage my unread GitHub notifications.", "I will list them with `list_notifications`. "},
+		},
+		{
+			name:           "triage notifications of a repository without owner",
+			prompt:         TriageNotificationsPrompt,
+			expectedName:   "TriageNotifications",
+			args:           map[string]string{"repo": "repo"},
+			expectedErrMsg: "argument repo requires owner",
+		},
+		{
+			name:           "triage notifications with an invalid time",
+			prompt:         TriageNotificationsPrompt,
+			expectedName:   "TriageNotifications",
+			args:           map[string]string{"since": "yesterday"},
+			expectedErrMsg: "invalid since argument",
+		},
+		{
+			name:             "diagnose failing run",
+			prompt:           DiagnoseFailingRunPrompt,
+			expectedName:     "DiagnoseFailingRun",
+			requiredArgs:     []string{"owner", "repo", "run_id"},
+			args:             map[string]string{"owner": "owner", "repo": "repo", "run_id": "12345"},
+			expectedContains: []string{"Workflow run 12345 in the owner/repo GitHub repository failed", "`get_job_logs`, passing run_id=12345, failed_only=true and return_content=true", "`rerun_failed_jobs`"},
+		},
+		{
+			name:           "diagnose failing run without run ID",
+			prompt:         DiagnoseFailingRunPrompt,
+			expectedName:   "DiagnoseFailingRun",
+			requiredArgs:   []string{"owner", "repo", "run_id"},
+			args:           map[string]string{"owner": "owner", "repo": "repo"},
+			expectedErrMsg: "missing required argument: run_id",
+		},
+		{
+			name:             "draft release notes",
+			prompt:           DraftReleaseNotesPrompt,
+			expectedName:     "DraftReleaseNotes",
+			requiredArgs:     []string{"owner", "repo", "from_tag", "to_tag"},
+			args:             map[string]string{"owner": "owner", "repo": "repo", "from_tag": "v1.0.0", "to_tag": "v1.1.0"},
+			expectedContains: []string{"release notes of v1.1.0 in the owner/repo GitHub repository, covering the changes since v1.0.0", "`compare_refs`, passing base=v1.0.0 and head=v1.1.0"},
+		},
+		{
+			name:           "draft release notes between the same tags",
+			prompt:         DraftReleaseNotesPrompt,
+			expectedName:   "DraftReleaseNotes",
+			requiredArgs:   []string{"owner", "repo", "from_tag", "to_tag"},
+			args:           map[string]string{"owner": "owner", "repo": "repo", "from_tag": "v1.0.0", "to_tag": "v1.0.0"},
+			expectedErrMsg: "arguments from_tag and to_tag must be different tags",
+		},
+		{
+			name:             "summarize discussion",
+			prompt:           SummarizeDiscussionPrompt,
+			expectedName:     "SummarizeDiscussion",
+			requiredArgs:     []string{"owner", "repo", "discussionNumber"},
+			args:             map[string]string{"owner": "owner", "repo": "repo", "discussionNumber": "7"},
+			expectedContains: []string{"discussion #7 in the owner/repo GitHub repository", "`get_discussion_comments`, passing discussionNumber=7"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			prompt, handler := tc.prompt(translations.NullTranslationHelper)
+			assert.Equal(t, tc.expectedName, prompt.Name)
+			assert.NotEmpty(t, prompt.Description)
+			var required []string
+			for _, arg := range prompt.Arguments {
+				assert.NotEmpty(t, arg.Description, arg.Name)
+				if arg.Required {
+					required = append(required, arg.Name)
+				}
+			}
+			assert.Equal(t, tc.requiredArgs, required)
+
+			request := mcp.GetPromptRequest{}
+			request.Params.Name = prompt.Name
+			request.Params.Arguments = tc.args
+			result, err := handler(context.Background(), request)
+			if tc.expectedErrMsg != "" {
+				require.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, result.Description)
+
+			var text string
+			for i, message := range result.Messages {
+				expectedRole := mcp.RoleUser
+				if i%2 == 1 {
+					expectedRole = mcp.RoleAssistant
+				}
+				assert.Equal(t, expectedRole, message.Role)
+				content, ok := message.Content.(mcp.TextContent)
+				require.True(t, ok)
+				text += content.Text + "\n"
+			}
+			for _, expected := range tc.expectedContains {
+				assert.Contains(t, text, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetPullRequestResource defines the resource template and handler for getting a pull request.
+func GetPullRequestResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"pr://{owner}/{repo}/{number}", // Resource template
+			t("RESOURCE_PULL_REQUEST_DESCRIPTION", "Pull request"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		PullRequestResourceHandler(getClient)
+}
+
+// GetPullRequestDiffResource defines the resource template and handler for getting the diff of a pull request.
+func GetPullRequestDiffResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"pr://{owner}/{repo}/{number}/diff", // Resource template
+			t("RESOURCE_PULL_REQUEST_DIFF_DESCRIPTION", "Pull request diff"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		PullRequestDiffResourceHandler(getClient)
+}
+
+// GetPullRequestFilesResource defines the resource template and handler for getting the files changed by a pull request.
+func GetPullRequestFilesResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"pr://{owner}/{repo}/{number}/files", // Resource template
+			t("RESOURCE_PULL_REQUEST_FILES_DESCRIPTION", "Files changed by a pull request"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		PullRequestFilesResourceHandler(getClient)
+}
+
+// GetPullRequestReviewsResource defines the resource template and handler for getting the reviews of a pull request.
+func GetPullRequestReviewsResource(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	return mcp.NewResourceTemplate(
+			"pr://{owner}/{repo}/{number}/reviews", // Resource template
+			t("RESOURCE_PULL_REQUEST_REVIEWS_DESCRIPTION", "Pull request reviews and review comments"),
+			mcp.WithTemplateMIMEType("text/markdown"),
+		),
+		PullRequestReviewsResourceHandler(getClient)
+}
+
+// pullRequestResourceParams returns the owner, repository and number of a pull request resource request
+func pullRequestResourceParams(request mcp.ReadResourceRequest) (string, string, int, error) {
+	owner, err := requiredResourceParam(request, "owner")
+	if err != nil {
+		return "", "", 0, err
+	}
+	repo, err := requiredResourceParam(request, "repo")
+	if err != nil {
+		return "", "", 0, err
+	}
+	number, err := requiredResourceIntParam(request, "number")
+	if err != nil {
+		return "", "", 0, err
+	}
+	return owner, repo, int(number), nil
+}
+
+// PullRequestResourceHandler returns a handler function for pull request requests, which renders the pull request as Markdown.
+func PullRequestResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := pullRequestResourceParams(request)
+		if err != nil {
+			return nil, err
+		}
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		pr, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request: %w", err)
+		}
+		return markdownResource(request.Params.URI, renderPullRequest(owner, repo, pr)), nil
+	}
+}
+
+// renderPullRequest renders the description and state of a pull request as Markdown
+func renderPullRequest(owner, repo string, pr *github.PullRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s (#%d)\n\n", pr.GetTitle(), pr.GetNumber())
+
+	state := pr.GetState()
+	switch {
+	case pr.GetMerged():
+		state = "merged"
+	case pr.GetDraft():
+		state += " (draft)"
+	}
+	fmt.Fprintf(&b, "- **State:** %s\n", state)
+	fmt.Fprintf(&b, "- **Author:** %s\n", markdownUser(pr.GetUser()))
+	fmt.Fprintf(&b, "- **Branches:** `%s` ← `%s`\n", pr.GetBase().GetLabel(), pr.GetHead().GetLabel())
+	fmt.Fprintf(&b, "- **Head commit:** %s\n", pr.GetHead().GetSHA())
+	if pr.GetMerged() {
+		fmt.Fprintf(&b, "- **Merged:** %s by %s\n", markdownTime(pr.MergedAt), markdownUser(pr.GetMergedBy()))
+	} else if pr.GetMergeableState() != "" {
+		fmt.Fprintf(&b, "- **Mergeable state:** %s\n", pr.GetMergeableState())
+	}
+	fmt.Fprintf(&b, "- **Changes:** %d commits, %d files, +%d −%d\n", pr.GetCommits(), pr.GetChangedFiles(), pr.GetAdditions(), pr.GetDeletions())
+	if len(pr.Labels) > 0 {
+		fmt.Fprintf(&b, "- **Labels:** %s\n", markdownLabels(pr.Labels))
+	}
+	if len(pr.Assignees) > 0 {
+		fmt.Fprintf(&b, "- **Assignees:** %s\n", markdownUsers(pr.Assignees))
+	}
+	if len(pr.RequestedReviewers) > 0 {
+		fmt.Fprintf(&b, "- **Requested reviewers:** %s\n", markdownUsers(pr.RequestedReviewers))
+	}
+	fmt.Fprintf(&b, "- **Created:** %s\n", markdownTime(pr.CreatedAt))
+	fmt.Fprintf(&b, "- **Updated:** %s\n", markdownTime(pr.UpdatedAt))
+	fmt.Fprintf(&b, "- **URL:** %s\n\n", pr.GetHTMLURL())
+	fmt.Fprintf(&b, "%s\n", markdownBody(pr.GetBody()))
+
+	base := fmt.Sprintf("pr://%s/%s/%d", owner, repo, pr.GetNumber())
+	fmt.Fprintf(&b, "\n## Related resources\n\n- Diff: %s/diff\n- Files: %s/files\n- Reviews: %s/reviews\n", base, base, base)
+	return b.String()
+}
+
+// PullRequestDiffResourceHandler returns a handler function for pull request diff requests.
+func PullRequestDiffResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := pullRequestResourceParams(request)
+		if err != nil {
+			return nil, err
+		}
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		diff, resp, err := client.PullRequests.GetRaw(ctx, owner, repo, number, github.RawOptions{Type: github.Diff})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request diff: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return nil, fmt.Errorf("failed to get pull request diff: %s", string(body))
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# Diff of pull request #%d\n\n", number)
+		if diff == "" {
+			b.WriteString("_No changes._\n")
+		} else {
+			b.WriteString(markdownFence(diff, "diff"))
+		}
+		return markdownResource(request.Params.URI, b.String()), nil
+	}
+}
+
+// PullRequestFilesResourceHandler returns a handler function for requests of the files changed by a pull request.
+func PullRequestFilesResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := pullRequestResourceParams(request)
+		if err != nil {
+			return nil, err
+		}
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		files, truncated, _, err := listPullRequestFiles(ctx, client, owner, repo, number, maxResourcePages)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pull request files: %w", err)
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# Files changed by pull request #%d\n\n", number)
+		if len(files) == 0 {
+			b.WriteString("_No files changed._\n")
+			return markdownResource(request.Params.URI, b.String()), nil
+		}
+
+		additions, deletions := 0, 0
+		b.WriteString("| File | Status | Additions | Deletions |\n| --- | --- | --- | --- |\n")
+		for _, file := range files {
+			name := "`" + file.GetFilename() + "`"
+			if file.GetPreviousFilename() != "" {
+				name = "`" + file.GetPreviousFilename() + "` → " + name
+			}
+			fmt.Fprintf(&b, "| %s | %s | +%d | −%d |\n", markdownTableCell(name), file.GetStatus(), file.GetAdditions(), file.GetDeletions())
+			additions += file.GetAdditions()
+			deletions += file.GetDeletions()
+		}
+		fmt.Fprintf(&b, "\n%d files changed, +%d −%d.\n", len(files), additions, deletions)
+		if truncated {
+			fmt.Fprintf(&b, "\n_Only the first %d files are shown._\n", len(files))
+		}
+		return markdownResource(request.Params.URI, b.String()), nil
+	}
+}
+
+// PullRequestReviewsResourceHandler returns a handler function for requests of the reviews of a pull request.
+func PullRequestReviewsResourceHandler(getClient GetClientFn) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		owner, repo, number, err := pullRequestResourceParams(request)
+		if err != nil {
+			return nil, err
+		}
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		var reviews []*github.PullRequestReview
+		opts := &github.ListOptions{PerPage: 100}
+		for page := 0; page < maxResourcePages; page++ {
+			pageReviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request reviews: %w", err)
+			}
+			reviews = append(reviews, pageReviews...)
+			if resp.NextPage == 0 {
+				break
+			}
+			opts.Page = resp.NextPage
+		}
+
+		var comments []*github.PullRequestComment
+		commentOpts := &github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		for page := 0; page < maxResourcePages; page++ {
+			pageComments, resp, err := client.PullRequests.ListComments(ctx, owner, repo, number, commentOpts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request review comments: %w", err)
+			}
+			comments = append(comments, pageComments...)
+			if resp.NextPage == 0 {
+				break
+			}
+			commentOpts.Page = resp.NextPage
+		}
+
+		return markdownResource(request.Params.URI, renderPullRequestReviews(number, reviews, comments)), nil
+	}
+}
+
+// renderPullRequestReviews renders the reviews of a pull request and their comments as Markdown
+func renderPullRequestReviews(number int, reviews []*github.PullRequestReview, comments []*github.PullRequestComment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Reviews of pull request #%d\n", number)
+
+	fmt.Fprintf(&b, "\n## Reviews (%d)\n", len(reviews))
+	if len(reviews) == 0 {
+		b.WriteString("\n_No reviews._\n")
+	}
+	for _, review := range reviews {
+		fmt.Fprintf(&b, "\n### %s: %s on %s\n", markdownUser(review.GetUser()), review.GetState(), markdownTime(review.SubmittedAt))
+		if strings.TrimSpace(review.GetBody()) != "" {
+			fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(review.GetBody()))
+		}
+	}
+
+	fmt.Fprintf(&b, "\n## Review comments (%d)\n", len(comments))
+	if len(comments) == 0 {
+		b.WriteString("\n_No review comments._\n")
+	}
+	for _, comment := range comments {
+		location := "`" + comment.GetPath() + "`"
+		if comment.GetLine() != 0 {
+			location = fmt.Sprintf("`%s` line %d", comment.GetPath(), comment.GetLine())
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n%s on %s:\n\n", location, markdownUser(comment.GetUser()), markdownTime(comment.CreatedAt))
+		if comment.GetDiffHunk() != "" {
+			b.WriteString(markdownFence(comment.GetDiffHunk(), "diff"))
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s\n", strings.TrimSpace(comment.GetBody()))
+	}
+	return b.String()
+}
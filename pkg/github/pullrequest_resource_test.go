@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPullRequestResources(t *testing.T) {
+	tmpl, _ := GetPullRequestResource(nil, translations.NullTranslationHelper)
+	require.Equal(t, "pr://{owner}/{repo}/{number}", tmpl.URITemplate.Raw())
+	tmpl, _ = GetPullRequestDiffResource(nil, translations.NullTranslationHelper)
+	require.Equal(t, "pr://{owner}/{repo}/{number}/diff", tmpl.URITemplate.Raw())
+	tmpl, _ = GetPullRequestFilesResource(nil, translations.NullTranslationHelper)
+	require.Equal(t, "pr://{owner}/{repo}/{number}/files", tmpl.URITemplate.Raw())
+	tmpl, _ = GetPullRequestReviewsResource(nil, translations.NullTranslationHelper)
+	require.Equal(t, "pr://{owner}/{repo}/{number}/reviews", tmpl.URITemplate.Raw())
+
+	// The pull request template must not match the URIs of its sub-resources
+	tmpl, _ = GetPullRequestResource(nil, translations.NullTranslationHelper)
+	assert.True(t, tmpl.URITemplate.Regexp().MatchString("pr://owner/repo/1"))
+	assert.False(t, tmpl.URITemplate.Regexp().MatchString("pr://owner/repo/1/diff"))
+}
+
+func Test_PullRequestResourceHandlers(t *testing.T) {
+	created := github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	mockPR := &github.PullRequest{
+		Number:         github.Ptr(7),
+		Title:          github.Ptr("Add feature"),
+		Body:           github.Ptr(""),
+		State:          github.Ptr("open"),
+		Draft:          github.Ptr(true),
+		HTMLURL:        github.Ptr("https://github.com/owner/repo/pull/7"),
+		User:           &github.User{Login: github.Ptr("octocat")},
+		Base:           &github.PullRequestBranch{Label: github.Ptr("owner:main")},
+		Head:           &github.PullRequestBranch{Label: github.Ptr("octocat:feature"), SHA: github.Ptr("abc123")},
+		MergeableState: github.Ptr("clean"),
+		Commits:        github.Ptr(2),
+		ChangedFiles:   github.Ptr(1),
+		Additions:      github.Ptr(10),
+		Deletions:      github.Ptr(3),
+		CreatedAt:      &created,
+		UpdatedAt:      &created,
+	}
+	mockFiles := []*github.CommitFile{
+		{Filename: github.Ptr("new.go"), PreviousFilename: github.Ptr("old.go"), Status: github.Ptr("renamed"), Additions: github.Ptr(10), Deletions: github.Ptr(3)},
+	}
+	mockReviews := []*github.PullRequestReview{
+		{User: &github.User{Login: github.Ptr("hubot")}, State: github.Ptr("APPROVED"), Body: github.Ptr("LGTM"), SubmittedAt: &created},
+	}
+	mockComments := []*github.PullRequestComment{
+		{User: &github.User{Login: github.Ptr("hubot")}, Path: github.Ptr("new.go"), Line: github.Ptr(4), DiffHunk: github.Ptr("@@ -1 +1 @@\n+x"), Body: github.Ptr("Nit"), CreatedAt: &created},
+	}
+
+	tests := []struct {
+		name           string
+		handler        func(GetClientFn) server.ResourceTemplateHandlerFunc
+		mockedClient   *http.Client
+		args           map[string]string
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name:         "pull request",
+			handler:      PullRequestResourceHandler,
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, mockPR)),
+			args:         map[string]string{"owner": "owner", "repo": "repo", "number": "7"},
+			expectedText: "# Add feature (#7)\n\n" +
+				"- **State:** open (draft)\n" +
+				"- **Author:** @octocat\n" +
+				"- **Branches:** `owner:main` ← `octocat:feature`\n" +
+				"- **Head commit:** abc123\n" +
+				"- **Mergeable state:** clean\n" +
+				"- **Changes:** 2 commits, 1 files, +10 −3\n" +
+				"- **Created:** 2025-01-02T03:04:05Z\n" +
+				"- **Updated:** 2025-01-02T03:04:05Z\n" +
+				"- **URL:** https://github.com/owner/repo/pull/7\n\n" +
+				"_No description provided._\n\n" +
+				"## Related resources\n\n" +
+				"- Diff: pr://owner/repo/7/diff\n" +
+				"- Files: pr://owner/repo/7/files\n" +
+				"- Reviews: pr://owner/repo/7/reviews\n",
+		},
+		{
+			name:    "diff",
+			handler: PullRequestDiffResourceHandler,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte("diff --git a/x b/x\n+x\n"))
+					}),
+				),
+			),
+			args:         map[string]string{"owner": "owner", "repo": "repo", "number": "7"},
+			expectedText: "# Diff of pull request #7\n\n```diff\ndiff --git a/x b/x\n+x\n```\n",
+		},
+		{
+			name:    "files",
+			handler: PullRequestFilesResourceHandler,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsFilesByOwnerByRepoByPullNumber, mockFiles),
+			),
+			args: map[string]string{"owner": "owner", "repo": "repo", "number": "7"},
+			expectedText: "# Files changed by pull request #7\n\n" +
+				"| File | Status | Additions | Deletions |\n| --- | --- | --- | --- |\n" +
+				"| `old.go` → `new.go` | renamed | +10 | −3 |\n\n" +
+				"1 files changed, +10 −3.\n",
+		},
+		{
+			name:    "reviews",
+			handler: PullRequestReviewsResourceHandler,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsReviewsByOwnerByRepoByPullNumber, mockReviews),
+				mock.WithRequestMatch(mock.GetReposPullsCommentsByOwnerByRepoByPullNumber, mockComments),
+			),
+			args: map[string]string{"owner": "owner", "repo": "repo", "number": "7"},
+			expectedText: "# Reviews of pull request #7\n\n" +
+				"## Reviews (1)\n\n" +
+				"### @hubot: APPROVED on 2025-01-02T03:04:05Z\n\nLGTM\n\n" +
+				"## Review comments (1)\n\n" +
+				"### `new.go` line 4\n\n" +
+				"@hubot on 2025-01-02T03:04:05Z:\n\n" +
+				"```diff\n@@ -1 +1 @@\n+x\n```\n\n" +
+				"Nit\n",
+		},
+		{
+			name:    "pull request not found",
+			handler: PullRequestResourceHandler,
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			args:           map[string]string{"owner": "owner", "repo": "repo", "number": "7"},
+			expectedErrMsg: "failed to get pull request",
+		},
+		{
+			name:           "invalid number",
+			handler:        PullRequestFilesResourceHandler,
+			mockedClient:   mock.NewMockedHTTPClient(),
+			args:           map[string]string{"owner": "owner", "repo": "repo", "number": "0"},
+			expectedErrMsg: "invalid number: 0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := tc.handler(stubGetClientFn(github.NewClient(tc.mockedClient)))
+			uri := "pr://owner/repo/7"
+			contents, err := handler(context.Background(), readResourceRequest(uri, tc.args))
+			if tc.expectedErrMsg != "" {
+				require.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, markdownResource(uri, tc.expectedText), contents)
+		})
+	}
+}
@@ -652,6 +652,28 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 		}
 }
 
+// listPullRequestFiles lists the files changed by a pull request, reading at most maxPages pages of 100 files.
+// truncated is true if the pull request changes more files.
+func listPullRequestFiles(ctx context.Context, client *github.Client, owner, repo string, number, maxPages int) ([]*github.CommitFile, bool, *github.Response, error) {
+	var files []*github.CommitFile
+	opts := &github.ListOptions{PerPage: 100}
+	for page := 0; ; page++ {
+		if page == maxPages {
+			return files, true, nil, nil
+		}
+		pageFiles, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, false, resp, err
+		}
+		_ = resp.Body.Close()
+		files = append(files, pageFiles...)
+		if resp.NextPage == 0 {
+			return files, false, nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
 // GetPullRequestStatus creates a tool to get the combined status of all status checks for a pull request.
 func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_status",
@@ -1739,3 +1761,42 @@ func newGQLIntPtr(i *int32) *githubv4.Int {
 	gi := githubv4.Int(*i)
 	return &gi
 }
+
+// ReviewPullRequestPrompt creates a prompt to review a pull request from its diff, files and existing reviews.
+func ReviewPullRequestPrompt(t translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc) {
+	return mcp.NewPrompt("ReviewPullRequest",
+			mcp.WithPromptDescription(t("PROMPT_REVIEW_PULL_REQUEST_DESCRIPTION", "Review a pull request, taking its diff, changed files and existing reviews into account.")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner (string)."), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name (string)."), mcp.RequiredArgument()),
+			mcp.WithArgument("pullNumber", mcp.ArgumentDescription("Pull request number (number)."), mcp.RequiredArgument()),
+			mcp.WithArgument("focus", mcp.ArgumentDescription("Aspects to focus the review on, such as security or performance (string, optional).")),
+		), func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner, err := requiredPromptArg(request, "owner")
+			if err != nil {
+				return nil, err
+			}
+			repo, err := requiredPromptArg(request, "repo")
+			if err != nil {
+				return nil, err
+			}
+			pullNumber, err := requiredPromptIntArg(request, "pullNumber")
+			if err != nil {
+				return nil, err
+			}
+
+			focus := "correctness, readability, tests and consistency with the rest of the code base"
+			if f := request.Params.Arguments["focus"]; f != "" {
+				focus = f
+			}
+
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Review of pull request #%d of %s/%s", pullNumber, owner, repo),
+				Messages: promptMessages(
+					fmt.Sprintf("Please review pull request #%d in the %s/%s GitHub repository, focusing on %s.", pullNumber, owner, repo, focus),
+					fmt.Sprintf("Sure! I will start by reading the pull request with `get_pull_request`, its diff with `get_pull_request_diff`, the changed files with `get_pull_request_files` and the status of its checks with `get_pull_request_status`. I will also read the existing reviews with `get_pull_request_reviews` and review comments with `get_pull_request_comments`, so I don't repeat what reviewers already said. When the diff lacks context, I will read the files at the head of the pull request with `get_file_contents`, using the ref refs/pull/%d/head.", pullNumber),
+					"Great. Summarize what the pull request changes, then list your findings ordered by severity, each with the file and line it refers to. Point out which comments of existing reviews are still unaddressed. Only comment on the lines the pull request changes, and say so explicitly if you found nothing worth changing.",
+					"Understood. Once I have presented my findings, I will ask you whether to leave them as a review. If you agree, I will create a pending review with `create_pending_pull_request_review`, add my comments with `add_pull_request_review_comment_to_pending_review` and only submit it with `submit_pending_pull_request_review` after you confirm the verdict.",
+				),
+			}, nil
+		}
+}
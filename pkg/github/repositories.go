@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/raw"
@@ -18,6 +21,195 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// repositoryWithLanguages is a repository along with the number of bytes of code written in each of its languages
+type repositoryWithLanguages struct {
+	*github.Repository
+	Languages map[string]int `json:"languages"`
+}
+
+// GetRepository creates a tool to get the details of a repository.
+func GetRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_DESCRIPTION", "Get details of a GitHub repository, including its settings, default branch, topics, languages, license, visibility and the permissions of the authenticated user")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_USER_TITLE", "Get repository details"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			languages, resp, err := client.Repositories.ListLanguages(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list languages of repository %s/%s", owner, repo),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+
+			r, err := json.Marshal(repositoryWithLanguages{Repository: repository, Languages: languages})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// repositoryListTypes are the values of the type filter of list_repositories accepted for each kind of owner
+var repositoryListTypes = map[string][]string{
+	"authenticated user": {"all", "owner", "public", "private", "member"},
+	"user":               {"all", "owner", "member"},
+	"organization":       {"all", "public", "private", "forks", "sources", "member"},
+}
+
+// ListRepositories creates a tool to list the repositories of a user, an organization or the authenticated user.
+func ListRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repositories",
+			mcp.WithDescription(t("TOOL_LIST_REPOSITORIES_DESCRIPTION", "List the repositories of a user or organization, or those the authenticated user can access if no owner is given")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPOSITORIES_USER_TITLE", "List repositories"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Description("User or organization to list the repositories of. If not provided, lists the repositories the authenticated user owns, collaborates on or can access as an organization member"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Type of repositories to list. Users accept all, owner and member. Organizations accept all, public, private, forks, sources and member. The authenticated user accepts all, owner, public, private and member"),
+				mcp.Enum("all", "owner", "public", "private", "member", "forks", "sources"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Property to sort repositories by"),
+				mcp.Enum("created", "updated", "pushed", "full_name"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction, defaults to asc when sorting by full_name and to desc otherwise"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			listType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			listOpts := github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			kind := "authenticated user"
+			if owner != "" {
+				user, resp, err := client.Users.Get(ctx, owner)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get owner %s", owner),
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				kind = "user"
+				if user.GetType() == "Organization" {
+					kind = "organization"
+				}
+			}
+			if listType != "" && !slices.Contains(repositoryListTypes[kind], listType) {
+				subject := "the " + kind
+				if owner != "" {
+					subject = kind + " " + owner
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("type %s is not supported for the repositories of %s, use one of: %s",
+					listType, subject, strings.Join(repositoryListTypes[kind], ", "))), nil
+			}
+
+			var repos []*github.Repository
+			var resp *github.Response
+			switch kind {
+			case "organization":
+				repos, resp, err = client.Repositories.ListByOrg(ctx, owner, &github.RepositoryListByOrgOptions{
+					Type: listType, Sort: sort, Direction: direction, ListOptions: listOpts,
+				})
+			case "user":
+				repos, resp, err = client.Repositories.ListByUser(ctx, owner, &github.RepositoryListByUserOptions{
+					Type: listType, Sort: sort, Direction: direction, ListOptions: listOpts,
+				})
+			default:
+				repos, resp, err = client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{
+					Type: listType, Sort: sort, Direction: direction, ListOptions: listOpts,
+				})
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to list repositories",
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(repos)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_commit",
 			mcp.WithDescription(t("TOOL_GET_COMMITS_DESCRIPTION", "Get details for a commit from a GitHub repository")),
@@ -183,6 +375,203 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 		}
 }
 
+// maxCompareFiles is the number of changed files the compare API lists at most
+const maxCompareFiles = 300
+
+// compareCommit is a commit of a comparison, without the details of its tree and files
+type compareCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author,omitempty"`
+	Date    string `json:"date,omitempty"`
+}
+
+// compareFile is a file changed between the refs of a comparison
+type compareFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Changes          int    `json:"changes"`
+	Patch            string `json:"patch,omitempty"`
+}
+
+// compareResult is the result of compare_refs. TotalFiles counts every changed file, including those left out of
+// Files, unless FilesTruncated says there are too many to count.
+type compareResult struct {
+	Status         string          `json:"status"`
+	AheadBy        int             `json:"ahead_by"`
+	BehindBy       int             `json:"behind_by"`
+	TotalCommits   int             `json:"total_commits"`
+	MergeBaseSHA   string          `json:"merge_base_sha"`
+	HTMLURL        string          `json:"html_url"`
+	Commits        []compareCommit `json:"commits"`
+	Files          []compareFile   `json:"files,omitempty"`
+	TotalFiles     int             `json:"total_files,omitempty"`
+	Additions      int             `json:"additions,omitempty"`
+	Deletions      int             `json:"deletions,omitempty"`
+	FilesTruncated bool            `json:"files_truncated,omitempty"`
+}
+
+// CompareRefs creates a tool to compare two commits, branches or tags of a repository.
+func CompareRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_refs",
+			mcp.WithDescription(t("TOOL_COMPARE_REFS_DESCRIPTION", "Compare two commits, branches or tags of a GitHub repository. Returns how far head is ahead of and behind base, the commits of head missing from base, and the files changed with their line counts. Commits are paginated; changed files are only listed on the first page, up to 300 of them, with total_files counting every changed file")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_REFS_USER_TITLE", "Compare refs"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag to compare from"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag to compare to. Use owner:branch to compare with a branch of a fork"),
+			),
+			mcp.WithBoolean("include_diff",
+				mcp.Description("Include the unified diff of every changed file. Like in get_pull_request_files, GitHub omits the diff of binary and very large files"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := RequiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := RequiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includeDiff, err := OptionalParam[bool](request, "include_diff")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			opts := &github.ListOptions{
+				PerPage: pagination.perPage,
+				Page:    pagination.page,
+			}
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to compare %s...%s", base, head),
+					resp,
+					err,
+				), nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to compare %s...%s: %s", base, head, string(body))), nil
+			}
+
+			result := compareResult{
+				Status:       comparison.GetStatus(),
+				AheadBy:      comparison.GetAheadBy(),
+				BehindBy:     comparison.GetBehindBy(),
+				TotalCommits: comparison.GetTotalCommits(),
+				MergeBaseSHA: comparison.GetMergeBaseCommit().GetSHA(),
+				HTMLURL:      comparison.GetHTMLURL(),
+				Commits:      make([]compareCommit, 0, len(comparison.Commits)),
+			}
+			for _, commit := range comparison.Commits {
+				c := compareCommit{
+					SHA:     commit.GetSHA(),
+					Message: commit.GetCommit().GetMessage(),
+					Author:  commit.GetAuthor().GetLogin(),
+				}
+				if c.Author == "" {
+					c.Author = commit.GetCommit().GetAuthor().GetName()
+				}
+				if date := commit.GetCommit().GetAuthor().Date; date != nil {
+					c.Date = date.UTC().Format(time.RFC3339)
+				}
+				result.Commits = append(result.Commits, c)
+			}
+			for _, file := range comparison.Files {
+				f := compareFile{
+					Filename:         file.GetFilename(),
+					PreviousFilename: file.GetPreviousFilename(),
+					Status:           file.GetStatus(),
+					Additions:        file.GetAdditions(),
+					Deletions:        file.GetDeletions(),
+					Changes:          file.GetChanges(),
+				}
+				if includeDiff {
+					f.Patch = file.GetPatch()
+				}
+				result.Files = append(result.Files, f)
+				result.Additions += f.Additions
+				result.Deletions += f.Deletions
+			}
+			result.TotalFiles = len(result.Files)
+			if len(result.Files) >= maxCompareFiles {
+				total, tooLarge, resp, err := countCompareFiles(ctx, client, owner, repo, base, head)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to count the files changed by %s...%s", base, head),
+						resp,
+						err,
+					), nil
+				}
+				// Beyond the files the diff allows, the total is unknown
+				result.TotalFiles = total
+				result.FilesTruncated = tooLarge || total > len(result.Files)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// countCompareFiles counts the files changed between base and head from the diff of their comparison, which lists
+// every changed file rather than the first maxCompareFiles. tooLarge is true if GitHub refuses to diff that many files.
+func countCompareFiles(ctx context.Context, client *github.Client, owner, repo, base, head string) (int, bool, *github.Response, error) {
+	diff, resp, err := client.Repositories.CompareCommitsRaw(ctx, owner, repo, base, head, github.RawOptions{Type: github.Diff})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotAcceptable {
+			return 0, true, nil, nil
+		}
+		return 0, false, resp, err
+	}
+	_ = resp.Body.Close()
+	return strings.Count("\n"+diff, "\ndiff --git "), false, nil, nil
+}
+
 // ListBranches creates a tool to list branches in a GitHub repository.
 func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("list_branches",
@@ -471,6 +860,14 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			mcp.WithString("sha",
 				mcp.Description("Accepts optional commit SHA. If specified, it will be used instead of ref"),
 			),
+			mcp.WithNumber("start_line",
+				mcp.Description("First line to return, starting at 1. Use with end_line to read part of a large text file"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("end_line",
+				mcp.Description("Last line to return, inclusive. Defaults to the end of the file"),
+				mcp.Min(1),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := RequiredParam[string](request, "owner")
@@ -493,6 +890,20 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			startLine, err := OptionalIntParam(request, "start_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			endLine, err := OptionalIntParam(request, "end_line")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if endLine != 0 && startLine == 0 {
+				startLine = 1
+			}
+			if startLine != 0 && (path == "" || strings.HasSuffix(path, "/")) {
+				return mcp.NewToolResultError("start_line and end_line are only supported for files"), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -512,55 +923,60 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 				if err != nil {
 					return mcp.NewToolResultError("failed to get GitHub raw content client"), nil
 				}
-				resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
-				if err != nil {
-					return mcp.NewToolResultError("failed to get raw repository content"), nil
+				var content *raw.Content
+				if startLine != 0 {
+					content, err = rawClient.ReadRawLines(ctx, owner, repo, path, rawOpts, startLine, endLine)
+				} else {
+					content, err = rawClient.ReadRawContent(ctx, owner, repo, path, rawOpts)
 				}
-				defer func() {
-					_ = resp.Body.Close()
-				}()
 
-				if resp.StatusCode == http.StatusOK {
+				var tooLarge *raw.TooLargeError
+				var statusErr *raw.StatusError
+				switch {
+				case errors.As(err, &tooLarge):
+					return mcp.NewToolResultError(fmt.Sprintf("%s, use start_line and end_line to read part of the file", tooLarge)), nil
+				case errors.As(err, &statusErr):
+					// The path may be a directory, which is handled below
+				case err != nil:
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get raw repository content: %s", err)), nil
+				default:
 					// If the raw content is found, return it directly
-					body, err := io.ReadAll(resp.Body)
+					contentType := content.ContentType
+					source := ""
+					if content.LFS != nil {
+						source = fmt.Sprintf(" from Git LFS object %s", content.LFS.OID)
+					}
+
+					resourceURI, err := repositoryResourceURI(owner, repo, ref, sha, path)
 					if err != nil {
-						return mcp.NewToolResultError("failed to read response body"), nil
+						return nil, fmt.Errorf("failed to create resource URI: %w", err)
 					}
-					contentType := resp.Header.Get("Content-Type")
-
-					var resourceURI string
-					switch {
-					case sha != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					case ref != "":
-						resourceURI, err = url.JoinPath("repo://", owner, repo, ref, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
-						}
-					default:
-						resourceURI, err = url.JoinPath("repo://", owner, repo, "contents", path)
-						if err != nil {
-							return nil, fmt.Errorf("failed to create resource URI: %w", err)
+
+					isText := strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text")
+					if startLine != 0 {
+						if !isText {
+							return mcp.NewToolResultError("start_line and end_line are only supported for text files"), nil
 						}
+						return mcp.NewToolResultResource(fmt.Sprintf("successfully downloaded lines %d-%d of text file%s", content.StartLine, content.EndLine, source), mcp.TextResourceContents{
+							URI:      resourceURI,
+							Text:     string(content.Data),
+							MIMEType: contentType,
+						}), nil
 					}
 
-					if strings.HasPrefix(contentType, "application") || strings.HasPrefix(contentType, "text") {
-						return mcp.NewToolResultResource("successfully downloaded text file", mcp.TextResourceContents{
+					if isText {
+						return mcp.NewToolResultResource("successfully downloaded text file"+source, mcp.TextResourceContents{
 							URI:      resourceURI,
-							Text:     string(body),
+							Text:     string(content.Data),
 							MIMEType: contentType,
 						}), nil
 					}
 
-					return mcp.NewToolResultResource("successfully downloaded binary file", mcp.BlobResourceContents{
+					return mcp.NewToolResultResource("successfully downloaded binary file"+source, mcp.BlobResourceContents{
 						URI:      resourceURI,
-						Blob:     base64.StdEncoding.EncodeToString(body),
+						Blob:     base64.StdEncoding.EncodeToString(content.Data),
 						MIMEType: contentType,
 					}), nil
-
 				}
 			}
 
@@ -1330,6 +1746,19 @@ func filterPaths(entries []*github.TreeEntry, path string, maxResults int) []str
 	return matchedPaths
 }
 
+// repositoryResourceURI returns the repo:// URI of a path at a commit SHA, which takes precedence, at a fully
+// qualified ref such as refs/heads/{branch} or refs/tags/{tag}, or at the default branch if both are empty
+func repositoryResourceURI(owner, repo, ref, sha, path string) (string, error) {
+	switch {
+	case sha != "":
+		return url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
+	case ref != "":
+		return url.JoinPath("repo://", owner, repo, ref, "contents", path)
+	default:
+		return url.JoinPath("repo://", owner, repo, "contents", path)
+	}
+}
+
 // resolveGitReference resolves git references with the following logic:
 // 1. If SHA is provided, it takes precedence
 // 2. If neither is provided, use the default branch as ref
@@ -1363,3 +1792,44 @@ func resolveGitReference(ctx context.Context, githubClient *github.Client, owner
 	// Use provided ref, or it will be empty which defaults to the default branch
 	return &raw.ContentOpts{Ref: ref, SHA: sha}, nil
 }
+
+// DraftReleaseNotesPrompt creates a prompt to draft release notes from the changes between two tags.
+func DraftReleaseNotesPrompt(t translations.TranslationHelperFunc) (mcp.Prompt, server.PromptHandlerFunc) {
+	return mcp.NewPrompt("DraftReleaseNotes",
+			mcp.WithPromptDescription(t("PROMPT_DRAFT_RELEASE_NOTES_DESCRIPTION", "Draft release notes from the commits and pull requests between two tags.")),
+			mcp.WithArgument("owner", mcp.ArgumentDescription("Repository owner (string)."), mcp.RequiredArgument()),
+			mcp.WithArgument("repo", mcp.ArgumentDescription("Repository name (string)."), mcp.RequiredArgument()),
+			mcp.WithArgument("from_tag", mcp.ArgumentDescription("Tag of the previous release (string)."), mcp.RequiredArgument()),
+			mcp.WithArgument("to_tag", mcp.ArgumentDescription("Tag of the release to draft the notes of (string)."), mcp.RequiredArgument()),
+		), func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			owner, err := requiredPromptArg(request, "owner")
+			if err != nil {
+				return nil, err
+			}
+			repo, err := requiredPromptArg(request, "repo")
+			if err != nil {
+				return nil, err
+			}
+			fromTag, err := requiredPromptArg(request, "from_tag")
+			if err != nil {
+				return nil, err
+			}
+			toTag, err := requiredPromptArg(request, "to_tag")
+			if err != nil {
+				return nil, err
+			}
+			if fromTag == toTag {
+				return nil, fmt.Errorf("arguments from_tag and to_tag must be different tags")
+			}
+
+			return &mcp.GetPromptResult{
+				Description: fmt.Sprintf("Release notes of %s/%s %s", owner, repo, toTag),
+				Messages: promptMessages(
+					fmt.Sprintf("Please draft the release notes of %s in the %s/%s GitHub repository, covering the changes since %s.", toTag, owner, repo, fromTag),
+					fmt.Sprintf("Sure! I will list the commits of %s missing from %s with `compare_refs`, passing base=%s and head=%s, and page through them until I have all of them. For the commits that merged pull requests, I will read the pull requests with `get_pull_request`, or find them with `search_pull_requests` when the commit message does not mention them.", toTag, fromTag, fromTag, toTag),
+					"Great. Group the changes into breaking changes, new features, bug fixes, and other changes, leaving out changes that only touch CI or tests unless they matter to users. Write one line per change in the imperative mood, ending with the pull request number and its author, and list first-time contributors at the end.",
+					"Understood. I will present the draft as Markdown for you to edit, without creating a release or changing the repository.",
+				),
+			}, nil
+		}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -65,6 +67,7 @@ func Test_GetFileContents(t *testing.T) {
 		expectedResult interface{}
 		expectedErrMsg string
 		expectStatus   int
+		maxSize        int64
 	}{
 		{
 			name: "successful text content fetch",
@@ -168,6 +171,107 @@ func Test_GetFileContents(t *testing.T) {
 			expectError:    false,
 			expectedResult: mockDirContent,
 		},
+		{
+			name: "successful line range fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write([]byte("line 1\nline 2\nline 3\nline 4\n"))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "notes.txt",
+				"ref":        "refs/heads/main",
+				"start_line": float64(2),
+				"end_line":   float64(3),
+			},
+			expectError: false,
+			expectedResult: mcp.TextResourceContents{
+				URI:      "repo://owner/repo/refs/heads/main/contents/notes.txt",
+				Text:     "line 2\nline 3\n",
+				MIMEType: "text/plain",
+			},
+		},
+		{
+			name: "line range beyond the end of the file",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/plain")
+						_, _ = w.Write([]byte("line 1\nline 2"))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "notes.txt",
+				"ref":        "refs/heads/main",
+				"start_line": float64(10),
+			},
+			expectError:    false,
+			expectedResult: mcp.NewTextContent("failed to get raw repository content: start line 10 is beyond the end of the file, which has 2 lines"),
+		},
+		{
+			name: "file larger than the max size",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.Header().Set("Content-Type", "text/markdown")
+						_, _ = w.Write(mockRawContent)
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "README.md",
+				"ref":   "refs/heads/main",
+			},
+			maxSize:        10,
+			expectError:    false,
+			expectedResult: mcp.NewTextContent(fmt.Sprintf("content is %d bytes, larger than the limit of 10 bytes, use start_line and end_line to read part of the file", len(mockRawContent))),
+		},
+		{
+			name:         "line range of a directory",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "src/",
+				"start_line": float64(1),
+			},
+			expectError:    false,
+			expectedResult: mcp.NewTextContent("start_line and end_line are only supported for files"),
+		},
 		{
 			name: "content fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -208,7 +312,11 @@ func Test_GetFileContents(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			var rawOpts []raw.ClientOption
+			if tc.maxSize != 0 {
+				rawOpts = append(rawOpts, raw.WithMaxSize(tc.maxSize))
+			}
+			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"}, rawOpts...)
 			_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
 
 			// Create call request
@@ -253,6 +361,75 @@ func Test_GetFileContents(t *testing.T) {
 	}
 }
 
+func Test_GetFileContents_LFS(t *testing.T) {
+	oid := "sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+	object := "id,name\n1,octocat\n"
+	pointer := fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid %s\nsize %d\n", oid, len(object))
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitRefByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"ref": "refs/heads/main", "object": {"sha": ""}}`))
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				_, _ = w.Write([]byte(pointer))
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			raw.PostLFSObjectsBatchByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprintf(w, `{"objects": [{"oid": %q, "size": %d, "actions": {"download": {"href": "https://objects.example.com/objects/1"}}}]}`,
+					strings.TrimPrefix(oid, "sha256:"), len(object))
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.EndpointPattern{Pattern: "/objects/{id}", Method: "GET"},
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(object))
+			}),
+		),
+	)
+	client := github.NewClient(mockedClient)
+	lfsURL := &url.URL{Scheme: "https", Host: "github.example.com", Path: "/"}
+	rawURL := &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"}
+	request := createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"path":  "users.json",
+		"ref":   "refs/heads/main",
+	})
+
+	t.Run("object is returned instead of the pointer", func(t *testing.T) {
+		rawClient := raw.NewClient(client, rawURL, raw.WithLFSURL(lfsURL), raw.WithDownloadClient(mockedClient))
+		_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		assert.Equal(t, "successfully downloaded text file from Git LFS object "+oid, result.Content[0].(mcp.TextContent).Text)
+		assert.Equal(t, mcp.TextResourceContents{
+			URI:      "repo://owner/repo/refs/heads/main/contents/users.json",
+			Text:     object,
+			MIMEType: "application/json",
+		}, getTextResourceResult(t, result))
+	})
+
+	t.Run("too large object is described", func(t *testing.T) {
+		rawClient := raw.NewClient(client, rawURL, raw.WithLFSURL(lfsURL), raw.WithDownloadClient(mockedClient), raw.WithMaxSize(5))
+		_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		errorContent := getErrorResult(t, result)
+		assert.Equal(t, fmt.Sprintf("Git LFS object %s is %d bytes, larger than the limit of 5 bytes, use start_line and end_line to read part of the file", oid, len(object)), errorContent.Text)
+	})
+}
+
 func Test_ForkRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -2251,3 +2428,511 @@ func Test_resolveGitReference(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRepo := &github.Repository{
+		FullName:         github.Ptr("owner/repo"),
+		DefaultBranch:    github.Ptr("main"),
+		Visibility:       github.Ptr("public"),
+		Topics:           []string{"mcp", "github"},
+		License:          &github.License{SPDXID: github.Ptr("MIT")},
+		AllowSquashMerge: github.Ptr(true),
+		Permissions:      map[string]bool{"admin": false, "push": true, "pull": true},
+	}
+	mockLanguages := map[string]int{"Go": 12345, "Shell": 67}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful repository fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, mockRepo),
+				mock.WithRequestMatch(mock.GetReposLanguagesByOwnerByRepo, mockLanguages),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository owner/missing",
+		},
+		{
+			name:         "missing repo",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned struct {
+				FullName         string          `json:"full_name"`
+				DefaultBranch    string          `json:"default_branch"`
+				Visibility       string          `json:"visibility"`
+				Topics           []string        `json:"topics"`
+				AllowSquashMerge bool            `json:"allow_squash_merge"`
+				License          *github.License `json:"license"`
+				Permissions      map[string]bool `json:"permissions"`
+				Languages        map[string]int  `json:"languages"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, "owner/repo", returned.FullName)
+			assert.Equal(t, "main", returned.DefaultBranch)
+			assert.Equal(t, "public", returned.Visibility)
+			assert.Equal(t, []string{"mcp", "github"}, returned.Topics)
+			assert.True(t, returned.AllowSquashMerge)
+			assert.Equal(t, "MIT", returned.License.GetSPDXID())
+			assert.Equal(t, mockRepo.Permissions, returned.Permissions)
+			assert.Equal(t, mockLanguages, returned.Languages)
+		})
+	}
+}
+
+func Test_ListRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "direction")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockRepos := []*github.Repository{
+		{FullName: github.Ptr("octo-org/api")},
+		{FullName: github.Ptr("octo-org/web")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "repositories of an organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUsersByUsername, &github.User{Login: github.Ptr("octo-org"), Type: github.Ptr("Organization")}),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsReposByOrg,
+					expectQueryParams(t, map[string]string{
+						"type":      "sources",
+						"sort":      "pushed",
+						"direction": "desc",
+						"page":      "1",
+						"per_page":  "30",
+					}).andThen(mockResponse(t, http.StatusOK, mockRepos)),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "octo-org",
+				"type":      "sources",
+				"sort":      "pushed",
+				"direction": "desc",
+			},
+		},
+		{
+			name: "repositories of a user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUsersByUsername, &github.User{Login: github.Ptr("octocat"), Type: github.Ptr("User")}),
+				mock.WithRequestMatchHandler(
+					mock.GetUsersReposByUsername,
+					expectQueryParams(t, map[string]string{
+						"type":     "owner",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(mockResponse(t, http.StatusOK, mockRepos)),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "octocat",
+				"type":    "owner",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+		},
+		{
+			name: "repositories of the authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserRepos,
+					expectQueryParams(t, map[string]string{
+						"type":     "private",
+						"sort":     "full_name",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(mockResponse(t, http.StatusOK, mockRepos)),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"type": "private",
+				"sort": "full_name",
+			},
+		},
+		{
+			name: "type not supported for users",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetUsersByUsername, &github.User{Login: github.Ptr("octocat"), Type: github.Ptr("User")}),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "octocat",
+				"type":  "forks",
+			},
+			expectError:    true,
+			expectedErrMsg: "type forks is not supported for the repositories of user octocat, use one of: all, owner, member",
+		},
+		{
+			name:         "type not supported for the authenticated user",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"type": "sources",
+			},
+			expectError:    true,
+			expectedErrMsg: "type sources is not supported for the repositories of the authenticated user",
+		},
+		{
+			name: "owner not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "nobody",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get owner nobody",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned []*github.Repository
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			require.Len(t, returned, len(mockRepos))
+			for i, repo := range returned {
+				assert.Equal(t, mockRepos[i].GetFullName(), repo.GetFullName())
+			}
+		})
+	}
+}
+
+func Test_CompareRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "compare_refs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.Contains(t, tool.InputSchema.Properties, "include_diff")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	date := github.Timestamp{Time: time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)}
+	mockComparison := &github.CommitsComparison{
+		Status:          github.Ptr("diverged"),
+		AheadBy:         github.Ptr(2),
+		BehindBy:        github.Ptr(1),
+		TotalCommits:    github.Ptr(2),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("base123")},
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/compare/v1.2...main"),
+		Commits: []*github.RepositoryCommit{
+			{
+				SHA:    github.Ptr("abc123"),
+				Author: &github.User{Login: github.Ptr("octocat")},
+				Commit: &github.Commit{Message: github.Ptr("Add feature"), Author: &github.CommitAuthor{Name: github.Ptr("The Octocat"), Date: &date}},
+			},
+			{
+				SHA:    github.Ptr("def456"),
+				Commit: &github.Commit{Message: github.Ptr("Fix bug"), Author: &github.CommitAuthor{Name: github.Ptr("Someone Else")}},
+			},
+		},
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(10), Deletions: github.Ptr(2), Changes: github.Ptr(12), Patch: github.Ptr("@@ -1 +1 @@")},
+			{Filename: github.Ptr("new.go"), PreviousFilename: github.Ptr("old.go"), Status: github.Ptr("renamed"), Additions: github.Ptr(1), Deletions: github.Ptr(1), Changes: github.Ptr(2)},
+		},
+	}
+	manyFiles := make([]*github.CommitFile, maxCompareFiles)
+	for i := range manyFiles {
+		manyFiles[i] = &github.CommitFile{Filename: github.Ptr(fmt.Sprintf("file%d.go", i)), Status: github.Ptr("added"), Additions: github.Ptr(1)}
+	}
+
+	// manyFilesComparison answers with the files of the comparison, or with its diff of diffFiles files or a 406 if
+	// diffFiles is 0, like GitHub does when a diff has too many files
+	manyFilesComparison := func(diffFiles int) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.GetReposCompareByOwnerByRepoByBasehead,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.Contains(r.Header.Get("Accept"), "diff") {
+					mockResponse(t, http.StatusOK, &github.CommitsComparison{Status: github.Ptr("ahead"), Files: manyFiles})(w, r)
+					return
+				}
+				if diffFiles == 0 {
+					mockResponse(t, http.StatusNotAcceptable, `{"message": "Sorry, the diff exceeded the maximum number of files (300)."}`)(w, r)
+					return
+				}
+				var diff strings.Builder
+				for i := range diffFiles {
+					fmt.Fprintf(&diff, "diff --git a/file%d.go b/file%d.go\n+line\n", i, i)
+				}
+				_, _ = w.Write([]byte(diff.String()))
+			}),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedResult compareResult
+		// expectTruncated checks that the files are truncated rather than comparing the whole result
+		expectTruncated   bool
+		expectedTotal     int
+		expectedTruncated bool
+	}{
+		{
+			name: "compare without diff",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectQueryParams(t, map[string]string{"page": "1", "per_page": "30"}).andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.2",
+				"head":  "main",
+			},
+			expectedResult: compareResult{
+				Status:       "diverged",
+				AheadBy:      2,
+				BehindBy:     1,
+				TotalCommits: 2,
+				MergeBaseSHA: "base123",
+				HTMLURL:      "https://github.com/owner/repo/compare/v1.2...main",
+				Commits: []compareCommit{
+					{SHA: "abc123", Message: "Add feature", Author: "octocat", Date: "2025-03-04T05:06:07Z"},
+					{SHA: "def456", Message: "Fix bug", Author: "Someone Else"},
+				},
+				Files: []compareFile{
+					{Filename: "main.go", Status: "modified", Additions: 10, Deletions: 2, Changes: 12},
+					{Filename: "new.go", PreviousFilename: "old.go", Status: "renamed", Additions: 1, Deletions: 1, Changes: 2},
+				},
+				TotalFiles: 2,
+				Additions:  11,
+				Deletions:  3,
+			},
+		},
+		{
+			name: "compare with diff",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCompareByOwnerByRepoByBasehead, &github.CommitsComparison{
+					Status: github.Ptr("ahead"),
+					Files:  mockComparison.Files[:1],
+				}),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"base":         "v1.2",
+				"head":         "main",
+				"include_diff": true,
+			},
+			expectedResult: compareResult{
+				Status:     "ahead",
+				Commits:    []compareCommit{},
+				Files:      []compareFile{{Filename: "main.go", Status: "modified", Additions: 10, Deletions: 2, Changes: 12, Patch: "@@ -1 +1 @@"}},
+				TotalFiles: 1,
+				Additions:  10,
+				Deletions:  2,
+			},
+		},
+		{
+			name:         "as many files as listed",
+			mockedClient: mock.NewMockedHTTPClient(manyFilesComparison(maxCompareFiles)),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.2",
+				"head":  "main",
+			},
+			expectTruncated:   true,
+			expectedTotal:     maxCompareFiles,
+			expectedTruncated: false,
+		},
+		{
+			name:         "more files than listed",
+			mockedClient: mock.NewMockedHTTPClient(manyFilesComparison(maxCompareFiles + 20)),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.2",
+				"head":  "main",
+			},
+			expectTruncated:   true,
+			expectedTotal:     maxCompareFiles + 20,
+			expectedTruncated: true,
+		},
+		{
+			name:         "too many files to diff",
+			mockedClient: mock.NewMockedHTTPClient(manyFilesComparison(0)),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.2",
+				"head":  "main",
+			},
+			expectTruncated:   true,
+			expectedTotal:     0,
+			expectedTruncated: true,
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v9.9",
+				"head":  "main",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare v9.9...main",
+		},
+		{
+			name:         "missing head",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "v1.2",
+			},
+			expectError:    true,
+			expectedErrMsg: "missing required parameter: head",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareRefs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var returned compareResult
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			if tc.expectTruncated {
+				assert.Len(t, returned.Files, maxCompareFiles)
+				assert.Equal(t, tc.expectedTotal, returned.TotalFiles)
+				assert.Equal(t, tc.expectedTruncated, returned.FilesTruncated)
+				return
+			}
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
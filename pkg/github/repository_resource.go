@@ -3,11 +3,12 @@ package github
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"mime"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -90,26 +91,32 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 
 		opts := &github.RepositoryContentGetOptions{}
 		rawOpts := &raw.ContentOpts{}
+		// uriPrefix is the URI of the root directory in the template of the request, used for the URIs of directory entries
+		uriPrefix := "repo://" + owner + "/" + repo + "/contents"
 
 		sha, ok := request.Params.Arguments["sha"].([]string)
 		if ok && len(sha) > 0 {
 			opts.Ref = sha[0]
 			rawOpts.SHA = sha[0]
+			uriPrefix = "repo://" + owner + "/" + repo + "/sha/" + sha[0] + "/contents"
 		}
 
 		branch, ok := request.Params.Arguments["branch"].([]string)
 		if ok && len(branch) > 0 {
 			opts.Ref = "refs/heads/" + branch[0]
 			rawOpts.Ref = "refs/heads/" + branch[0]
+			uriPrefix = "repo://" + owner + "/" + repo + "/refs/heads/" + branch[0] + "/contents"
 		}
 
 		tag, ok := request.Params.Arguments["tag"].([]string)
 		if ok && len(tag) > 0 {
 			opts.Ref = "refs/tags/" + tag[0]
 			rawOpts.Ref = "refs/tags/" + tag[0]
+			uriPrefix = "repo://" + owner + "/" + repo + "/refs/tags/" + tag[0] + "/contents"
 		}
 		prNumber, ok := request.Params.Arguments["prNumber"].([]string)
 		if ok && len(prNumber) > 0 {
+			uriPrefix = "repo://" + owner + "/" + repo + "/refs/pull/" + prNumber[0] + "/head/contents"
 			// fetch the PR from the API to get the latest commit and use SHA
 			githubClient, err := getClient(ctx)
 			if err != nil {
@@ -129,7 +136,7 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 		}
 		//  if it's a directory
 		if path == "" || strings.HasSuffix(path, "/") {
-			return nil, fmt.Errorf("directories are not supported: %s", path)
+			return repositoryDirectoryContents(ctx, getClient, owner, repo, path, opts, uriPrefix, request.Params.URI)
 		}
 		rawClient, err := getRawClient(ctx)
 
@@ -137,56 +144,107 @@ func RepositoryResourceContentsHandler(getClient GetClientFn, getRawClient raw.G
 			return nil, fmt.Errorf("failed to get GitHub raw content client: %w", err)
 		}
 
-		resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
-		defer func() {
-			_ = resp.Body.Close()
-		}()
-		// If the raw content is not found, we will fall back to the GitHub API (in case it is a directory)
+		content, err := rawClient.ReadRawContent(ctx, owner, repo, path, rawOpts)
+		var statusErr *raw.StatusError
 		switch {
+		case raw.IsNotFound(err):
+			// The path may be a directory without a trailing slash
+			return repositoryDirectoryContents(ctx, getClient, owner, repo, path, opts, uriPrefix, request.Params.URI)
+		case errors.As(err, &statusErr):
+			// If we got a response but it is not 200 OK, we return an error
+			return nil, fmt.Errorf("failed to fetch raw content: %s", statusErr.Body)
 		case err != nil:
 			return nil, fmt.Errorf("failed to get raw content: %w", err)
-		case resp.StatusCode == http.StatusOK:
-			ext := filepath.Ext(path)
-			mimeType := resp.Header.Get("Content-Type")
-			if ext == ".md" {
-				mimeType = "text/markdown"
-			} else if mimeType == "" {
-				mimeType = mime.TypeByExtension(ext)
-			}
+		}
 
-			content, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read file content: %w", err)
-			}
+		ext := filepath.Ext(path)
+		mimeType := content.ContentType
+		if ext == ".md" {
+			mimeType = "text/markdown"
+		} else if mimeType == "" {
+			mimeType = mime.TypeByExtension(ext)
+		}
 
-			switch {
-			case strings.HasPrefix(mimeType, "text"), strings.HasPrefix(mimeType, "application"):
-				return []mcp.ResourceContents{
-					mcp.TextResourceContents{
-						URI:      request.Params.URI,
-						MIMEType: mimeType,
-						Text:     string(content),
-					},
-				}, nil
-			default:
-				return []mcp.ResourceContents{
-					mcp.BlobResourceContents{
-						URI:      request.Params.URI,
-						MIMEType: mimeType,
-						Blob:     base64.StdEncoding.EncodeToString(content),
-					},
-				}, nil
-			}
-		case resp.StatusCode != http.StatusNotFound:
-			// If we got a response but it is not 200 OK, we return an error
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read response body: %w", err)
-			}
-			return nil, fmt.Errorf("failed to fetch raw content: %s", string(body))
+		switch {
+		case strings.HasPrefix(mimeType, "text"), strings.HasPrefix(mimeType, "application"):
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: mimeType,
+					Text:     string(content.Data),
+				},
+			}, nil
 		default:
-			// This should be unreachable because GetContents should return an error if neither file nor directory content is found.
-			return nil, errors.New("404 Not Found")
+			return []mcp.ResourceContents{
+				mcp.BlobResourceContents{
+					URI:      request.Params.URI,
+					MIMEType: mimeType,
+					Blob:     base64.StdEncoding.EncodeToString(content.Data),
+				},
+			}, nil
+		}
+	}
+}
+
+// repositoryDirectoryEntry is an entry of a directory listing resource
+type repositoryDirectoryEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int    `json:"size"`
+	SHA  string `json:"sha"`
+	// URI is the repo:// URI of the entry in the same template as the listing, directories end with a slash
+	URI string `json:"uri"`
+}
+
+// repositoryDirectoryContents returns a JSON listing of the directory at path as a resource
+func repositoryDirectoryContents(ctx context.Context, getClient GetClientFn, owner, repo, path string, opts *github.RepositoryContentGetOptions, uriPrefix, uri string) ([]mcp.ResourceContents, error) {
+	githubClient, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	fileContent, dirContent, resp, err := githubClient.Repositories.GetContents(ctx, owner, repo, strings.TrimSuffix(path, "/"), opts)
+	if resp != nil {
+		defer func() { _ = resp.Body.Close() }()
+	}
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+		return nil, errors.New("404 Not Found")
+	case err != nil:
+		return nil, fmt.Errorf("failed to get directory contents: %w", err)
+	case fileContent != nil:
+		return nil, fmt.Errorf("not a directory: %s", path)
+	}
+
+	entries := make([]repositoryDirectoryEntry, 0, len(dirContent))
+	for _, content := range dirContent {
+		entryURI, err := url.JoinPath(uriPrefix, content.GetPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create resource URI: %w", err)
 		}
+		if content.GetType() == "dir" {
+			entryURI += "/"
+		}
+		entries = append(entries, repositoryDirectoryEntry{
+			Name: content.GetName(),
+			Path: content.GetPath(),
+			Type: content.GetType(),
+			Size: content.GetSize(),
+			SHA:  content.GetSHA(),
+			URI:  entryURI,
+		})
+	}
+
+	listing, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal directory listing: %w", err)
 	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(listing),
+		},
+	}, nil
 }
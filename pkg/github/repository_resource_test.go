@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -207,6 +209,25 @@ func Test_repositoryResourceContentsHandler(t *testing.T) {
 				URI:      "",
 			}},
 		},
+		{
+			name: "raw content fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoByBranchByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte("server error"))
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":  []string{"owner"},
+				"repo":   []string{"repo"},
+				"path":   []string{"README.md"},
+				"branch": []string{"main"},
+			},
+			expectError: "failed to fetch raw content: server error",
+		},
 		{
 			name: "content fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -278,3 +299,154 @@ func Test_GetRepositoryResourceTagContent(t *testing.T) {
 	tmpl, _ := GetRepositoryResourceTagContent(nil, stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
 	require.Equal(t, "repo://{owner}/{repo}/refs/tags/{tag}/contents{/path*}", tmpl.URITemplate.Raw())
 }
+
+func Test_repositoryResourceContentsHandler_Directories(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+	dirContent := []*github.RepositoryContent{
+		{
+			Type: github.Ptr("file"),
+			Name: github.Ptr("main.go"),
+			Path: github.Ptr("src/main.go"),
+			SHA:  github.Ptr("abc123"),
+			Size: github.Ptr(42),
+		},
+		{
+			Type: github.Ptr("dir"),
+			Name: github.Ptr("util"),
+			Path: github.Ptr("src/util"),
+			SHA:  github.Ptr("def456"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		requestArgs map[string]any
+		expectedRef string
+		uriPrefix   string
+	}{
+		{
+			name: "default branch",
+			requestArgs: map[string]any{
+				"path": []string{"src", ""},
+			},
+			uriPrefix: "repo://owner/repo/contents",
+		},
+		{
+			name: "branch",
+			requestArgs: map[string]any{
+				"path":   []string{"src", ""},
+				"branch": []string{"main"},
+			},
+			expectedRef: "refs/heads/main",
+			uriPrefix:   "repo://owner/repo/refs/heads/main/contents",
+		},
+		{
+			name: "commit",
+			requestArgs: map[string]any{
+				"path": []string{"src", ""},
+				"sha":  []string{"0123abc"},
+			},
+			expectedRef: "0123abc",
+			uriPrefix:   "repo://owner/repo/sha/0123abc/contents",
+		},
+		{
+			name: "tag",
+			requestArgs: map[string]any{
+				"path": []string{"src", ""},
+				"tag":  []string{"v1.0.0"},
+			},
+			expectedRef: "refs/tags/v1.0.0",
+			uriPrefix:   "repo://owner/repo/refs/tags/v1.0.0/contents",
+		},
+		{
+			name: "pull request",
+			requestArgs: map[string]any{
+				"path":     []string{"src", ""},
+				"prNumber": []string{"42"},
+			},
+			expectedRef: "headsha",
+			uriPrefix:   "repo://owner/repo/refs/pull/42/head/contents",
+		},
+		{
+			name: "directory without trailing slash",
+			requestArgs: map[string]any{
+				"path":   []string{"src"},
+				"branch": []string{"main"},
+			},
+			expectedRef: "refs/heads/main",
+			uriPrefix:   "repo://owner/repo/refs/heads/main/contents",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			queryParams := map[string]string{}
+			if tc.expectedRef != "" {
+				queryParams["ref"] = tc.expectedRef
+			}
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectPath(t, "/repos/owner/repo/contents/src").andThen(
+						expectQueryParams(t, queryParams).andThen(
+							mockResponse(t, http.StatusOK, dirContent),
+						),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusOK, &github.PullRequest{Head: &github.PullRequestBranch{SHA: github.Ptr("headsha")}}),
+				),
+			)
+			client := github.NewClient(mockedClient)
+			handler := RepositoryResourceContentsHandler(stubGetClientFn(client), stubGetRawClientFn(raw.NewClient(client, base)))
+
+			args := map[string]any{"owner": []string{"owner"}, "repo": []string{"repo"}}
+			for k, v := range tc.requestArgs {
+				args[k] = v
+			}
+			request := mcp.ReadResourceRequest{}
+			request.Params.URI = "repo://listing"
+			request.Params.Arguments = args
+
+			resp, err := handler(context.Background(), request)
+			require.NoError(t, err)
+			require.Len(t, resp, 1)
+
+			listing, ok := resp[0].(mcp.TextResourceContents)
+			require.True(t, ok)
+			assert.Equal(t, "repo://listing", listing.URI)
+			assert.Equal(t, "application/json", listing.MIMEType)
+
+			var entries []repositoryDirectoryEntry
+			require.NoError(t, json.Unmarshal([]byte(listing.Text), &entries))
+			assert.Equal(t, []repositoryDirectoryEntry{
+				{Name: "main.go", Path: "src/main.go", Type: "file", Size: 42, SHA: "abc123", URI: tc.uriPrefix + "/src/main.go"},
+				{Name: "util", Path: "src/util", Type: "dir", SHA: "def456", URI: tc.uriPrefix + "/src/util/"},
+			}, entries)
+		})
+	}
+}
+
+func Test_repositoryResourceContentsHandler_RootDirectory(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposContentsByOwnerByRepoByPath,
+			mockResponse(t, http.StatusOK, []*github.RepositoryContent{
+				{Type: github.Ptr("file"), Name: github.Ptr("README.md"), Path: github.Ptr("README.md"), SHA: github.Ptr("abc123"), Size: github.Ptr(7)},
+			}),
+		),
+	)
+	client := github.NewClient(mockedClient)
+	handler := RepositoryResourceContentsHandler(stubGetClientFn(client), stubGetRawClientFn(raw.NewClient(client, base)))
+
+	request := mcp.ReadResourceRequest{}
+	request.Params.Arguments = map[string]any{"owner": []string{"owner"}, "repo": []string{"repo"}}
+
+	resp, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.JSONEq(t, `[{"name": "README.md", "path": "README.md", "type": "file", "size": 7, "sha": "abc123", "uri": "repo://owner/repo/contents/README.md"}]`,
+		resp[0].(mcp.TextResourceContents).Text)
+}
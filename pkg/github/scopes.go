@@ -0,0 +1,49 @@
+package github
+
+// toolsetScopes lists the classic personal access token scopes the tools of a toolset need to work
+// with private resources. Public resources can often be read with fewer scopes.
+var toolsetScopes = map[string][]string{
+	"context":           {},
+	"repos":             {"repo"},
+	"issues":            {"repo"},
+	"users":             {},
+	"orgs":              {},
+	"pull_requests":     {"repo"},
+	"releases":          {"repo"},
+	"actions":           {"repo"},
+	"code_security":     {"security_events"},
+	"secret_protection": {"security_events"},
+	"dependabot":        {"security_events"},
+	"notifications":     {"notifications"},
+	"experiments":       {},
+	"discussions":       {"repo"},
+	"dynamic":           {},
+}
+
+// toolScopes overrides the scopes of the tools needing other scopes than the rest of their toolset. Scopes only
+// grant access to what the user can do: managing collaborators still requires the admin role on the repository.
+var toolScopes = map[string][]string{
+	"list_collaborators":    {"repo", "read:org"},
+	"list_repository_teams": {"repo", "read:org"},
+	"set_team_permission":   {"repo", "admin:org"},
+}
+
+// RequiredScopes returns the classic personal access token scopes needed by the tools of a toolset,
+// and false if the toolset is unknown.
+func RequiredScopes(toolset string) ([]string, bool) {
+	scopes, ok := toolsetScopes[toolset]
+	return scopes, ok
+}
+
+// RequiredToolScopes returns the classic personal access token scopes needed by a tool of a toolset,
+// and false if the toolset is unknown.
+func RequiredToolScopes(toolset, tool string) ([]string, bool) {
+	scopes, ok := RequiredScopes(toolset)
+	if !ok {
+		return nil, false
+	}
+	if override, ok := toolScopes[tool]; ok {
+		return override, true
+	}
+	return scopes, true
+}
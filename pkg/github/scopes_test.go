@@ -0,0 +1,56 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/workspace"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RequiredScopes(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), workspace.NewManager(workspace.DefaultLimits), translations.NullTranslationHelper)
+	dynamic := InitDynamicToolset(server.NewMCPServer("test", "0.0.1"), tsg, translations.NullTranslationHelper)
+
+	// Every toolset must declare its scopes, even if it needs none
+	for name := range tsg.Toolsets {
+		_, ok := RequiredScopes(name)
+		assert.True(t, ok, "missing scopes for toolset %s", name)
+	}
+	_, ok := RequiredScopes(dynamic.Name)
+	assert.True(t, ok)
+
+	scopes, ok := RequiredScopes("repos")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"repo"}, scopes)
+
+	_, ok = RequiredScopes("nope")
+	assert.False(t, ok)
+}
+
+func Test_RequiredToolScopes(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), stubGetGQLClientFn(nil), stubGetRawClientFn(nil), workspace.NewManager(workspace.DefaultLimits), translations.NullTranslationHelper)
+
+	// Overrides must name existing tools
+	tools := map[string]bool{}
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			tools[tool.Tool.Name] = true
+		}
+	}
+	for name := range toolScopes {
+		assert.True(t, tools[name], "scopes declared for unknown tool %s", name)
+	}
+
+	scopes, ok := RequiredToolScopes("repos", "get_file_contents")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"repo"}, scopes)
+
+	scopes, ok = RequiredToolScopes("repos", "set_team_permission")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"repo", "admin:org"}, scopes)
+
+	_, ok = RequiredToolScopes("nope", "get_file_contents")
+	assert.False(t, ok)
+}
@@ -0,0 +1,281 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultResourcePollInterval is how often the refs of subscribed resources are polled by default.
+const DefaultResourcePollInterval = time.Minute
+
+// NotifyFunc sends a notifications/resources/updated notification for uri to a session.
+type NotifyFunc func(sessionID, uri string) error
+
+// resourceRef is the ref a repo:// resource is read at
+type resourceRef struct {
+	owner, repo string
+	// ref is the commit reference of the commits API, such as HEAD, heads/main or pull/1/head
+	ref string
+}
+
+// refWatch is a polled ref and the subscriptions to resources read at it
+type refWatch struct {
+	sha string
+	// uris maps the subscribed URIs to the IDs of the subscribed sessions
+	uris map[string]map[string]bool
+}
+
+// ResourceSubscriptions tracks the subscriptions of sessions to repo:// resources. It polls the refs the
+// resources are read at with conditional requests, which do not count against the rate limit when
+// nothing changed, and notifies the subscribed sessions when a ref points at another commit.
+// Resources pinned to a commit SHA never change, so subscribing to them never results in notifications.
+// ResourceSubscriptions is safe for concurrent use.
+type ResourceSubscriptions struct {
+	getClient GetClientFn
+	notify    NotifyFunc
+	interval  time.Duration
+
+	mu      sync.Mutex
+	watches map[resourceRef]*refWatch
+}
+
+// NewResourceSubscriptions creates subscriptions polling every interval once Run is called.
+func NewResourceSubscriptions(getClient GetClientFn, notify NotifyFunc, interval time.Duration) *ResourceSubscriptions {
+	return &ResourceSubscriptions{
+		getClient: getClient,
+		notify:    notify,
+		interval:  interval,
+		watches:   map[resourceRef]*refWatch{},
+	}
+}
+
+// parseResourceRef returns the ref a repo:// resource URI is read at, or false for URIs pinned to a commit SHA
+func parseResourceRef(uri string) (resourceRef, bool, error) {
+	rest, ok := strings.CutPrefix(uri, "repo://")
+	if !ok {
+		return resourceRef{}, false, &invalidParamsError{message: fmt.Sprintf("unsupported resource URI %s, only repo:// resources can be subscribed to", uri)}
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" {
+		return resourceRef{}, false, &invalidParamsError{message: fmt.Sprintf("invalid repository resource URI: %s", uri)}
+	}
+	ref := resourceRef{owner: parts[0], repo: parts[1]}
+
+	parts = parts[2:]
+	switch {
+	case parts[0] == "contents":
+		ref.ref = "HEAD"
+	case len(parts) >= 3 && parts[0] == "sha" && parts[2] == "contents":
+		return ref, false, nil
+	case len(parts) >= 4 && parts[0] == "refs" && (parts[1] == "heads" || parts[1] == "tags") && slices.Contains(parts[3:], "contents"):
+		// Branch and tag names may contain slashes, escaped as %2F by the resource templates or left as they are
+		end := 3 + slices.Index(parts[3:], "contents")
+		name, err := url.PathUnescape(strings.Join(parts[2:end], "/"))
+		if err != nil || name == "" {
+			return resourceRef{}, false, &invalidParamsError{message: fmt.Sprintf("invalid repository resource URI: %s", uri)}
+		}
+		ref.ref = parts[1] + "/" + name
+	case len(parts) >= 5 && parts[0] == "refs" && parts[1] == "pull" && parts[3] == "head" && parts[4] == "contents":
+		ref.ref = "pull/" + parts[2] + "/head"
+	default:
+		return resourceRef{}, false, &invalidParamsError{message: fmt.Sprintf("invalid repository resource URI: %s", uri)}
+	}
+	return ref, true, nil
+}
+
+// Subscribe subscribes a session to a resource. The commit the resource is read at is fetched right away,
+// so that only later changes are notified.
+func (s *ResourceSubscriptions) Subscribe(ctx context.Context, sessionID, uri string) error {
+	ref, polled, err := parseResourceRef(uri)
+	if err != nil || !polled {
+		return err
+	}
+
+	s.mu.Lock()
+	_, ok := s.watches[ref]
+	s.mu.Unlock()
+
+	var sha string
+	if !ok {
+		client, err := s.getClient(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		var resp *github.Response
+		sha, resp, err = client.Repositories.GetCommitSHA1(ctx, ref.owner, ref.repo, ref.ref, "")
+		if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity) {
+			return &invalidParamsError{message: fmt.Sprintf("resource %s not found", uri)}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get commit of %s: %w", uri, err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The watch may have been created or removed by other subscriptions in the meantime, a watch without
+	// a SHA gets one on the next poll
+	watch, ok := s.watches[ref]
+	if !ok {
+		watch = &refWatch{sha: sha, uris: map[string]map[string]bool{}}
+		s.watches[ref] = watch
+	}
+	if watch.uris[uri] == nil {
+		watch.uris[uri] = map[string]bool{}
+	}
+	watch.uris[uri][sessionID] = true
+	return nil
+}
+
+// Unsubscribe removes the subscription of a session to a resource.
+func (s *ResourceSubscriptions) Unsubscribe(sessionID, uri string) error {
+	ref, polled, err := parseResourceRef(uri)
+	if err != nil || !polled {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if watch, ok := s.watches[ref]; ok {
+		delete(watch.uris[uri], sessionID)
+		s.cleanup(ref, watch)
+	}
+	return nil
+}
+
+// RemoveSession removes every subscription of a session.
+func (s *ResourceSubscriptions) RemoveSession(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ref, watch := range s.watches {
+		for _, sessions := range watch.uris {
+			delete(sessions, sessionID)
+		}
+		s.cleanup(ref, watch)
+	}
+}
+
+// cleanup removes the URIs without sessions and watches without URIs, s.mu must be held
+func (s *ResourceSubscriptions) cleanup(ref resourceRef, watch *refWatch) {
+	for uri, sessions := range watch.uris {
+		if len(sessions) == 0 {
+			delete(watch.uris, uri)
+		}
+	}
+	if len(watch.uris) == 0 {
+		delete(s.watches, ref)
+	}
+}
+
+// Run polls the subscribed refs every interval until ctx is done.
+func (s *ResourceSubscriptions) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Poll(ctx)
+		}
+	}
+}
+
+// Poll checks every subscribed ref once and notifies the subscribed sessions of the refs that changed.
+// Refs that fail to be checked are checked again on the next poll.
+func (s *ResourceSubscriptions) Poll(ctx context.Context) {
+	s.mu.Lock()
+	shas := make(map[resourceRef]string, len(s.watches))
+	for ref, watch := range s.watches {
+		shas[ref] = watch.sha
+	}
+	s.mu.Unlock()
+	if len(shas) == 0 {
+		return
+	}
+
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return
+	}
+
+	type notification struct{ sessionID, uri string }
+	var notifications []notification
+	for ref, lastSHA := range shas {
+		sha, resp, err := client.Repositories.GetCommitSHA1(ctx, ref.owner, ref.repo, ref.ref, lastSHA)
+		if resp != nil && resp.StatusCode == http.StatusNotModified {
+			continue
+		}
+		if err != nil || sha == "" || sha == lastSHA {
+			continue
+		}
+
+		s.mu.Lock()
+		// Watches without a SHA only get their first one
+		if watch, ok := s.watches[ref]; ok && watch.sha != "" {
+			for uri, sessions := range watch.uris {
+				for sessionID := range sessions {
+					notifications = append(notifications, notification{sessionID: sessionID, uri: uri})
+				}
+			}
+		}
+		if watch, ok := s.watches[ref]; ok {
+			watch.sha = sha
+		}
+		s.mu.Unlock()
+	}
+
+	for _, n := range notifications {
+		if err := s.notify(n.sessionID, n.uri); errors.Is(err, server.ErrSessionNotFound) {
+			s.RemoveSession(n.sessionID)
+		}
+	}
+}
+
+// HandleMessage answers resources/subscribe and resources/unsubscribe requests of a session, which the
+// MCP server does not handle itself. It returns false for any other message, which is left to the server.
+func (s *ResourceSubscriptions) HandleMessage(ctx context.Context, sessionID string, message json.RawMessage) (mcp.JSONRPCMessage, bool) {
+	var request struct {
+		ID     any           `json:"id"`
+		Method mcp.MCPMethod `json:"method"`
+		Params struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil {
+		return nil, false
+	}
+
+	var err error
+	switch request.Method {
+	case "resources/subscribe":
+		if request.Params.URI == "" {
+			return mcp.NewJSONRPCError(mcp.NewRequestId(request.ID), mcp.INVALID_PARAMS, "uri is required", nil), true
+		}
+		err = s.Subscribe(ctx, sessionID, request.Params.URI)
+	case "resources/unsubscribe":
+		if request.Params.URI == "" {
+			return mcp.NewJSONRPCError(mcp.NewRequestId(request.ID), mcp.INVALID_PARAMS, "uri is required", nil), true
+		}
+		err = s.Unsubscribe(sessionID, request.Params.URI)
+	default:
+		return nil, false
+	}
+
+	if err != nil {
+		return jsonRPCError(mcp.NewRequestId(request.ID), err), true
+	}
+	return mcp.NewJSONRPCResponse(mcp.NewRequestId(request.ID), mcp.Result{}), true
+}
@@ -0,0 +1,327 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseResourceRef(t *testing.T) {
+	tests := []struct {
+		name           string
+		uri            string
+		expectedRef    resourceRef
+		expectedPolled bool
+		expectedErrMsg string
+	}{
+		{
+			name:           "default branch",
+			uri:            "repo://owner/repo/contents/README.md",
+			expectedRef:    resourceRef{owner: "owner", repo: "repo", ref: "HEAD"},
+			expectedPolled: true,
+		},
+		{
+			name:           "branch directory",
+			uri:            "repo://owner/repo/refs/heads/main/contents/src/",
+			expectedRef:    resourceRef{owner: "owner", repo: "repo", ref: "heads/main"},
+			expectedPolled: true,
+		},
+		{
+			name:           "branch with an escaped slash",
+			uri:            "repo://owner/repo/refs/heads/feature%2Fx/contents/README.md",
+			expectedRef:    resourceRef{owner: "owner", repo: "repo", ref: "heads/feature/x"},
+			expectedPolled: true,
+		},
+		{
+			name:           "branch with a slash",
+			uri:            "repo://owner/repo/refs/heads/feature/x/contents/README.md",
+			expectedRef:    resourceRef{owner: "owner", repo: "repo", ref: "heads/feature/x"},
+			expectedPolled: true,
+		},
+		{
+			name:           "branch with an invalid escape",
+			uri:            "repo://owner/repo/refs/heads/feature%zz/contents/README.md",
+			expectedErrMsg: "invalid repository resource URI: repo://owner/repo/refs/heads/feature%zz/contents/README.md",
+		},
+		{
+			name:           "tag",
+			uri:            "repo://owner/repo/refs/tags/v1.0.0/contents/go.mod",
+			expectedRef:    resourceRef{owner: "owner", repo: "repo", ref: "tags/v1.0.0"},
+			expectedPolled: true,
+		},
+		{
+			name:           "pull request head",
+			uri:            "repo://owner/repo/refs/pull/42/head/contents/main.go",
+			expectedRef:    resourceRef{owner: "owner", repo: "repo", ref: "pull/42/head"},
+			expectedPolled: true,
+		},
+		{
+			name:        "commit",
+			uri:         "repo://owner/repo/sha/abc123/contents/main.go",
+			expectedRef: resourceRef{owner: "owner", repo: "repo"},
+		},
+		{
+			name:           "other scheme",
+			uri:            "issue://owner/repo/1",
+			expectedErrMsg: "unsupported resource URI issue://owner/repo/1, only repo:// resources can be subscribed to",
+		},
+		{
+			name:           "unknown template",
+			uri:            "repo://owner/repo/refs/remotes/origin/contents/main.go",
+			expectedErrMsg: "invalid repository resource URI: repo://owner/repo/refs/remotes/origin/contents/main.go",
+		},
+		{
+			name:           "missing repo",
+			uri:            "repo://owner",
+			expectedErrMsg: "invalid repository resource URI: repo://owner",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, polled, err := parseResourceRef(tc.uri)
+			if tc.expectedErrMsg != "" {
+				assert.EqualError(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRef, ref)
+			assert.Equal(t, tc.expectedPolled, polled)
+		})
+	}
+}
+
+// commitsServer serves the commit SHAs of refs, answering conditional requests for unchanged refs with 304
+type commitsServer struct {
+	mu       sync.Mutex
+	shas     map[string]string
+	requests int
+}
+
+func (c *commitsServer) setSHA(ref, sha string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shas[ref] = sha
+}
+
+func (c *commitsServer) client() *github.Client {
+	return github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.EndpointPattern{Pattern: "/repos/{owner}/{repo}/commits/{ref:.*}", Method: "GET"},
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+				c.requests++
+
+				sha, ok := c.shas[r.URL.Path]
+				if sha == "error" {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, _ = w.Write([]byte(`{"message": "Server Error"}`))
+					return
+				}
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				if r.Header.Get("If-None-Match") == `"`+sha+`"` {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				_, _ = w.Write([]byte(sha))
+			}),
+		),
+	))
+}
+
+// recordedNotifications records the notifications sent by subscriptions
+type recordedNotifications struct {
+	mu            sync.Mutex
+	notifications []string
+}
+
+func (r *recordedNotifications) notify(sessionID, uri string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if sessionID == "gone" {
+		return server.ErrSessionNotFound
+	}
+	r.notifications = append(r.notifications, sessionID+" "+uri)
+	return nil
+}
+
+func (r *recordedNotifications) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	notifications := r.notifications
+	r.notifications = nil
+	return notifications
+}
+
+func Test_ResourceSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	commits := &commitsServer{shas: map[string]string{
+		"/repos/owner/repo/commits/pull/42/head": "sha1",
+		"/repos/owner/repo/commits/HEAD":         "main1",
+	}}
+	recorded := &recordedNotifications{}
+	subscriptions := NewResourceSubscriptions(stubGetClientFn(commits.client()), recorded.notify, DefaultResourcePollInterval)
+
+	prFile := "repo://owner/repo/refs/pull/42/head/contents/main.go"
+	prDir := "repo://owner/repo/refs/pull/42/head/contents/src/"
+	mainFile := "repo://owner/repo/contents/README.md"
+	require.NoError(t, subscriptions.Subscribe(ctx, "session1", prFile))
+	require.NoError(t, subscriptions.Subscribe(ctx, "session2", prFile))
+	require.NoError(t, subscriptions.Subscribe(ctx, "session1", prDir))
+	require.NoError(t, subscriptions.Subscribe(ctx, "session1", mainFile))
+	require.NoError(t, subscriptions.Subscribe(ctx, "session1", "repo://owner/repo/sha/abc/contents/main.go"))
+	// The commit of a ref is only fetched by its first subscription
+	assert.Equal(t, 2, commits.requests)
+
+	err := subscriptions.Subscribe(ctx, "session1", "repo://owner/missing/contents/README.md")
+	assert.EqualError(t, err, "resource repo://owner/missing/contents/README.md not found")
+
+	// Nothing changed
+	subscriptions.Poll(ctx)
+	assert.Empty(t, recorded.take())
+
+	// The pull request got a new commit
+	commits.setSHA("/repos/owner/repo/commits/pull/42/head", "sha2")
+	subscriptions.Poll(ctx)
+	assert.ElementsMatch(t, []string{
+		"session1 " + prFile,
+		"session2 " + prFile,
+		"session1 " + prDir,
+	}, recorded.take())
+
+	// The new commit is only notified once
+	subscriptions.Poll(ctx)
+	assert.Empty(t, recorded.take())
+
+	// Unsubscribed and removed sessions are no longer notified
+	require.NoError(t, subscriptions.Unsubscribe("session2", prFile))
+	subscriptions.RemoveSession("session1")
+	commits.setSHA("/repos/owner/repo/commits/pull/42/head", "sha3")
+	commits.setSHA("/repos/owner/repo/commits/HEAD", "main2")
+	subscriptions.Poll(ctx)
+	assert.Empty(t, recorded.take())
+	assert.Empty(t, subscriptions.watches)
+
+	// Sessions that are gone are removed once a notification fails
+	require.NoError(t, subscriptions.Subscribe(ctx, "gone", mainFile))
+	commits.setSHA("/repos/owner/repo/commits/HEAD", "main3")
+	subscriptions.Poll(ctx)
+	assert.Empty(t, recorded.take())
+	assert.Empty(t, subscriptions.watches)
+}
+
+func Test_ResourceSubscriptions_HandleMessage(t *testing.T) {
+	ctx := context.Background()
+	commits := &commitsServer{shas: map[string]string{
+		"/repos/owner/repo/commits/heads/main":      "sha1",
+		"/repos/owner/repo/commits/heads/down":      "error",
+		"/repos/owner/repo/commits/heads/feature/x": "sha2",
+	}}
+	recorded := &recordedNotifications{}
+	subscriptions := NewResourceSubscriptions(stubGetClientFn(commits.client()), recorded.notify, DefaultResourcePollInterval)
+
+	tests := []struct {
+		name            string
+		message         string
+		expectedHandled bool
+		expected        string
+		// expectedCode is checked instead of the whole response when set, for messages holding URLs
+		expectedCode int
+	}{
+		{
+			name:            "subscribe",
+			message:         `{"jsonrpc": "2.0", "id": 1, "method": "resources/subscribe", "params": {"uri": "repo://owner/repo/refs/heads/main/contents/README.md"}}`,
+			expectedHandled: true,
+			expected:        `{"jsonrpc": "2.0", "id": 1, "result": {}}`,
+		},
+		{
+			name:            "subscribe to a branch with a slash",
+			message:         `{"jsonrpc": "2.0", "id": 8, "method": "resources/subscribe", "params": {"uri": "repo://owner/repo/refs/heads/feature%2Fx/contents/README.md"}}`,
+			expectedHandled: true,
+			expected:        `{"jsonrpc": "2.0", "id": 8, "result": {}}`,
+		},
+		{
+			name:            "subscribe to an unsupported resource",
+			message:         `{"jsonrpc": "2.0", "id": "2", "method": "resources/subscribe", "params": {"uri": "issue://owner/repo/1"}}`,
+			expectedHandled: true,
+			expected:        `{"jsonrpc": "2.0", "id": "2", "error": {"code": -32602, "message": "unsupported resource URI issue://owner/repo/1, only repo:// resources can be subscribed to"}}`,
+		},
+		{
+			name:            "subscribe without uri",
+			message:         `{"jsonrpc": "2.0", "id": 3, "method": "resources/subscribe", "params": {}}`,
+			expectedHandled: true,
+			expected:        `{"jsonrpc": "2.0", "id": 3, "error": {"code": -32602, "message": "uri is required"}}`,
+		},
+		{
+			name:            "subscribe to a missing resource",
+			message:         `{"jsonrpc": "2.0", "id": 6, "method": "resources/subscribe", "params": {"uri": "repo://owner/repo/refs/heads/gone/contents/README.md"}}`,
+			expectedHandled: true,
+			expected:        `{"jsonrpc": "2.0", "id": 6, "error": {"code": -32602, "message": "resource repo://owner/repo/refs/heads/gone/contents/README.md not found"}}`,
+		},
+		{
+			name:            "subscribe while the API fails",
+			message:         `{"jsonrpc": "2.0", "id": 7, "method": "resources/subscribe", "params": {"uri": "repo://owner/repo/refs/heads/down/contents/README.md"}}`,
+			expectedHandled: true,
+			expectedCode:    mcp.INTERNAL_ERROR,
+		},
+		{
+			name:            "unsubscribe",
+			message:         `{"jsonrpc": "2.0", "id": 4, "method": "resources/unsubscribe", "params": {"uri": "repo://owner/repo/refs/heads/main/contents/README.md"}}`,
+			expectedHandled: true,
+			expected:        `{"jsonrpc": "2.0", "id": 4, "result": {}}`,
+		},
+		{
+			name:            "unsubscribe from a branch with a slash",
+			message:         `{"jsonrpc": "2.0", "id": 9, "method": "resources/unsubscribe", "params": {"uri": "repo://owner/repo/refs/heads/feature%2Fx/contents/README.md"}}`,
+			expectedHandled: true,
+			expected:        `{"jsonrpc": "2.0", "id": 9, "result": {}}`,
+		},
+		{
+			name:    "other request",
+			message: `{"jsonrpc": "2.0", "id": 5, "method": "resources/read", "params": {"uri": "repo://owner/repo/contents/README.md"}}`,
+		},
+		{
+			name:    "notification",
+			message: `{"jsonrpc": "2.0", "method": "resources/subscribe", "params": {"uri": "repo://owner/repo/contents/README.md"}}`,
+		},
+		{
+			name:    "invalid JSON",
+			message: `{"jsonrpc"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			response, handled := subscriptions.HandleMessage(ctx, "session1", json.RawMessage(tc.message))
+			assert.Equal(t, tc.expectedHandled, handled)
+			if !tc.expectedHandled {
+				assert.Nil(t, response)
+				return
+			}
+			if tc.expectedCode != 0 {
+				errResp, ok := response.(mcp.JSONRPCError)
+				require.True(t, ok, "expected an error, got %#v", response)
+				assert.Equal(t, tc.expectedCode, errResp.Error.Code)
+				return
+			}
+			data, err := json.Marshal(response)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(data))
+		})
+	}
+	assert.Empty(t, subscriptions.watches)
+}
@@ -0,0 +1,322 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxTreeEntries is the number of entries get_repository_tree returns at most
+	maxTreeEntries = 5000
+	// maxTreeRequests is the number of trees fetched at most when walking a tree too large to be fetched at once
+	maxTreeRequests = 50
+)
+
+// treeEntry is an entry of the result of get_repository_tree
+type treeEntry struct {
+	Path string `json:"path"`
+	// Type is blob for files, tree for directories and commit for submodules
+	Type string `json:"type"`
+	// Size is the size of files in bytes
+	Size *int `json:"size,omitempty"`
+}
+
+// treeResult is the result of get_repository_tree
+type treeResult struct {
+	Ref   string `json:"ref"`
+	SHA   string `json:"sha"`
+	Files int    `json:"files"`
+	Dirs  int    `json:"directories"`
+	// Size is the total size of the files listed in bytes
+	Size    int         `json:"size"`
+	Entries []treeEntry `json:"entries"`
+	// Truncated is set when the tree has more matching entries than listed, or could not be walked completely
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// treeFilter selects the entries returned by get_repository_tree
+type treeFilter struct {
+	include  []string
+	exclude  []string
+	maxDepth int
+}
+
+// GetRepositoryTree creates a tool to list the files and directories of a repository at a ref in one call.
+func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repository_tree",
+			mcp.WithDescription(t("TOOL_GET_REPOSITORY_TREE_DESCRIPTION", "Get the tree of files and directories of a GitHub repository at a ref, with the type and size of every entry. Filter entries with glob patterns and a maximum depth to map a codebase in one call. Patterns without a slash match the name of an entry at any depth, such as *.go; patterns with a slash match its whole path, such as src/**/*.ts, where ** matches any number of directories")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPOSITORY_TREE_USER_TITLE", "Get repository tree"),
+				ReadOnlyHint: ToBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to get the tree of. Defaults to the default branch of the repository"),
+			),
+			mcp.WithArray("include",
+				mcp.Description("Only return entries matching one of these glob patterns"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithArray("exclude",
+				mcp.Description("Leave out entries matching one of these glob patterns, along with everything inside directories matching them, such as node_modules"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("max_depth",
+				mcp.Description("Only return entries up to this depth, where 1 lists the entries at the root of the repository"),
+				mcp.Min(1),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := RequiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := RequiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			var filter treeFilter
+			if filter.include, err = OptionalStringArrayParam(request, "include"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if filter.exclude, err = OptionalStringArrayParam(request, "exclude"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if filter.maxDepth, err = OptionalIntParam(request, "max_depth"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, pattern := range append(append([]string{}, filter.include...), filter.exclude...) {
+				if err := validateGlob(pattern); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get repository info",
+						resp,
+						err,
+					), nil
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			w := &treeWalker{client: client, owner: owner, repo: repo, filter: filter}
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get tree of %s", ref),
+					resp,
+					err,
+				), nil
+			}
+			_ = resp.Body.Close()
+			w.requests++
+
+			result := treeResult{Ref: ref, SHA: tree.GetSHA(), Entries: []treeEntry{}}
+			if tree.GetTruncated() {
+				// The tree is too large to be fetched recursively at once, so walk its subtrees instead
+				if err := w.walk(ctx, tree.GetSHA(), "", 0, &result); err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get tree of %s", ref),
+						w.resp,
+						err,
+					), nil
+				}
+			} else {
+				w.add(tree.Entries, "", &result)
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// treeWalker collects the entries of a tree matching a filter
+type treeWalker struct {
+	client      *github.Client
+	owner, repo string
+	filter      treeFilter
+	requests    int
+	// resp is the response of the last failed request
+	resp *github.Response
+}
+
+// walk adds the entries of the tree sha, found at the directory prefix and depth, to the result. Subtrees are
+// fetched recursively when possible, and walked one level at a time otherwise.
+func (w *treeWalker) walk(ctx context.Context, sha, prefix string, depth int, result *treeResult) error {
+	if w.filter.maxDepth > 0 && depth >= w.filter.maxDepth {
+		return nil
+	}
+	if w.requests >= maxTreeRequests {
+		result.Truncated = true
+		return nil
+	}
+
+	if prefix != "" {
+		tree, resp, err := w.client.Git.GetTree(ctx, w.owner, w.repo, sha, true)
+		w.requests++
+		if err != nil {
+			w.resp = resp
+			return err
+		}
+		_ = resp.Body.Close()
+		if !tree.GetTruncated() {
+			w.add(tree.Entries, prefix, result)
+			return nil
+		}
+	}
+
+	tree, resp, err := w.client.Git.GetTree(ctx, w.owner, w.repo, sha, false)
+	w.requests++
+	if err != nil {
+		w.resp = resp
+		return err
+	}
+	_ = resp.Body.Close()
+	w.add(tree.Entries, prefix, result)
+	for _, entry := range tree.Entries {
+		if entry.GetType() != "tree" || w.excluded(prefix+entry.GetPath()) {
+			continue
+		}
+		if err := w.walk(ctx, entry.GetSHA(), prefix+entry.GetPath()+"/", depth+1, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add adds the entries of a tree found at the directory prefix to the result, if they match the filter
+func (w *treeWalker) add(entries []*github.TreeEntry, prefix string, result *treeResult) {
+	for _, entry := range entries {
+		p := prefix + entry.GetPath()
+		if !w.matches(p) {
+			continue
+		}
+		if len(result.Entries) == maxTreeEntries {
+			result.Truncated = true
+			return
+		}
+		e := treeEntry{Path: p, Type: entry.GetType()}
+		switch e.Type {
+		case "blob":
+			e.Size = github.Ptr(entry.GetSize())
+			result.Files++
+			result.Size += entry.GetSize()
+		case "tree":
+			result.Dirs++
+		}
+		result.Entries = append(result.Entries, e)
+	}
+}
+
+// matches reports whether the entry at p is selected by the filter
+func (w *treeWalker) matches(p string) bool {
+	if w.filter.maxDepth > 0 && strings.Count(p, "/")+1 > w.filter.maxDepth {
+		return false
+	}
+	if w.excluded(p) {
+		return false
+	}
+	if len(w.filter.include) == 0 {
+		return true
+	}
+	for _, pattern := range w.filter.include {
+		if matchGlob(pattern, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// excluded reports whether the entry at p or one of its parent directories matches an exclude pattern
+func (w *treeWalker) excluded(p string) bool {
+	for _, pattern := range w.filter.exclude {
+		for dir := p; dir != "." && dir != ""; dir = path.Dir(dir) {
+			if matchGlob(pattern, dir) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateGlob returns an error if a glob pattern is malformed
+func validateGlob(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("empty glob pattern")
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchGlob reports whether the slash separated path p matches pattern. Within a path segment, * and ?
+// match like path.Match does, and a ** segment matches any number of segments. Patterns without a slash
+// match the last segment of p only.
+func matchGlob(pattern, p string) bool {
+	pattern = strings.Trim(pattern, "/")
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(p))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(p, "/"))
+}
+
+// matchSegments matches path segments against pattern segments
+func matchSegments(patterns, segments []string) bool {
+	for len(patterns) > 0 {
+		if patterns[0] == "**" {
+			// Try to match the rest of the pattern at every remaining position
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(patterns[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(patterns[0], segments[0]); !ok {
+			return false
+		}
+		patterns, segments = patterns[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
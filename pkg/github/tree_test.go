@@ -0,0 +1,249 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositoryTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepositoryTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "include")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude")
+	assert.Contains(t, tool.InputSchema.Properties, "max_depth")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	blob := func(p string, size int) *github.TreeEntry {
+		return &github.TreeEntry{Path: github.Ptr(p), Type: github.Ptr("blob"), Size: github.Ptr(size), SHA: github.Ptr("sha-" + p)}
+	}
+	dir := func(p string) *github.TreeEntry {
+		return &github.TreeEntry{Path: github.Ptr(p), Type: github.Ptr("tree"), SHA: github.Ptr("sha-" + p)}
+	}
+
+	fullTree := &github.Tree{
+		SHA: github.Ptr("root"),
+		Entries: []*github.TreeEntry{
+			blob("README.md", 10),
+			dir("cmd"),
+			blob("cmd/main.go", 100),
+			dir("pkg"),
+			dir("pkg/api"),
+			blob("pkg/api/api.go", 200),
+			blob("pkg/api/api_test.go", 300),
+			dir("node_modules"),
+			blob("node_modules/left-pad/index.js", 50),
+		},
+	}
+
+	// trees serves the trees of a repository too large to be fetched recursively from its root
+	trees := map[string]map[bool]*github.Tree{
+		"main":    {true: {SHA: github.Ptr("root"), Truncated: github.Ptr(true)}},
+		"root":    {false: {SHA: github.Ptr("root"), Entries: []*github.TreeEntry{blob("README.md", 10), dir("cmd"), dir("pkg")}}},
+		"sha-cmd": {true: {SHA: github.Ptr("sha-cmd"), Entries: []*github.TreeEntry{blob("main.go", 100)}}},
+		"sha-pkg": {
+			true:  {SHA: github.Ptr("sha-pkg"), Truncated: github.Ptr(true)},
+			false: {SHA: github.Ptr("sha-pkg"), Entries: []*github.TreeEntry{dir("api")}},
+		},
+		"sha-api": {true: {SHA: github.Ptr("sha-api"), Entries: []*github.TreeEntry{blob("api.go", 200)}}},
+	}
+	truncatedTreeHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sha := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		tree, ok := trees[sha][r.URL.Query().Get("recursive") == "1"]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(tree)
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedPaths  []string
+		expectedErrMsg string
+	}{
+		{
+			name: "lists the tree of the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{DefaultBranch: github.Ptr("main")},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expectQueryParams(t, map[string]string{"recursive": "1"}).andThen(
+						mockResponse(t, http.StatusOK, fullTree),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedPaths: []string{
+				"README.md", "cmd", "cmd/main.go", "pkg", "pkg/api", "pkg/api/api.go", "pkg/api/api_test.go",
+				"node_modules", "node_modules/left-pad/index.js",
+			},
+		},
+		{
+			name: "filters entries with globs and a maximum depth",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					fullTree,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"ref":       "main",
+				"include":   []interface{}{"*.go", "*.js"},
+				"exclude":   []interface{}{"node_modules", "*_test.go"},
+				"max_depth": float64(3),
+			},
+			expectedPaths: []string{"cmd/main.go", "pkg/api/api.go"},
+		},
+		{
+			name:         "walks subtrees of a truncated tree",
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, truncatedTreeHandler)),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "main",
+			},
+			expectedPaths: []string{"README.md", "cmd", "pkg", "cmd/main.go", "pkg/api", "pkg/api/api.go"},
+		},
+		{
+			name:         "does not walk subtrees beyond the maximum depth",
+			mockedClient: mock.NewMockedHTTPClient(mock.WithRequestMatchHandler(mock.GetReposGitTreesByOwnerByRepoByTreeSha, truncatedTreeHandler)),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"ref":       "main",
+				"exclude":   []interface{}{"cmd"},
+				"max_depth": float64(2),
+			},
+			expectedPaths: []string{"README.md", "pkg", "pkg/api"},
+		},
+		{
+			name:         "invalid glob pattern",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"include": []interface{}{"src/[a-"},
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid glob pattern "src/[a-"`,
+		},
+		{
+			name: "tree not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get tree of missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepositoryTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned treeResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "main", returned.Ref)
+			assert.Equal(t, "root", returned.SHA)
+			assert.False(t, returned.Truncated)
+
+			paths := make([]string, 0, len(returned.Entries))
+			files, size := 0, 0
+			for _, entry := range returned.Entries {
+				paths = append(paths, entry.Path)
+				if entry.Type == "blob" {
+					require.NotNil(t, entry.Size)
+					files++
+					size += *entry.Size
+				}
+			}
+			assert.Equal(t, tc.expectedPaths, paths)
+			assert.Equal(t, files, returned.Files)
+			assert.Equal(t, len(paths)-files, returned.Dirs)
+			assert.Equal(t, size, returned.Size)
+		})
+	}
+}
+
+func Test_matchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "pkg/api/api.go", true},
+		{"*.go", "pkg/api/api.go.txt", false},
+		{"api", "pkg/api", true},
+		{"pkg/*.go", "pkg/api.go", true},
+		{"pkg/*.go", "pkg/api/api.go", false},
+		{"pkg/**/*.go", "pkg/api.go", true},
+		{"pkg/**/*.go", "pkg/api/v1/api.go", true},
+		{"pkg/**", "pkg/api/v1/api.go", true},
+		{"pkg/**", "cmd/main.go", false},
+		{"**/testdata/*", "a/b/testdata/x.json", true},
+		{"/docs/?.md", "docs/a.md", true},
+		{"docs/[ab].md", "docs/c.md", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pattern+" "+tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.want, matchGlob(tc.pattern, tc.path))
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package patch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxRejectedLines is the number of lines of a rejected hunk shown in errors at most
+const maxRejectedLines = 10
+
+// RejectedHunk is a hunk that could not be applied
+type RejectedHunk struct {
+	// Index is the position of the hunk in the diff of its file, starting at 1
+	Index int
+	Hunk  *Hunk
+}
+
+// ApplyError is returned when hunks of a diff could not be applied
+type ApplyError struct {
+	Rejected []RejectedHunk
+}
+
+func (e *ApplyError) Error() string {
+	var b strings.Builder
+	for i, r := range e.Rejected {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "hunk #%d %s does not apply, no lines of the file match its context and removed lines:", r.Index, r.Hunk.Header())
+		n := 0
+		for _, l := range r.Hunk.Lines {
+			if l.Op == '+' {
+				continue
+			}
+			if n == maxRejectedLines {
+				b.WriteString("\n  ...")
+				break
+			}
+			fmt.Fprintf(&b, "\n  %c%s", l.Op, l.Text)
+			n++
+		}
+	}
+	return b.String()
+}
+
+// Apply applies the hunks of the diff of a file to its content, and returns the new content.
+//
+// Each hunk is applied where its context and removed lines match the content, at the closest position to
+// the line given by its header, after the previous hunk. When a hunk does not match, up to fuzz lines of
+// context at its start and end are ignored, and lines are compared regardless of whitespace, like patch
+// does. Context lines are kept as they are in the content. If any hunk can't be applied, an *ApplyError
+// listing all of them is returned.
+func Apply(content string, hunks []*Hunk, fuzz int) (string, error) {
+	sep := "\n"
+	if strings.Contains(content, "\r\n") {
+		sep = "\r\n"
+	}
+	eol := content == "" || strings.HasSuffix(content, "\n")
+	var lines []string
+	if content != "" {
+		lines = strings.Split(strings.TrimSuffix(strings.TrimSuffix(content, "\n"), "\r"), "\n")
+		if sep == "\r\n" {
+			for i, l := range lines {
+				lines[i] = strings.TrimSuffix(l, "\r")
+			}
+		}
+	}
+
+	var out []string
+	var rejected []RejectedHunk
+	prev := 0
+	for i, h := range hunks {
+		pos, end, replacement, ok := locate(lines, prev, h, fuzz)
+		if !ok {
+			rejected = append(rejected, RejectedHunk{Index: i + 1, Hunk: h})
+			continue
+		}
+		out = append(out, lines[prev:pos]...)
+		out = append(out, replacement...)
+		prev = end
+		if prev == len(lines) {
+			// The hunk reaches the end of the file, so it tells whether the file ends with a newline
+			if h.NewNoEOL {
+				eol = false
+			} else if h.OldNoEOL {
+				eol = true
+			}
+		}
+	}
+	if len(rejected) > 0 {
+		return "", &ApplyError{Rejected: rejected}
+	}
+	out = append(out, lines[prev:]...)
+
+	result := strings.Join(out, sep)
+	if eol && len(out) > 0 {
+		result += sep
+	}
+	return result, nil
+}
+
+// locate finds where a hunk applies to lines, at or after from. It returns the range of lines the hunk
+// replaces, and the lines replacing them.
+func locate(lines []string, from int, h *Hunk, fuzz int) (start, end int, replacement []string, ok bool) {
+	hint := h.OldStart - 1
+	if old, _ := h.counts(); old == 0 {
+		// Hunks without old lines insert after the line given by their header
+		pos := min(max(h.OldStart, from), len(lines))
+		return pos, pos, newLines(h.Lines, nil), true
+	}
+
+	for f := 0; f <= fuzz; f++ {
+		trimmed, skipped := trimContext(h.Lines, f)
+		if f > 0 && len(trimmed) == len(h.Lines) {
+			// There is no more context to ignore
+			break
+		}
+		var old []string
+		for _, l := range trimmed {
+			if l.Op != '+' {
+				old = append(old, l.Text)
+			}
+		}
+		if len(old) == 0 {
+			break
+		}
+		for _, equal := range []func(a, b string) bool{exactly, ignoringWhitespace} {
+			if pos := search(lines, from, old, hint+skipped, equal); pos >= 0 {
+				return pos, pos + len(old), newLines(trimmed, lines[pos:pos+len(old)]), true
+			}
+			if fuzz == 0 {
+				break
+			}
+		}
+	}
+	return 0, 0, nil, false
+}
+
+// trimContext removes up to n lines of context from the start and the end of the lines of a hunk, and returns
+// the remaining lines along with the number of lines removed from the start
+func trimContext(lines []Line, n int) ([]Line, int) {
+	start := 0
+	for start < n && start < len(lines) && lines[start].Op == ' ' {
+		start++
+	}
+	end := len(lines)
+	for len(lines)-end < n && end > start && lines[end-1].Op == ' ' {
+		end--
+	}
+	return lines[start:end], start
+}
+
+// search returns the position of old in lines at or after from that is closest to hint, or -1
+func search(lines []string, from int, old []string, hint int, equal func(a, b string) bool) int {
+	last := len(lines) - len(old)
+	if last < from {
+		return -1
+	}
+	hint = min(max(hint, from), last)
+	for d := 0; hint-d >= from || hint+d <= last; d++ {
+		if p := hint - d; p >= from && matches(lines[p:], old, equal) {
+			return p
+		}
+		if p := hint + d; d > 0 && p <= last && matches(lines[p:], old, equal) {
+			return p
+		}
+	}
+	return -1
+}
+
+// matches reports whether lines start with old
+func matches(lines, old []string, equal func(a, b string) bool) bool {
+	for i, l := range old {
+		if !equal(lines[i], l) {
+			return false
+		}
+	}
+	return true
+}
+
+func exactly(a, b string) bool {
+	return a == b
+}
+
+func ignoringWhitespace(a, b string) bool {
+	return strings.Join(strings.Fields(a), " ") == strings.Join(strings.Fields(b), " ")
+}
+
+// newLines returns the lines a hunk replaces matched with, keeping its context lines as they are in matched
+func newLines(hunk []Line, matched []string) []string {
+	var out []string
+	i := 0
+	for _, l := range hunk {
+		switch l.Op {
+		case ' ':
+			out = append(out, matched[i])
+			i++
+		case '-':
+			i++
+		case '+':
+			out = append(out, l.Text)
+		}
+	}
+	return out
+}
@@ -0,0 +1,214 @@
+package patch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// applyDiff parses a diff of a single file and applies it to content
+func applyDiff(t *testing.T, content, diff string, fuzz int) (string, error) {
+	t.Helper()
+	files, err := Parse(diff)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	return Apply(content, files[0].Hunks, fuzz)
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		diff     string
+		fuzz     int
+		expected string
+	}{
+		{
+			name:     "exact match",
+			content:  "a\nb\nc\nd\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -2,2 +2,2 @@\n b\n-c\n+C\n",
+			expected: "a\nb\nC\nd\n",
+		},
+		{
+			name:     "hunks offset by lines added above them",
+			content:  "new\nnew\na\nb\nc\nd\ne\nf\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n-a\n+A\n b\n@@ -5,2 +5,2 @@\n e\n-f\n+F\n",
+			expected: "new\nnew\nA\nb\nc\nd\ne\nF\n",
+		},
+		{
+			name:     "repeated context applies closest to the header line",
+			content:  "x\n}\nx\n}\nx\n}\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -5,2 +5,3 @@\n x\n+y\n }\n",
+			expected: "x\n}\nx\n}\nx\ny\n}\n",
+		},
+		{
+			name:     "wrong header line and counts",
+			content:  "one\ntwo\nthree\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -40,9 +40,9 @@\n one\n-two\n+2\n",
+			expected: "one\n2\nthree\n",
+		},
+		{
+			name:     "fuzz ignores mismatched context at the edges",
+			content:  "alpha\nbeta\ngamma\ndelta\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -1,4 +1,4 @@\n ALPHA\n beta\n-gamma\n+GAMMA\n DELTA\n",
+			fuzz:     1,
+			expected: "alpha\nbeta\nGAMMA\ndelta\n",
+		},
+		{
+			name:     "whitespace differences keep the context of the file",
+			content:  "func f() {\n\treturn  1\n}\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n func f()  {\n-    return 1\n+\treturn 2\n }\n",
+			fuzz:     1,
+			expected: "func f() {\n\treturn 2\n}\n",
+		},
+		{
+			name:     "blank context lines stripped by an editor",
+			content:  "def f():\n\n    return 1\n",
+			diff:     "--- a/app.py\n+++ b/app.py\n@@ -1,3 +1,3 @@\n def f():\n\n-    return 1\n+    return 2\n",
+			expected: "def f():\n\n    return 2\n",
+		},
+		{
+			name:     "create a file",
+			content:  "",
+			diff:     "--- /dev/null\n+++ b/f\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+			expected: "a\nb\n",
+		},
+		{
+			name:     "create a file without a newline at its end",
+			content:  "",
+			diff:     "--- /dev/null\n+++ b/f\n@@ -0,0 +1 @@\n+a\n\\ No newline at end of file\n",
+			expected: "a",
+		},
+		{
+			name:     "remove every line",
+			content:  "a\nb\n",
+			diff:     "--- a/f\n+++ /dev/null\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+			expected: "",
+		},
+		{
+			name:     "insert at the top without context",
+			content:  "b\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -0,0 +1 @@\n+a\n",
+			expected: "a\nb\n",
+		},
+		{
+			name:     "insert after a line without context",
+			content:  "a\nc\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -1,0 +2 @@\n+b\n",
+			expected: "a\nb\nc\n",
+		},
+		{
+			name:     "add a newline at the end of the file",
+			content:  "a\nb",
+			diff:     "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+			expected: "a\nb\n",
+		},
+		{
+			name:     "remove the newline at the end of the file",
+			content:  "a\nb\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n a\n-b\n+b\n\\ No newline at end of file\n",
+			expected: "a\nb",
+		},
+		{
+			name:     "keep a missing newline when changing another line",
+			content:  "a\nb\nc",
+			diff:     "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n-a\n+A\n b\n",
+			expected: "A\nb\nc",
+		},
+		{
+			name:     "change the last line of a file without a newline at its end",
+			content:  "a\nb",
+			diff:     "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n",
+			expected: "a\nc",
+		},
+		{
+			name:     "keep CRLF line endings",
+			content:  "a\r\nb\r\nc\r\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+			expected: "a\r\nB\r\nc\r\n",
+		},
+		{
+			name:     "CRLF diff of a CRLF file",
+			content:  "a\r\nb\r\n",
+			diff:     "--- a/f\r\n+++ b/f\r\n@@ -1,2 +1,2 @@\r\n a\r\n-b\r\n+B\r\n",
+			expected: "a\r\nB\r\n",
+		},
+		{
+			name:     "lines looking like diff syntax",
+			content:  "--- not a header\n+++ neither\n@@ nor this\n",
+			diff:     "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n---- not a header\n+--- a header\n +++ neither\n-@@ nor this\n+@@ still not\n",
+			expected: "--- a header\n+++ neither\n@@ still not\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := applyDiff(t, tc.content, tc.diff, tc.fuzz)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestApplyRejects(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		diff          string
+		fuzz          int
+		expectedHunks []int
+		expectedErr   string
+	}{
+		{
+			name:          "mismatched context without fuzz",
+			content:       "alpha\nbeta\ngamma\n",
+			diff:          "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n ALPHA\n-beta\n+BETA\n gamma\n",
+			expectedHunks: []int{1},
+			expectedErr:   "hunk #1 @@ -1,3 +1,3 @@ does not apply, no lines of the file match its context and removed lines:\n   ALPHA\n  -beta\n   gamma",
+		},
+		{
+			name:          "removed lines must match",
+			content:       "alpha\nbeta\ngamma\n",
+			diff:          "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n alpha\n-delta\n+BETA\n gamma\n",
+			fuzz:          2,
+			expectedHunks: []int{1},
+		},
+		{
+			name:          "all failing hunks are reported",
+			content:       "a\nb\nc\nd\n",
+			diff:          "--- a/f\n+++ b/f\n@@ -1 +1 @@\n-x\n+X\n@@ -2 +2 @@\n-b\n+B\n@@ -4 +4 @@\n-y\n+Y\n",
+			expectedHunks: []int{1, 3},
+		},
+		{
+			name:          "hunks out of order",
+			content:       "a\nb\n",
+			diff:          "--- a/f\n+++ b/f\n@@ -2 +2 @@\n-b\n+B\n@@ -1 +1 @@\n-a\n+A\n",
+			expectedHunks: []int{2},
+		},
+		{
+			name:          "fuzz never ignores every line of context",
+			content:       "a\nb\n",
+			diff:          "--- a/f\n+++ b/f\n@@ -1,2 +1,3 @@\n x\n+inserted\n y\n",
+			fuzz:          3,
+			expectedHunks: []int{1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := applyDiff(t, tc.content, tc.diff, tc.fuzz)
+			var applyErr *ApplyError
+			require.True(t, errors.As(err, &applyErr), "expected an ApplyError, got %v", err)
+			var hunks []int
+			for _, r := range applyErr.Rejected {
+				hunks = append(hunks, r.Index)
+			}
+			assert.Equal(t, tc.expectedHunks, hunks)
+			if tc.expectedErr != "" {
+				assert.Equal(t, tc.expectedErr, err.Error())
+			}
+		})
+	}
+}
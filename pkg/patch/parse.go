@@ -0,0 +1,377 @@
+// Package patch parses unified diffs, as produced by git diff or diff -u, and applies them to file contents
+// with some tolerance for context that has drifted or been mistyped.
+package patch
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrBinary is returned when parsing a diff of binary files, which can't be applied
+var ErrBinary = errors.New("binary patches are not supported")
+
+// File is the diff of a file
+type File struct {
+	// OldPath is the path of the file before the change, empty when the file is created
+	OldPath string
+	// NewPath is the path of the file after the change, empty when the file is deleted
+	NewPath string
+	// OldMode and NewMode are the modes of the file before and after the change, when the diff has them
+	OldMode string
+	NewMode string
+	// IsRename and IsCopy are set when the file is renamed or copied from OldPath to NewPath
+	IsRename bool
+	IsCopy   bool
+	Hunks    []*Hunk
+}
+
+// IsNew reports whether the diff creates the file
+func (f *File) IsNew() bool {
+	return f.OldPath == ""
+}
+
+// IsDelete reports whether the diff deletes the file
+func (f *File) IsDelete() bool {
+	return f.NewPath == ""
+}
+
+// Path returns the path of the file after the change, or before it if the file is deleted
+func (f *File) Path() string {
+	if f.NewPath != "" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+// Hunk is a contiguous change within a file
+type Hunk struct {
+	// OldStart and NewStart are the first lines of the hunk in the old and new file, as given by its header.
+	// The line counts of the header are not kept, as they are often wrong in hand written diffs.
+	OldStart int
+	NewStart int
+	// Section is the text following the header, usually the enclosing function
+	Section string
+	Lines   []Line
+	// OldNoEOL and NewNoEOL are set when the old or new file does not end with a newline after the hunk
+	OldNoEOL bool
+	NewNoEOL bool
+}
+
+// Line is a line of a hunk
+type Line struct {
+	// Op is ' ' for context, '-' for a removed line and '+' for an added line
+	Op   byte
+	Text string
+}
+
+// Header returns the header of the hunk, with line counts computed from its lines
+func (h *Hunk) Header() string {
+	oldCount, newCount := h.counts()
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.OldStart, oldCount, h.NewStart, newCount)
+}
+
+// counts returns the number of lines of the hunk in the old and new file
+func (h *Hunk) counts() (oldCount, newCount int) {
+	for _, l := range h.Lines {
+		if l.Op != '+' {
+			oldCount++
+		}
+		if l.Op != '-' {
+			newCount++
+		}
+	}
+	return oldCount, newCount
+}
+
+// Parse parses a unified diff of one or more files. Text before the first file, such as a commit message,
+// is ignored.
+func Parse(diff string) ([]*File, error) {
+	diff = strings.ReplaceAll(diff, "\r\n", "\n")
+	// The newline ending the diff does not start another line
+	p := &parser{lines: strings.Split(strings.TrimSuffix(diff, "\n"), "\n")}
+	var files []*File
+	for p.i < len(p.lines) {
+		line := p.lines[p.i]
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			f, err := p.gitFile()
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, f)
+		case p.atFileHeader():
+			f := &File{}
+			if err := p.fileHeader(f, false); err != nil {
+				return nil, err
+			}
+			if err := p.hunks(f); err != nil {
+				return nil, err
+			}
+			files = append(files, f)
+		case strings.HasPrefix(line, "@@"):
+			return nil, fmt.Errorf("line %d: hunk without a --- and +++ file header", p.i+1)
+		default:
+			p.i++
+		}
+	}
+	if len(files) == 0 {
+		return nil, errors.New("no file diffs found in patch")
+	}
+	return files, nil
+}
+
+type parser struct {
+	lines []string
+	i     int
+}
+
+// atFileHeader reports whether the parser is at a --- line followed by a +++ line
+func (p *parser) atFileHeader() bool {
+	return strings.HasPrefix(p.lines[p.i], "--- ") && p.i+1 < len(p.lines) && strings.HasPrefix(p.lines[p.i+1], "+++ ")
+}
+
+// gitFile parses the diff of a file starting with a diff --git line
+func (p *parser) gitFile() (*File, error) {
+	f := &File{}
+	start := p.i
+	f.OldPath, f.NewPath = splitGitHeader(strings.TrimPrefix(p.lines[p.i], "diff --git "))
+	p.i++
+
+	// Extended header lines, up to the file header or the next file
+	for ; p.i < len(p.lines); p.i++ {
+		line := p.lines[p.i]
+		switch {
+		case strings.HasPrefix(line, "new file mode "):
+			f.NewMode = strings.TrimPrefix(line, "new file mode ")
+			f.OldPath = ""
+		case strings.HasPrefix(line, "deleted file mode "):
+			f.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+			f.NewPath = ""
+		case strings.HasPrefix(line, "old mode "):
+			f.OldMode = strings.TrimPrefix(line, "old mode ")
+		case strings.HasPrefix(line, "new mode "):
+			f.NewMode = strings.TrimPrefix(line, "new mode ")
+		case strings.HasPrefix(line, "rename from "):
+			f.IsRename = true
+			f.OldPath = unquote(strings.TrimPrefix(line, "rename from "))
+		case strings.HasPrefix(line, "rename to "):
+			f.IsRename = true
+			f.NewPath = unquote(strings.TrimPrefix(line, "rename to "))
+		case strings.HasPrefix(line, "copy from "):
+			f.IsCopy = true
+			f.OldPath = unquote(strings.TrimPrefix(line, "copy from "))
+		case strings.HasPrefix(line, "copy to "):
+			f.IsCopy = true
+			f.NewPath = unquote(strings.TrimPrefix(line, "copy to "))
+		case strings.HasPrefix(line, "Binary files "), line == "GIT binary patch":
+			return nil, fmt.Errorf("%s: %w", f.Path(), ErrBinary)
+		case strings.HasPrefix(line, "index "), strings.HasPrefix(line, "similarity index "),
+			strings.HasPrefix(line, "dissimilarity index "):
+		default:
+			if f.OldPath == "" && f.NewPath == "" {
+				return nil, fmt.Errorf("line %d: cannot tell the path of the file", start+1)
+			}
+			if p.atFileHeader() {
+				if err := p.fileHeader(f, true); err != nil {
+					return nil, err
+				}
+			}
+			return f, p.hunks(f)
+		}
+	}
+	return f, nil
+}
+
+// fileHeader parses the --- and +++ lines of the diff of a file
+func (p *parser) fileHeader(f *File, git bool) error {
+	oldPath := headerPath(strings.TrimPrefix(p.lines[p.i], "--- "))
+	newPath := headerPath(strings.TrimPrefix(p.lines[p.i+1], "+++ "))
+	p.i += 2
+
+	// Paths are prefixed with a/ and b/ by git, and often in hand written diffs
+	if git || (oldPath == "/dev/null" || strings.HasPrefix(oldPath, "a/")) && (newPath == "/dev/null" || strings.HasPrefix(newPath, "b/")) {
+		oldPath = strings.TrimPrefix(oldPath, "a/")
+		newPath = strings.TrimPrefix(newPath, "b/")
+	}
+	if oldPath == "/dev/null" && newPath == "/dev/null" {
+		return fmt.Errorf("line %d: both files are /dev/null", p.i-1)
+	}
+	if oldPath == "/dev/null" {
+		oldPath = ""
+	}
+	if newPath == "/dev/null" {
+		newPath = ""
+	}
+	// The extended git header is authoritative for renames and copies
+	if !f.IsRename && !f.IsCopy {
+		f.OldPath, f.NewPath = oldPath, newPath
+	}
+	return nil
+}
+
+// hunks parses the hunks of the diff of a file
+func (p *parser) hunks(f *File) error {
+	for p.i < len(p.lines) && strings.HasPrefix(p.lines[p.i], "@@") {
+		h, err := p.hunk()
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Path(), err)
+		}
+		f.Hunks = append(f.Hunks, h)
+	}
+	return nil
+}
+
+// hunk parses a hunk. The lines of the hunk are read until a line that can't belong to it, rather than by the
+// counts of the header, which are often wrong in hand written diffs.
+func (p *parser) hunk() (*Hunk, error) {
+	h := &Hunk{}
+	oldCount, newCount, err := parseHunkHeader(p.lines[p.i], h)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", p.i+1, err)
+	}
+	p.i++
+
+	oldSeen, newSeen := 0, 0
+	for ; p.i < len(p.lines); p.i++ {
+		line := p.lines[p.i]
+		if line == "-- " && oldSeen >= oldCount && newSeen >= newCount {
+			// The signature separator of git format-patch emails
+			break
+		}
+		if line == "" {
+			// Editors often strip the trailing space of empty context lines. Treat them as context while the
+			// hunk is incomplete or followed by more of its lines, so that the blank lines ending a diff are not
+			// mistaken for context.
+			if oldSeen >= oldCount && !p.hunkContinues() {
+				break
+			}
+			h.Lines = append(h.Lines, Line{Op: ' '})
+			oldSeen++
+			newSeen++
+			continue
+		}
+		if line[0] == '\\' {
+			// No newline at end of file, for the side of the previous line
+			if len(h.Lines) == 0 {
+				return nil, fmt.Errorf("line %d: %q before any line of the hunk", p.i+1, line)
+			}
+			switch h.Lines[len(h.Lines)-1].Op {
+			case '-':
+				h.OldNoEOL = true
+			case '+':
+				h.NewNoEOL = true
+			default:
+				h.OldNoEOL, h.NewNoEOL = true, true
+			}
+			continue
+		}
+		if line[0] != ' ' && line[0] != '-' && line[0] != '+' || p.atFileHeader() {
+			break
+		}
+		h.Lines = append(h.Lines, Line{Op: line[0], Text: line[1:]})
+		if line[0] != '+' {
+			oldSeen++
+		}
+		if line[0] != '-' {
+			newSeen++
+		}
+	}
+	if len(h.Lines) == 0 {
+		return nil, fmt.Errorf("hunk %s has no lines", h.Header())
+	}
+	return h, nil
+}
+
+// hunkContinues reports whether the lines of the current hunk continue after the blank lines at the parser
+func (p *parser) hunkContinues() bool {
+	for j := p.i; j < len(p.lines); j++ {
+		line := p.lines[j]
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "--- ") && j+1 < len(p.lines) && strings.HasPrefix(p.lines[j+1], "+++ ") {
+			return false
+		}
+		return line[0] == ' ' || line[0] == '-' || line[0] == '+' || line[0] == '\\'
+	}
+	return false
+}
+
+// parseHunkHeader parses a hunk header such as @@ -1,5 +1,6 @@ section into h, and returns its line counts
+func parseHunkHeader(line string, h *Hunk) (oldCount, newCount int, err error) {
+	rest, ok := strings.CutPrefix(line, "@@ -")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid hunk header %q", line)
+	}
+	ranges, section, ok := strings.Cut(rest, " @@")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid hunk header %q", line)
+	}
+	oldRange, newRange, ok := strings.Cut(ranges, " +")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid hunk header %q", line)
+	}
+	if h.OldStart, oldCount, err = parseRange(oldRange); err != nil {
+		return 0, 0, fmt.Errorf("invalid hunk header %q: %w", line, err)
+	}
+	if h.NewStart, newCount, err = parseRange(newRange); err != nil {
+		return 0, 0, fmt.Errorf("invalid hunk header %q: %w", line, err)
+	}
+	h.Section = strings.TrimSpace(section)
+	return oldCount, newCount, nil
+}
+
+// parseRange parses a range of a hunk header, such as 12,5 or 12 for a single line
+func parseRange(r string) (start, count int, err error) {
+	s, c, ok := strings.Cut(r, ",")
+	if start, err = strconv.Atoi(s); err != nil || start < 0 {
+		return 0, 0, fmt.Errorf("invalid line number %q", s)
+	}
+	if !ok {
+		return start, 1, nil
+	}
+	if count, err = strconv.Atoi(c); err != nil || count < 0 {
+		return 0, 0, fmt.Errorf("invalid line count %q", c)
+	}
+	return start, count, nil
+}
+
+// headerPath returns the path of a --- or +++ line, without the timestamp diff -u appends after a tab
+func headerPath(s string) string {
+	if strings.HasPrefix(s, `"`) {
+		return unquote(s)
+	}
+	s, _, _ = strings.Cut(s, "\t")
+	return strings.TrimSpace(s)
+}
+
+// splitGitHeader returns the paths of a diff --git line. Unquoted paths may contain spaces, so the line is
+// split where both halves name the same file, which holds unless the file is renamed.
+func splitGitHeader(s string) (oldPath, newPath string) {
+	if strings.HasPrefix(s, `"`) {
+		if end := strings.Index(s[1:], `" `); end >= 0 {
+			return strings.TrimPrefix(unquote(s[:end+2]), "a/"), strings.TrimPrefix(unquote(s[end+3:]), "b/")
+		}
+	}
+	if len(s)%2 == 1 {
+		half := len(s) / 2
+		if s[half] == ' ' && strings.HasPrefix(s, "a/") && s[2:half] == strings.TrimPrefix(s[half+1:], "b/") {
+			return s[2:half], s[2:half]
+		}
+	}
+	oldPath, newPath, _ = strings.Cut(s, " b/")
+	return strings.TrimPrefix(oldPath, "a/"), newPath
+}
+
+// unquote returns a path quoted by git in C style, or the path as is if it is not quoted
+func unquote(s string) string {
+	if !strings.HasPrefix(s, `"`) {
+		return s
+	}
+	if u, err := strconv.Unquote(s); err == nil {
+		return u
+	}
+	return s
+}
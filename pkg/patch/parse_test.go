@@ -0,0 +1,208 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		diff     string
+		expected []*File
+	}{
+		{
+			name: "git diff of several files",
+			diff: `diff --git a/main.go b/main.go
+index 3b18e51..a2c5f3e 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@ package main
+ package main
+-var x = 1
++var x = 2
+
+@@ -10,2 +10,3 @@ func main() {
+ 	run()
++	stop()
+ }
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+index 0000000..e69de29
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1 @@
++# New
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index e69de29..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-old
+`,
+			expected: []*File{
+				{
+					OldPath: "main.go",
+					NewPath: "main.go",
+					Hunks: []*Hunk{
+						{OldStart: 1, NewStart: 1, Section: "package main", Lines: []Line{
+							{' ', "package main"}, {'-', "var x = 1"}, {'+', "var x = 2"}, {' ', ""},
+						}},
+						{OldStart: 10, NewStart: 10, Section: "func main() {", Lines: []Line{
+							{' ', "\trun()"}, {'+', "\tstop()"}, {' ', "}"},
+						}},
+					},
+				},
+				{
+					NewPath: "docs/new.md",
+					NewMode: "100644",
+					Hunks:   []*Hunk{{OldStart: 0, NewStart: 1, Lines: []Line{{'+', "# New"}}}},
+				},
+				{
+					OldPath: "old.txt",
+					OldMode: "100644",
+					Hunks:   []*Hunk{{OldStart: 1, NewStart: 0, Lines: []Line{{'-', "old"}}}},
+				},
+			},
+		},
+		{
+			name: "renames, copies and mode changes without hunks",
+			diff: `diff --git a/run.sh b/run.sh
+old mode 100644
+new mode 100755
+diff --git a/src/a.go b/pkg/a.go
+similarity index 100%
+rename from src/a.go
+rename to pkg/a.go
+diff --git a/b.go b/c.go
+similarity index 90%
+copy from b.go
+copy to c.go
+--- a/b.go
++++ b/c.go
+@@ -1 +1 @@
+-package b
++package c
+`,
+			expected: []*File{
+				{OldPath: "run.sh", NewPath: "run.sh", OldMode: "100644", NewMode: "100755"},
+				{OldPath: "src/a.go", NewPath: "pkg/a.go", IsRename: true},
+				{OldPath: "b.go", NewPath: "c.go", IsCopy: true, Hunks: []*Hunk{
+					{OldStart: 1, NewStart: 1, Lines: []Line{{'-', "package b"}, {'+', "package c"}}},
+				}},
+			},
+		},
+		{
+			name: "diff -u with timestamps and without prefixes",
+			diff: "--- README.md\t2025-01-01 10:00:00.000000000 +0000\n+++ README.md\t2025-01-02 10:00:00.000000000 +0000\n@@ -1 +1 @@\n-a\n+b\n",
+			expected: []*File{
+				{OldPath: "README.md", NewPath: "README.md", Hunks: []*Hunk{
+					{OldStart: 1, NewStart: 1, Lines: []Line{{'-', "a"}, {'+', "b"}}},
+				}},
+			},
+		},
+		{
+			name: "hand written diff with wrong counts and stripped blank context lines",
+			diff: "Here is the fix:\n\n--- a/app.py\n+++ b/app.py\n@@ -4,2 +4,2 @@\n def f():\n\n-    return 1\n+    return 2\n\n\n",
+			expected: []*File{
+				{OldPath: "app.py", NewPath: "app.py", Hunks: []*Hunk{
+					{OldStart: 4, NewStart: 4, Lines: []Line{{' ', "def f():"}, {' ', ""}, {'-', "    return 1"}, {'+', "    return 2"}}},
+				}},
+			},
+		},
+		{
+			name: "blank context line ending a hunk followed by another hunk",
+			diff: "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n-a\n+b\n c\n\n@@ -8 +8 @@\n-x\n+y\n",
+			expected: []*File{
+				{OldPath: "f", NewPath: "f", Hunks: []*Hunk{
+					{OldStart: 1, NewStart: 1, Lines: []Line{{'-', "a"}, {'+', "b"}, {' ', "c"}, {' ', ""}}},
+					{OldStart: 8, NewStart: 8, Lines: []Line{{'-', "x"}, {'+', "y"}}},
+				}},
+			},
+		},
+		{
+			name: "no newline at end of file",
+			diff: "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n",
+			expected: []*File{
+				{OldPath: "f", NewPath: "f", Hunks: []*Hunk{
+					{OldStart: 1, NewStart: 1, OldNoEOL: true, Lines: []Line{{' ', "a"}, {'-', "b"}, {'+', "c"}}},
+				}},
+			},
+		},
+		{
+			name: "quoted paths and paths with spaces",
+			diff: "diff --git \"a/caf\\303\\251.txt\" \"b/caf\\303\\251.txt\"\nold mode 100644\nnew mode 100755\ndiff --git a/my file.txt b/my file.txt\nold mode 100755\nnew mode 100644\n",
+			expected: []*File{
+				{OldPath: "café.txt", NewPath: "café.txt", OldMode: "100644", NewMode: "100755"},
+				{OldPath: "my file.txt", NewPath: "my file.txt", OldMode: "100755", NewMode: "100644"},
+			},
+		},
+		{
+			name: "format-patch email with a diffstat",
+			diff: "From 1234 Mon Sep 17 00:00:00 2001\nSubject: [PATCH] Fix\n\n---\n f | 2 +-\n 1 file changed\n\ndiff --git a/f b/f\n--- a/f\n+++ b/f\n@@ -1 +1 @@\n-a\n+b\n-- \n2.40.0\n",
+			expected: []*File{
+				{OldPath: "f", NewPath: "f", Hunks: []*Hunk{
+					{OldStart: 1, NewStart: 1, Lines: []Line{{'-', "a"}, {'+', "b"}}},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			files, err := Parse(tc.diff)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, files)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		diff        string
+		expectedErr string
+	}{
+		{
+			name:        "no diff",
+			diff:        "just some text\n",
+			expectedErr: "no file diffs found in patch",
+		},
+		{
+			name:        "hunk without file header",
+			diff:        "@@ -1 +1 @@\n-a\n+b\n",
+			expectedErr: "line 1: hunk without a --- and +++ file header",
+		},
+		{
+			name:        "invalid hunk header",
+			diff:        "--- a/f\n+++ b/f\n@@ -x +1 @@\n-a\n",
+			expectedErr: `f: line 3: invalid hunk header "@@ -x +1 @@": invalid line number "x"`,
+		},
+		{
+			name:        "empty hunk",
+			diff:        "--- a/f\n+++ b/f\n@@ -1 +1 @@\n@@ -2 +2 @@\n-a\n",
+			expectedErr: "f: hunk @@ -1,0 +1,0 @@ has no lines",
+		},
+		{
+			name:        "binary diff",
+			diff:        "diff --git a/logo.png b/logo.png\nindex 1..2 100644\nBinary files a/logo.png and b/logo.png differ\n",
+			expectedErr: "logo.png: binary patches are not supported",
+		},
+		{
+			name:        "both files are /dev/null",
+			diff:        "--- /dev/null\n+++ /dev/null\n",
+			expectedErr: "both files are /dev/null",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.diff)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package raw
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	gogithub "github.com/google/go-github/v72/github"
+)
+
+// ArchiveFormat is the format of a repository archive.
+type ArchiveFormat string
+
+const (
+	// ArchiveTarball is a gzipped tar archive
+	ArchiveTarball ArchiveFormat = "tarball"
+	// ArchiveZipball is a zip archive
+	ArchiveZipball ArchiveFormat = "zipball"
+)
+
+// GetArchive starts downloading the archive of a repository at ref, or at the default branch if ref is empty.
+// Archives larger than maxSize bytes are rejected with a TooLargeError, based on the Content-Length header
+// where possible and otherwise once reading the returned body exceeds maxSize. The caller is responsible for
+// closing the body.
+func (c *Client) GetArchive(ctx context.Context, owner, repo, ref string, format ArchiveFormat, maxSize int64) (io.ReadCloser, error) {
+	if format != ArchiveTarball && format != ArchiveZipball {
+		return nil, fmt.Errorf("unsupported archive format: %s", format)
+	}
+
+	link, apiResp, err := c.api.Repositories.GetArchiveLink(ctx, owner, repo, gogithub.ArchiveFormat(format), &gogithub.RepositoryContentGetOptions{Ref: ref}, 1)
+	if err != nil {
+		if apiResp != nil {
+			return nil, &StatusError{StatusCode: apiResp.StatusCode}
+		}
+		return nil, fmt.Errorf("failed to get archive link: %w", err)
+	}
+	if link == nil || link.String() == "" {
+		return nil, fmt.Errorf("failed to get archive link: no location returned")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive request: %w", err)
+	}
+	resp, err := c.downloadClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archive: %w", err)
+	}
+	if err := checkStatus(resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if resp.ContentLength > maxSize {
+		_ = resp.Body.Close()
+		return nil, &TooLargeError{Size: resp.ContentLength, MaxSize: maxSize}
+	}
+
+	return &limitedBody{body: resp.Body, remaining: maxSize, maxSize: maxSize}, nil
+}
+
+// limitedBody fails with a TooLargeError once more than maxSize bytes were read
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	maxSize   int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, &TooLargeError{Size: -1, MaxSize: l.maxSize}
+	}
+	// Read one byte more than allowed, to tell content of exactly maxSize bytes from larger content
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.body.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), &TooLargeError{Size: -1, MaxSize: l.maxSize}
+	}
+	return n, err
+}
+
+func (l *limitedBody) Close() error {
+	return l.body.Close()
+}
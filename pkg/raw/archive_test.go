@@ -0,0 +1,113 @@
+package raw
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var getCodeloadTarball = mock.EndpointPattern{
+	Pattern: "/{owner}/{repo}/legacy.tar.gz/{ref:.*}",
+	Method:  "GET",
+}
+
+// newArchiveClient returns a client whose archive link redirects to archive, served with the given content length
+func newArchiveClient(t *testing.T, archive string, contentLength bool) *Client {
+	t.Helper()
+	base, _ := url.Parse("https://raw.example.com/")
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposTarballByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/missing") {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				w.Header().Set("Location", "https://codeload.example.com/octocat/hello/legacy.tar.gz/refs/heads/main")
+				w.WriteHeader(http.StatusFound)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			getCodeloadTarball,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/x-gzip")
+				if !contentLength {
+					// Flushing forces a chunked response without a Content-Length
+					w.(http.Flusher).Flush()
+				}
+				_, _ = w.Write([]byte(archive))
+			}),
+		),
+	)
+	return NewClient(github.NewClient(mockedClient), base, WithDownloadClient(mockedClient))
+}
+
+func TestGetArchive(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("downloads the archive", func(t *testing.T) {
+		client := newArchiveClient(t, "archive data", true)
+		body, err := client.GetArchive(ctx, "octocat", "hello", "main", ArchiveTarball, 100)
+		require.NoError(t, err)
+		defer func() { _ = body.Close() }()
+
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "archive data", string(data))
+	})
+
+	t.Run("archive of exactly the max size", func(t *testing.T) {
+		client := newArchiveClient(t, "archive data", false)
+		body, err := client.GetArchive(ctx, "octocat", "hello", "main", ArchiveTarball, int64(len("archive data")))
+		require.NoError(t, err)
+		defer func() { _ = body.Close() }()
+
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, "archive data", string(data))
+	})
+
+	t.Run("rejects a too large content length", func(t *testing.T) {
+		client := newArchiveClient(t, "archive data", true)
+		_, err := client.GetArchive(ctx, "octocat", "hello", "main", ArchiveTarball, 5)
+
+		var tooLarge *TooLargeError
+		require.ErrorAs(t, err, &tooLarge)
+		assert.Equal(t, int64(len("archive data")), tooLarge.Size)
+	})
+
+	t.Run("stops reading a too large body", func(t *testing.T) {
+		client := newArchiveClient(t, "archive data", false)
+		body, err := client.GetArchive(ctx, "octocat", "hello", "main", ArchiveTarball, 5)
+		require.NoError(t, err)
+		defer func() { _ = body.Close() }()
+
+		data, err := io.ReadAll(body)
+		var tooLarge *TooLargeError
+		require.ErrorAs(t, err, &tooLarge)
+		assert.Equal(t, int64(-1), tooLarge.Size)
+		assert.Equal(t, "archi", string(data))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client := newArchiveClient(t, "archive data", true)
+		_, err := client.GetArchive(ctx, "octocat", "hello", "missing", ArchiveTarball, 100)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		client := newArchiveClient(t, "archive data", true)
+		_, err := client.GetArchive(ctx, "octocat", "hello", "main", ArchiveFormat("rar"), 100)
+		assert.EqualError(t, err, "unsupported archive format: rar")
+	})
+}
@@ -0,0 +1,169 @@
+package raw
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// lfsPointerMaxSize is the size below which files are checked for being Git LFS pointers,
+// as the specification requires pointers to be smaller than 1024 bytes
+const lfsPointerMaxSize = 1024
+
+const lfsMediaType = "application/vnd.git-lfs+json"
+
+// LFSPointer is the pointer stored in a repository in place of a file tracked by Git LFS.
+type LFSPointer struct {
+	// OID is the object ID, such as sha256:4d7a2146...
+	OID string `json:"oid"`
+	// Size of the object in bytes
+	Size int64 `json:"size"`
+}
+
+// ParseLFSPointer parses data as a Git LFS pointer, see https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md
+func ParseLFSPointer(data []byte) (*LFSPointer, bool) {
+	if len(data) >= lfsPointerMaxSize {
+		return nil, false
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) < 3 || lines[0] != "version https://git-lfs.github.com/spec/v1" {
+		return nil, false
+	}
+
+	pointer := &LFSPointer{Size: -1}
+	for _, line := range lines[1:] {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, false
+		}
+		switch key {
+		case "oid":
+			hash, found := strings.CutPrefix(value, "sha256:")
+			if _, err := hex.DecodeString(hash); !found || err != nil || len(hash) != 64 {
+				return nil, false
+			}
+			pointer.OID = value
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size < 0 {
+				return nil, false
+			}
+			pointer.Size = size
+		}
+	}
+	if pointer.OID == "" || pointer.Size < 0 {
+		return nil, false
+	}
+	return pointer, true
+}
+
+// hash returns the hash of the object ID without the hash algorithm, as used by the batch API
+func (p *LFSPointer) hash() string {
+	_, hash, _ := strings.Cut(p.OID, ":")
+	return hash
+}
+
+type (
+	lfsBatchRequest struct {
+		Operation string           `json:"operation"`
+		Transfers []string         `json:"transfers"`
+		Objects   []lfsBatchObject `json:"objects"`
+	}
+
+	lfsBatchResponse struct {
+		Objects []lfsBatchObject `json:"objects"`
+	}
+
+	lfsBatchObject struct {
+		OID     string `json:"oid"`
+		Size    int64  `json:"size"`
+		Actions *struct {
+			Download *struct {
+				Href   string            `json:"href"`
+				Header map[string]string `json:"header"`
+			} `json:"download"`
+		} `json:"actions,omitempty"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error,omitempty"`
+	}
+)
+
+// lfsContentType guesses the content type of an LFS object from its path, as the object store does not know it
+func lfsContentType(filePath string) string {
+	if contentType := mime.TypeByExtension(path.Ext(filePath)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// downloadLFSObject resolves the download URL of an LFS object through the batch API of the repository and
+// starts downloading it. The caller is responsible for closing the returned body.
+func (c *Client) downloadLFSObject(ctx context.Context, owner, repo string, pointer *LFSPointer) (io.ReadCloser, error) {
+	batchURL := c.lfsURL.JoinPath(owner, repo+".git", "info", "lfs", "objects", "batch").String()
+	req, err := c.newRequest(ctx, http.MethodPost, batchURL, &lfsBatchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   []lfsBatchObject{{OID: pointer.hash(), Size: pointer.Size}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+
+	resp, err := c.client.Client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request Git LFS object %s: %w", pointer.OID, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to request Git LFS object %s: unexpected status %d from batch API: %s", pointer.OID, resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	var batch lfsBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return nil, fmt.Errorf("failed to decode Git LFS batch response: %w", err)
+	}
+	if len(batch.Objects) != 1 {
+		return nil, fmt.Errorf("failed to request Git LFS object %s: batch API returned %d objects", pointer.OID, len(batch.Objects))
+	}
+	object := batch.Objects[0]
+	if object.Error != nil {
+		return nil, fmt.Errorf("failed to request Git LFS object %s: %s (%d)", pointer.OID, object.Error.Message, object.Error.Code)
+	}
+	if object.Actions == nil || object.Actions.Download == nil {
+		return nil, fmt.Errorf("failed to request Git LFS object %s: batch API returned no download", pointer.OID)
+	}
+
+	// The download URL is usually signed for an object store, so only the headers from the batch API are sent
+	download, err := http.NewRequestWithContext(ctx, http.MethodGet, object.Actions.Download.Href, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Git LFS download request: %w", err)
+	}
+	for name, value := range object.Actions.Download.Header {
+		download.Header.Set(name, value)
+	}
+
+	downloadResp, err := c.downloadClient.Do(download)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download Git LFS object %s: %w", pointer.OID, err)
+	}
+	if downloadResp.StatusCode != http.StatusOK {
+		_ = downloadResp.Body.Close()
+		return nil, fmt.Errorf("failed to download Git LFS object %s: unexpected status %d", pointer.OID, downloadResp.StatusCode)
+	}
+	return downloadResp.Body, nil
+}
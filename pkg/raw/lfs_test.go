@@ -0,0 +1,202 @@
+package raw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOID = "sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+
+func testPointer(size int) string {
+	return fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid %s\nsize %d\n", testOID, size)
+}
+
+func TestParseLFSPointer(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want *LFSPointer
+	}{
+		{
+			name: "pointer",
+			data: testPointer(12345),
+			want: &LFSPointer{OID: testOID, Size: 12345},
+		},
+		{
+			name: "pointer with extensions and without trailing newline",
+			data: "version https://git-lfs.github.com/spec/v1\next-0-foo sha256:abc\noid " + testOID + "\nsize 3",
+			want: &LFSPointer{OID: testOID, Size: 3},
+		},
+		{name: "regular file", data: "# README\n\nHello\n"},
+		{name: "missing size", data: "version https://git-lfs.github.com/spec/v1\noid " + testOID + "\n"},
+		{name: "invalid oid", data: "version https://git-lfs.github.com/spec/v1\noid sha256:xyz\nsize 3\n"},
+		{name: "negative size", data: "version https://git-lfs.github.com/spec/v1\noid " + testOID + "\nsize -3\n"},
+		{name: "unknown version", data: "version https://example.com/spec/v2\noid " + testOID + "\nsize 3\n"},
+		{name: "too large", data: testPointer(3) + strings.Repeat("x", lfsPointerMaxSize)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pointer, ok := ParseLFSPointer([]byte(tc.data))
+			assert.Equal(t, tc.want != nil, ok)
+			assert.Equal(t, tc.want, pointer)
+		})
+	}
+}
+
+// newLFSClient returns a client whose repository stores a pointer to object in model.bin, served by the batch API
+func newLFSClient(t *testing.T, object string, batchHandler http.HandlerFunc, opts ...ClientOption) *Client {
+	t.Helper()
+	base, _ := url.Parse("https://raw.example.com/")
+	lfsURL, _ := url.Parse("https://github.example.com/")
+
+	if batchHandler == nil {
+		batchHandler = func(w http.ResponseWriter, r *http.Request) {
+			var batch lfsBatchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			assert.Equal(t, "download", batch.Operation)
+			assert.Equal(t, lfsMediaType, r.Header.Get("Accept"))
+			require.Len(t, batch.Objects, 1)
+
+			w.Header().Set("Content-Type", lfsMediaType)
+			_, _ = fmt.Fprintf(w, `{"objects": [{"oid": %q, "size": %d, "actions": {"download": {"href": "https://objects.example.com/objects/%s", "header": {"X-Signature": "signed"}}}}]}`,
+				batch.Objects[0].OID, batch.Objects[0].Size, batch.Objects[0].OID)
+		}
+	}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			GetRawReposContentsByOwnerByRepoByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pointer := testPointer(len(object))
+				w.Header().Set("Content-Type", "text/plain")
+				if r.Header.Get("Range") != "" {
+					// Serve the requested part of the pointer, which does not parse on its own
+					var start, end int
+					_, _ = fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end)
+					end = min(end, len(pointer)-1)
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(pointer)))
+					w.WriteHeader(http.StatusPartialContent)
+					_, _ = w.Write([]byte(pointer[start : end+1]))
+					return
+				}
+				_, _ = w.Write([]byte(pointer))
+			}),
+		),
+		mock.WithRequestMatchHandler(PostLFSObjectsBatchByOwnerByRepo, batchHandler),
+		mock.WithRequestMatchHandler(
+			mock.EndpointPattern{Pattern: "/objects/{oid}", Method: "GET"},
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "signed", r.Header.Get("X-Signature"))
+				w.Header().Set("Content-Type", "application/octet-stream")
+				_, _ = w.Write([]byte(object))
+			}),
+		),
+	)
+	opts = append(opts, WithLFSURL(lfsURL), WithDownloadClient(mockedClient))
+	return NewClient(github.NewClient(mockedClient), base, opts...)
+}
+
+func TestReadRawContent_LFS(t *testing.T) {
+	ctx := context.Background()
+	object := "line 1\nline 2\nline 3\n"
+
+	client := newLFSClient(t, object, nil)
+	content, err := client.ReadRawContent(ctx, "octocat", "hello", "model.json", nil)
+	require.NoError(t, err)
+	assert.Equal(t, object, string(content.Data))
+	assert.Equal(t, &LFSPointer{OID: testOID, Size: int64(len(object))}, content.LFS)
+	assert.Equal(t, "application/json", content.ContentType)
+	assert.Equal(t, int64(len(object)), content.Size)
+
+	// Lines and byte ranges apply to the object
+	lines, err := client.ReadRawLines(ctx, "octocat", "hello", "model.json", nil, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "line 2\n", string(lines.Data))
+
+	content, err = client.ReadRawContent(ctx, "octocat", "hello", "model.json", &ContentOpts{Range: &ByteRange{Start: 7, End: 12}})
+	require.NoError(t, err)
+	assert.Equal(t, "line 2", string(content.Data))
+	assert.Equal(t, int64(6), content.Length)
+}
+
+func TestReadRawContent_LFSTooLarge(t *testing.T) {
+	// The size of the pointer is checked before the object is requested
+	client := newLFSClient(t, strings.Repeat("x", 100), func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("unexpected batch request")
+	}, WithMaxSize(10))
+
+	_, err := client.ReadRawContent(context.Background(), "octocat", "hello", "model.bin", nil)
+	var tooLarge *TooLargeError
+	require.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, &LFSPointer{OID: testOID, Size: 100}, tooLarge.LFS)
+	assert.EqualError(t, err, "Git LFS object "+testOID+" is 100 bytes, larger than the limit of 10 bytes")
+}
+
+func TestReadRawContent_LFSErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc
+		expectError string
+	}{
+		{
+			name: "object error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprintf(w, `{"objects": [{"oid": "abc", "size": 3, "error": {"code": 404, "message": "Object does not exist"}}]}`)
+			},
+			expectError: "failed to request Git LFS object " + testOID + ": Object does not exist (404)",
+		},
+		{
+			name: "batch API error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message": "forbidden"}`))
+			},
+			expectError: "failed to request Git LFS object " + testOID + `: unexpected status 403 from batch API: {"message": "forbidden"}`,
+		},
+		{
+			name: "no download",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprintf(w, `{"objects": [{"oid": "abc", "size": 3}]}`)
+			},
+			expectError: "failed to request Git LFS object " + testOID + ": batch API returned no download",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newLFSClient(t, "abc", tc.handler)
+			_, err := client.ReadRawContent(context.Background(), "octocat", "hello", "model.bin", nil)
+			assert.EqualError(t, err, tc.expectError)
+		})
+	}
+}
+
+func TestReadRawContent_LFSDisabled(t *testing.T) {
+	// Without a Git LFS URL, the pointer itself is returned
+	base, _ := url.Parse("https://raw.example.com/")
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			GetRawReposContentsByOwnerByRepoByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(testPointer(3)))
+			}),
+		),
+	)
+	client := NewClient(github.NewClient(mockedClient), base)
+
+	content, err := client.ReadRawContent(context.Background(), "octocat", "hello", "model.bin", nil)
+	require.NoError(t, err)
+	assert.Equal(t, testPointer(3), string(content.Data))
+	assert.Nil(t, content.LFS)
+}
@@ -2,9 +2,16 @@
 package raw
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 
 	gogithub "github.com/google/go-github/v72/github"
 )
@@ -12,17 +19,68 @@ import (
 // GetRawClientFn is a function type that returns a RawClient instance.
 type GetRawClientFn func(context.Context) (*Client, error)
 
+// DefaultMaxSize is the largest content, in bytes, that is read into memory unless another limit is configured.
+const DefaultMaxSize int64 = 10 * 1024 * 1024
+
+// chunkSize is the size of the chunks content is streamed in
+const chunkSize = 32 * 1024
+
 // Client is a client for interacting with the GitHub raw content API.
 type Client struct {
-	url    *url.URL
-	client *gogithub.Client
+	url     *url.URL
+	client  *gogithub.Client
+	maxSize int64
+
+	// api is the client of the REST API, used to find the download URLs of archives
+	api *gogithub.Client
+	// downloadClient downloads Git LFS objects and archives, which are served by object stores
+	downloadClient *http.Client
+
+	// lfsURL is the base URL of the Git LFS API, Git LFS pointers are not resolved without one
+	lfsURL *url.URL
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithMaxSize sets the largest content, in bytes, that ReadRawContent and ReadRawLines read into memory.
+func WithMaxSize(maxSize int64) ClientOption {
+	return func(c *Client) {
+		c.maxSize = maxSize
+	}
+}
+
+// WithLFSURL resolves Git LFS pointers to their objects through the Git LFS batch API, at
+// {lfsURL}/{owner}/{repo}.git/info/lfs/objects/batch.
+func WithLFSURL(lfsURL *url.URL) ClientOption {
+	return func(c *Client) {
+		c.lfsURL = lfsURL
+	}
+}
+
+// WithDownloadClient sets the HTTP client used to download Git LFS objects and archives. It must not
+// authenticate with GitHub, as the download URLs are signed and usually point to an object store.
+func WithDownloadClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		c.downloadClient = client
+	}
 }
 
 // NewClient creates a new instance of the raw API Client with the provided GitHub client and provided URL.
-func NewClient(client *gogithub.Client, rawURL *url.URL) *Client {
+func NewClient(client *gogithub.Client, rawURL *url.URL, opts ...ClientOption) *Client {
+	api := client
 	client = gogithub.NewClient(client.Client())
 	client.BaseURL = rawURL
-	return &Client{client: client, url: rawURL}
+	c := &Client{client: client, url: rawURL, maxSize: DefaultMaxSize, api: api, downloadClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// MaxSize returns the largest content, in bytes, that is read into memory.
+func (c *Client) MaxSize() int64 {
+	return c.maxSize
 }
 
 func (c *Client) newRequest(ctx context.Context, method string, urlStr string, body interface{}, opts ...gogithub.RequestOption) (*http.Request, error) {
@@ -59,15 +117,367 @@ func (c *Client) commitURL(owner, repo, sha, path string) string {
 type ContentOpts struct {
 	Ref string
 	SHA string
+	// Range limits the content to a range of bytes
+	Range *ByteRange
+}
+
+// ByteRange is a range of bytes of a file, starting at zero. End is inclusive and -1 means up to the end of the file.
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+func (r ByteRange) validate() error {
+	if r.Start < 0 {
+		return fmt.Errorf("invalid byte range: start %d is negative", r.Start)
+	}
+	if r.End != -1 && r.End < r.Start {
+		return fmt.Errorf("invalid byte range: end %d is before start %d", r.End, r.Start)
+	}
+	return nil
+}
+
+// header returns the value of the Range header requesting the range
+func (r ByteRange) header() string {
+	if r.End == -1 {
+		return fmt.Sprintf("bytes=%d-", r.Start)
+	}
+	return fmt.Sprintf("bytes=%d-%d", r.Start, r.End)
 }
 
 // GetRawContent fetches the raw content of a file from a GitHub repository.
+// The caller is responsible for closing the body of the response.
 func (c *Client) GetRawContent(ctx context.Context, owner, repo, path string, opts *ContentOpts) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, owner, repo, path, opts)
+}
+
+func (c *Client) do(ctx context.Context, method, owner, repo, path string, opts *ContentOpts) (*http.Response, error) {
 	url := c.URLFromOpts(opts, owner, repo, path)
-	req, err := c.newRequest(ctx, "GET", url, nil)
+	req, err := c.newRequest(ctx, method, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	if opts != nil && opts.Range != nil {
+		if err := opts.Range.validate(); err != nil {
+			return nil, err
+		}
+		req.Header.Set("Range", opts.Range.header())
+	}
 
 	return c.client.Client().Do(req)
 }
+
+// StatusError is returned when the raw content API responds with a status other than 200 or 206.
+type StatusError struct {
+	StatusCode int
+	// Body is the start of the response body
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status %d from raw content API", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status %d from raw content API: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is a StatusError for a missing file.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// TooLargeError is returned when content is larger than the max size of the client.
+type TooLargeError struct {
+	// Size of the content in bytes, -1 when it is only known to exceed MaxSize
+	Size    int64
+	MaxSize int64
+	// LFS is the pointer of the content if it is a Git LFS object
+	LFS *LFSPointer
+}
+
+func (e *TooLargeError) Error() string {
+	if e.LFS != nil {
+		return fmt.Sprintf("Git LFS object %s is %d bytes, larger than the limit of %d bytes", e.LFS.OID, e.Size, e.MaxSize)
+	}
+	if e.Size < 0 {
+		return fmt.Sprintf("content is larger than the limit of %d bytes", e.MaxSize)
+	}
+	return fmt.Sprintf("content is %d bytes, larger than the limit of %d bytes", e.Size, e.MaxSize)
+}
+
+// ErrStopStream can be returned by the callback of StreamRawContent to stop streaming without an error.
+var ErrStopStream = errors.New("stop stream")
+
+// ContentInfo describes content of the raw content API.
+type ContentInfo struct {
+	ContentType string
+	// Size of the whole file in bytes, -1 if unknown
+	Size int64
+	// Length of the requested content in bytes, which is less than Size for byte ranges, -1 if unknown
+	Length int64
+	// LFS is the pointer the content was resolved from if the file is tracked by Git LFS
+	LFS *LFSPointer
+}
+
+// Content is content of the raw content API read into memory.
+type Content struct {
+	ContentInfo
+	Data []byte
+	// StartLine and EndLine are the lines, starting at one, of the content read by ReadRawLines
+	StartLine int
+	EndLine   int
+}
+
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusPartialContent {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+}
+
+func contentInfo(resp *http.Response, byteRange *ByteRange) *ContentInfo {
+	info := &ContentInfo{
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+		Length:      resp.ContentLength,
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		// Content-Range is "bytes start-end/size", where size may be "*"
+		info.Size = -1
+		if _, size, ok := strings.Cut(resp.Header.Get("Content-Range"), "/"); ok {
+			if n, err := strconv.ParseInt(size, 10, 64); err == nil {
+				info.Size = n
+			}
+		}
+	case byteRange != nil:
+		// The server ignored the range, so it is applied while reading
+		info.Length = rangeLength(info.Size, byteRange)
+	}
+	return info
+}
+
+// rangeLength returns the length of byteRange within content of size bytes, -1 if size is unknown
+func rangeLength(size int64, byteRange *ByteRange) int64 {
+	if size < 0 {
+		return -1
+	}
+	length := max(0, size-byteRange.Start)
+	if byteRange.End != -1 {
+		length = min(length, byteRange.End-byteRange.Start+1)
+	}
+	return length
+}
+
+// StatRawContent describes the raw content of a file using a HEAD request, without downloading it.
+// Git LFS pointers are not resolved, as that requires their content.
+func (c *Client) StatRawContent(ctx context.Context, owner, repo, path string, opts *ContentOpts) (*ContentInfo, error) {
+	resp, err := c.do(ctx, http.MethodHead, owner, repo, path, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	var byteRange *ByteRange
+	if opts != nil {
+		byteRange = opts.Range
+	}
+	return contentInfo(resp, byteRange), nil
+}
+
+// StreamRawContent streams the raw content of a file to fn in chunks, without reading it into memory.
+// The chunk is only valid until fn returns. Streaming stops at the first error returned by fn, which is
+// returned unless it is ErrStopStream.
+func (c *Client) StreamRawContent(ctx context.Context, owner, repo, path string, opts *ContentOpts, fn func(chunk []byte) error) (*ContentInfo, error) {
+	return c.stream(ctx, owner, repo, path, opts, nil, fn)
+}
+
+// stream streams the raw content of a file to fn, after check approved the content based on the response headers.
+// Git LFS pointers are resolved to their objects, if the client has a Git LFS URL.
+func (c *Client) stream(ctx context.Context, owner, repo, path string, opts *ContentOpts, check func(*ContentInfo) error, fn func(chunk []byte) error) (*ContentInfo, error) {
+	resp, err := c.GetRawContent(ctx, owner, repo, path, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+
+	var byteRange *ByteRange
+	if opts != nil {
+		byteRange = opts.Range
+	}
+	info := contentInfo(resp, byteRange)
+
+	if c.lfsURL != nil && resp.StatusCode == http.StatusPartialContent && info.Size >= 0 && info.Size < lfsPointerMaxSize {
+		// Part of a pointer cannot be recognized, so fetch the whole file and apply the range while reading
+		_ = resp.Body.Close()
+		resp, err = c.GetRawContent(ctx, owner, repo, path, &ContentOpts{Ref: opts.Ref, SHA: opts.SHA})
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if err := checkStatus(resp); err != nil {
+			return nil, err
+		}
+		info = contentInfo(resp, byteRange)
+	}
+
+	body := io.Reader(resp.Body)
+	applyRange := byteRange != nil && resp.StatusCode == http.StatusOK
+
+	if c.lfsURL != nil && resp.ContentLength < lfsPointerMaxSize {
+		buffered := bufio.NewReaderSize(resp.Body, lfsPointerMaxSize+1)
+		body = buffered
+
+		// Peek returns less than asked for when the file is smaller, which is what pointers are
+		peeked, _ := buffered.Peek(lfsPointerMaxSize)
+		if pointer, ok := ParseLFSPointer(peeked); ok {
+			info = &ContentInfo{
+				ContentType: lfsContentType(path),
+				Size:        pointer.Size,
+				Length:      pointer.Size,
+				LFS:         pointer,
+			}
+			if byteRange != nil {
+				info.Length = rangeLength(pointer.Size, byteRange)
+			}
+			if check != nil {
+				if err := check(info); err != nil {
+					return info, err
+				}
+			}
+
+			object, err := c.downloadLFSObject(ctx, owner, repo, pointer)
+			if err != nil {
+				return info, err
+			}
+			defer func() { _ = object.Close() }()
+
+			body = object
+			applyRange = byteRange != nil
+			check = nil
+		}
+	}
+
+	if check != nil {
+		if err := check(info); err != nil {
+			return info, err
+		}
+	}
+
+	if applyRange {
+		// The server ignored the range, so skip to its start and stop at its end
+		if _, err := io.CopyN(io.Discard, body, byteRange.Start); err != nil && !errors.Is(err, io.EOF) {
+			return info, fmt.Errorf("failed to read raw content: %w", err)
+		}
+		if byteRange.End != -1 {
+			body = io.LimitReader(body, byteRange.End-byteRange.Start+1)
+		}
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if err := fn(buf[:n]); err != nil {
+				if errors.Is(err, ErrStopStream) {
+					return info, nil
+				}
+				return info, err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return info, nil
+		}
+		if err != nil {
+			return info, fmt.Errorf("failed to read raw content: %w", err)
+		}
+	}
+}
+
+// ReadRawContent reads the raw content of a file into memory. Content larger than the max size of the client
+// is rejected with a TooLargeError, based on the Content-Length header where possible.
+func (c *Client) ReadRawContent(ctx context.Context, owner, repo, path string, opts *ContentOpts) (*Content, error) {
+	var data bytes.Buffer
+	check := func(info *ContentInfo) error {
+		if info.Length > c.maxSize {
+			return &TooLargeError{Size: info.Length, MaxSize: c.maxSize, LFS: info.LFS}
+		}
+		return nil
+	}
+	info, err := c.stream(ctx, owner, repo, path, opts, check, func(chunk []byte) error {
+		if int64(data.Len()+len(chunk)) > c.maxSize {
+			return &TooLargeError{Size: -1, MaxSize: c.maxSize}
+		}
+		data.Write(chunk)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Content{ContentInfo: *info, Data: data.Bytes()}, nil
+}
+
+// ReadRawLines reads the lines from startLine to endLine, starting at one and inclusive, of the raw content
+// of a file into memory. An endLine of zero reads up to the end of the file. Only the lines read count
+// towards the max size of the client, and streaming stops after endLine.
+func (c *Client) ReadRawLines(ctx context.Context, owner, repo, path string, opts *ContentOpts, startLine, endLine int) (*Content, error) {
+	if startLine < 1 {
+		return nil, fmt.Errorf("invalid line range: start line %d must be at least 1", startLine)
+	}
+	if endLine != 0 && endLine < startLine {
+		return nil, fmt.Errorf("invalid line range: end line %d is before start line %d", endLine, startLine)
+	}
+
+	var data bytes.Buffer
+	line, lastLine := 1, 0
+	// partial is set while a line without its newline was read, so the last line is counted without one
+	partial := false
+	info, err := c.stream(ctx, owner, repo, path, opts, nil, func(chunk []byte) error {
+		for len(chunk) > 0 {
+			segment := chunk
+			i := bytes.IndexByte(chunk, '\n')
+			if i >= 0 {
+				segment = chunk[:i+1]
+			}
+			chunk = chunk[len(segment):]
+			partial = i < 0
+
+			if line >= startLine {
+				if int64(data.Len()+len(segment)) > c.maxSize {
+					return &TooLargeError{Size: -1, MaxSize: c.maxSize}
+				}
+				data.Write(segment)
+				lastLine = line
+			}
+			if i >= 0 {
+				line++
+				if endLine != 0 && line > endLine {
+					return ErrStopStream
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lastLine == 0 {
+		lines := line - 1
+		if partial {
+			lines++
+		}
+		return nil, fmt.Errorf("start line %d is beyond the end of the file, which has %d lines", startLine, lines)
+	}
+	return &Content{ContentInfo: *info, Data: data.Bytes(), StartLine: startLine, EndLine: lastLine}, nil
+}
@@ -18,3 +18,11 @@ var GetRawReposContentsByOwnerByRepoBySHAByPath mock.EndpointPattern = mock.Endp
 	Pattern: "/{owner}/{repo}/{sha}/{path:.*}",
 	Method:  "GET",
 }
+var HeadRawReposContentsByOwnerByRepoByPath mock.EndpointPattern = mock.EndpointPattern{
+	Pattern: "/{owner}/{repo}/HEAD/{path:.*}",
+	Method:  "HEAD",
+}
+var PostLFSObjectsBatchByOwnerByRepo mock.EndpointPattern = mock.EndpointPattern{
+	Pattern: "/{owner}/{repo}.git/info/lfs/objects/batch",
+	Method:  "POST",
+}
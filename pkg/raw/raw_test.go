@@ -2,12 +2,17 @@ package raw
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/google/go-github/v72/github"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -148,3 +153,232 @@ func TestUrlFromOpts(t *testing.T) {
 		})
 	}
 }
+
+// newContentClient returns a client whose raw content API serves content for README.md at HEAD, honouring
+// Range headers when ranges is set
+func newContentClient(t *testing.T, content string, ranges bool, opts ...ClientOption) *Client {
+	t.Helper()
+	base, _ := url.Parse("https://raw.example.com/")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		if ranges && r.Header.Get("Range") != "" {
+			var start, end int
+			if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end); err != nil {
+				end = len(content) - 1
+			}
+			end = min(end, len(content)-1)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(content)))
+			w.Header().Set("Content-Length", strconv.Itoa(end-start+1))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(content[start : end+1]))
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		if r.Method == http.MethodHead {
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	})
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(GetRawReposContentsByOwnerByRepoByPath, handler),
+		mock.WithRequestMatchHandler(HeadRawReposContentsByOwnerByRepoByPath, handler),
+	)
+	return NewClient(github.NewClient(mockedClient), base, opts...)
+}
+
+func TestStatRawContent(t *testing.T) {
+	client := newContentClient(t, "0123456789", false)
+	info, err := client.StatRawContent(context.Background(), "octocat", "hello", "README.md", nil)
+	require.NoError(t, err)
+	assert.Equal(t, &ContentInfo{ContentType: "text/plain", Size: 10, Length: 10}, info)
+}
+
+func TestReadRawContent(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		ranges      bool
+		maxSize     int64
+		opts        *ContentOpts
+		want        string
+		wantInfo    ContentInfo
+		expectError string
+	}{
+		{
+			name:     "whole file",
+			want:     "0123456789",
+			wantInfo: ContentInfo{ContentType: "text/plain", Size: 10, Length: 10},
+		},
+		{
+			name:     "byte range",
+			ranges:   true,
+			opts:     &ContentOpts{Range: &ByteRange{Start: 2, End: 4}},
+			want:     "234",
+			wantInfo: ContentInfo{ContentType: "text/plain", Size: 10, Length: 3},
+		},
+		{
+			name:     "byte range ignored by the server",
+			opts:     &ContentOpts{Range: &ByteRange{Start: 2, End: 4}},
+			want:     "234",
+			wantInfo: ContentInfo{ContentType: "text/plain", Size: 10, Length: 3},
+		},
+		{
+			name:     "open byte range ignored by the server",
+			opts:     &ContentOpts{Range: &ByteRange{Start: 7, End: -1}},
+			want:     "789",
+			wantInfo: ContentInfo{ContentType: "text/plain", Size: 10, Length: 3},
+		},
+		{
+			name:     "byte range within the max size",
+			ranges:   true,
+			maxSize:  5,
+			opts:     &ContentOpts{Range: &ByteRange{Start: 5, End: 9}},
+			want:     "56789",
+			wantInfo: ContentInfo{ContentType: "text/plain", Size: 10, Length: 5},
+		},
+		{
+			name:        "larger than the max size",
+			maxSize:     5,
+			expectError: "content is 10 bytes, larger than the limit of 5 bytes",
+		},
+		{
+			name:        "invalid byte range",
+			opts:        &ContentOpts{Range: &ByteRange{Start: 4, End: 2}},
+			expectError: "invalid byte range: end 2 is before start 4",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []ClientOption
+			if tc.maxSize != 0 {
+				opts = append(opts, WithMaxSize(tc.maxSize))
+			}
+			client := newContentClient(t, "0123456789", tc.ranges, opts...)
+
+			content, err := client.ReadRawContent(ctx, "octocat", "hello", "README.md", tc.opts)
+			if tc.expectError != "" {
+				require.EqualError(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(content.Data))
+			assert.Equal(t, tc.wantInfo, content.ContentInfo)
+		})
+	}
+}
+
+func TestReadRawContent_Errors(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			GetRawReposContentsByOwnerByRepoByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/octocat/hello/HEAD/missing.txt":
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte("404: Not Found"))
+				case "/octocat/hello/HEAD/chunked.txt":
+					// Flushing before writing everything leaves out the Content-Length header
+					_, _ = w.Write([]byte("01234"))
+					w.(http.Flusher).Flush()
+					_, _ = w.Write([]byte("56789"))
+				default:
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}),
+		),
+	)
+	client := NewClient(github.NewClient(mockedClient), base, WithMaxSize(8))
+	ctx := context.Background()
+
+	_, err := client.ReadRawContent(ctx, "octocat", "hello", "missing.txt", nil)
+	assert.EqualError(t, err, "unexpected status 404 from raw content API: 404: Not Found")
+	assert.True(t, IsNotFound(err))
+
+	_, err = client.ReadRawContent(ctx, "octocat", "hello", "broken.txt", nil)
+	assert.EqualError(t, err, "unexpected status 500 from raw content API")
+	assert.False(t, IsNotFound(err))
+
+	_, err = client.ReadRawContent(ctx, "octocat", "hello", "chunked.txt", nil)
+	var tooLarge *TooLargeError
+	require.ErrorAs(t, err, &tooLarge)
+	assert.Equal(t, int64(-1), tooLarge.Size)
+	assert.EqualError(t, err, "content is larger than the limit of 8 bytes")
+}
+
+func TestReadRawLines(t *testing.T) {
+	ctx := context.Background()
+	content := "one\ntwo\nthree\nfour"
+
+	tests := []struct {
+		name        string
+		start, end  int
+		maxSize     int64
+		want        string
+		wantEnd     int
+		expectError string
+	}{
+		{name: "middle lines", start: 2, end: 3, want: "two\nthree\n", wantEnd: 3},
+		{name: "up to the end", start: 3, want: "three\nfour", wantEnd: 4},
+		{name: "end past the last line", start: 4, end: 10, want: "four", wantEnd: 4},
+		{name: "first line", start: 1, end: 1, want: "one\n", wantEnd: 1},
+		{name: "only the lines count towards the max size", start: 1, end: 2, maxSize: 8, want: "one\ntwo\n", wantEnd: 2},
+		{name: "lines larger than the max size", start: 1, maxSize: 8, expectError: "content is larger than the limit of 8 bytes"},
+		{name: "start past the last line", start: 5, expectError: "start line 5 is beyond the end of the file, which has 4 lines"},
+		{name: "invalid start", start: 0, expectError: "invalid line range: start line 0 must be at least 1"},
+		{name: "end before start", start: 3, end: 2, expectError: "invalid line range: end line 2 is before start line 3"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []ClientOption
+			if tc.maxSize != 0 {
+				opts = append(opts, WithMaxSize(tc.maxSize))
+			}
+			client := newContentClient(t, content, false, opts...)
+
+			lines, err := client.ReadRawLines(ctx, "octocat", "hello", "README.md", nil, tc.start, tc.end)
+			if tc.expectError != "" {
+				require.EqualError(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(lines.Data))
+			assert.Equal(t, tc.start, lines.StartLine)
+			assert.Equal(t, tc.wantEnd, lines.EndLine)
+		})
+	}
+}
+
+func TestStreamRawContent(t *testing.T) {
+	content := strings.Repeat("x", chunkSize*3+10)
+	client := newContentClient(t, content, false, WithMaxSize(10))
+	ctx := context.Background()
+
+	// Streaming is not limited by the max size
+	var streamed int
+	info, err := client.StreamRawContent(ctx, "octocat", "hello", "README.md", nil, func(chunk []byte) error {
+		streamed += len(chunk)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, len(content), streamed)
+	assert.Equal(t, int64(len(content)), info.Size)
+
+	// ErrStopStream ends the stream early without an error
+	chunks := 0
+	_, err = client.StreamRawContent(ctx, "octocat", "hello", "README.md", nil, func(_ []byte) error {
+		chunks++
+		return ErrStopStream
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, chunks)
+
+	// Other errors are returned
+	_, err = client.StreamRawContent(ctx, "octocat", "hello", "README.md", nil, func(_ []byte) error {
+		return errors.New("out of space")
+	})
+	assert.EqualError(t, err, "out of space")
+}
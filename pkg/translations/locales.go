@@ -0,0 +1,161 @@
+package translations
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// bundledLocales are the locale files shipped with the server, so they are found wherever it runs from
+//
+//go:embed locales/*.json
+var bundledLocales embed.FS
+
+// Locale holds the translations of a single locale file, such as locales/ja.json
+type Locale struct {
+	Name         string
+	Path         string
+	Translations map[string]string
+}
+
+// NormalizeLocale brings a locale name into the form used for locale files, e.g. pt_br becomes pt-BR
+func NormalizeLocale(locale string) string {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	for i, part := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(part)
+		case len(part) == 2:
+			// Region subtags are upper case
+			parts[i] = strings.ToUpper(part)
+		case len(part) == 4:
+			// Script subtags are title case
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// LocaleFallbackChain expands the requested locales, in order of preference, into the order in which
+// locale files are consulted. Each locale is followed by its parents, so "pt-BR,es" becomes pt-BR, pt, es.
+// The built-in English text is always the final fallback and is not part of the chain.
+func LocaleFallbackChain(locales []string) []string {
+	var chain []string
+	seen := map[string]bool{}
+	for _, requested := range locales {
+		for _, entry := range strings.Split(requested, ",") {
+			locale := NormalizeLocale(entry)
+			for locale != "" {
+				if !seen[strings.ToLower(locale)] {
+					seen[strings.ToLower(locale)] = true
+					chain = append(chain, locale)
+				}
+				i := strings.LastIndex(locale, "-")
+				if i == -1 {
+					break
+				}
+				locale = locale[:i]
+			}
+		}
+	}
+	return chain
+}
+
+// BundledLocales reads the locale files shipped with the server, keyed by the lower case locale name
+func BundledLocales() (map[string]*Locale, error) {
+	return loadLocales(bundledLocales, "locales", "locales")
+}
+
+// LoadLocales reads every locale file in dir, keyed by the lower case locale name.
+// A missing directory is not an error, as locale files are optional.
+func LoadLocales(dir string) (map[string]*Locale, error) {
+	locales, err := loadLocales(os.DirFS(dir), ".", dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]*Locale{}, nil
+	}
+	return locales, err
+}
+
+// loadLocales reads every locale file in dir of fsys, naming their paths after displayDir
+func loadLocales(fsys fs.FS, dir, displayDir string) (map[string]*Locale, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locales directory: %w", err)
+	}
+
+	locales := map[string]*Locale{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		file := filepath.Join(displayDir, entry.Name())
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read locale file: %w", err)
+		}
+
+		var raw map[string]string
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse locale file %s: %w", file, err)
+		}
+		// Keys are matched in upper case, like the keys of the config file
+		translations := make(map[string]string, len(raw))
+		for key, value := range raw {
+			translations[strings.ToUpper(key)] = value
+		}
+
+		name := NormalizeLocale(strings.TrimSuffix(entry.Name(), ".json"))
+		locales[strings.ToLower(name)] = &Locale{Name: name, Path: file, Translations: translations}
+	}
+	return locales, nil
+}
+
+// OverlayLocales returns the locales of base with the translations of overrides layered over them key by key,
+// so an override file only needs the keys it changes. Neither argument is modified.
+func OverlayLocales(base, overrides map[string]*Locale) map[string]*Locale {
+	locales := maps.Clone(base)
+	for key, override := range overrides {
+		locale, ok := locales[key]
+		if !ok {
+			locales[key] = override
+			continue
+		}
+		translations := maps.Clone(locale.Translations)
+		maps.Copy(translations, override.Translations)
+		locales[key] = &Locale{Name: locale.Name, Path: override.Path, Translations: translations}
+	}
+	return locales
+}
+
+// LocaleNames returns the names of the loaded locales in sorted order
+func LocaleNames(locales map[string]*Locale) []string {
+	names := make([]string, 0, len(locales))
+	for _, locale := range locales {
+		names = append(names, locale.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// localeLookup finds the translation of key in the first locale of the chain that has one
+func localeLookup(locales map[string]*Locale, chain []string, key string) (string, bool) {
+	for _, name := range chain {
+		locale, ok := locales[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+		if value, ok := locale.Translations[key]; ok {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,121 @@
+package translations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NormalizeLocale(t *testing.T) {
+	assert.Equal(t, "pt-BR", NormalizeLocale("pt_br"))
+	assert.Equal(t, "ja", NormalizeLocale(" JA "))
+	assert.Equal(t, "zh-Hant-TW", NormalizeLocale("zh-hant-tw"))
+	assert.Equal(t, "es-419", NormalizeLocale("es-419"))
+}
+
+func Test_LocaleFallbackChain(t *testing.T) {
+	assert.Equal(t, []string{"pt-BR", "pt", "es"}, LocaleFallbackChain([]string{"pt-BR,es"}))
+	assert.Equal(t, []string{"zh-Hant-TW", "zh-Hant", "zh"}, LocaleFallbackChain([]string{"zh_Hant_TW"}))
+	assert.Equal(t, []string{"pt-PT", "pt", "pt-BR"}, LocaleFallbackChain([]string{"pt-PT", "pt", "pt_BR"}))
+	assert.Empty(t, LocaleFallbackChain(nil))
+	assert.Empty(t, LocaleFallbackChain([]string{""}))
+}
+
+func writeLocale(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+}
+
+func Test_LoadLocales(t *testing.T) {
+	dir := t.TempDir()
+	writeLocale(t, dir, "pt_br.json", `{"tool_a_description": "Ferramenta A"}`)
+	writeLocale(t, dir, "ja.json", `{"TOOL_A_DESCRIPTION": "ツール A"}`)
+	writeLocale(t, dir, "README.md", "not a locale")
+
+	locales, err := LoadLocales(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ja", "pt-BR"}, LocaleNames(locales))
+	assert.Equal(t, map[string]string{"TOOL_A_DESCRIPTION": "Ferramenta A"}, locales["pt-br"].Translations)
+	assert.Equal(t, filepath.Join(dir, "pt_br.json"), locales["pt-br"].Path)
+
+	// A missing directory has no locales
+	locales, err = LoadLocales(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, locales)
+
+	writeLocale(t, dir, "broken.json", `{"TOOL_A_DESCRIPTION": 1}`)
+	_, err = LoadLocales(dir)
+	assert.ErrorContains(t, err, "failed to parse locale file")
+}
+
+func Test_BundledLocales(t *testing.T) {
+	locales, err := BundledLocales()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ja", "pt-BR"}, LocaleNames(locales))
+	assert.Equal(t, filepath.Join("locales", "ja.json"), locales["ja"].Path)
+	assert.NotEmpty(t, locales["ja"].Translations)
+}
+
+func Test_OverlayLocales(t *testing.T) {
+	base := map[string]*Locale{
+		"ja": {Name: "ja", Path: "locales/ja.json", Translations: map[string]string{"TOOL_A_DESCRIPTION": "ツール A", "TOOL_B_DESCRIPTION": "ツール B"}},
+	}
+	overrides := map[string]*Locale{
+		"ja": {Name: "ja", Path: "custom/ja.json", Translations: map[string]string{"TOOL_B_DESCRIPTION": "別のツール B"}},
+		"de": {Name: "de", Path: "custom/de.json", Translations: map[string]string{"TOOL_A_DESCRIPTION": "Werkzeug A"}},
+	}
+
+	locales := OverlayLocales(base, overrides)
+	assert.Equal(t, []string{"de", "ja"}, LocaleNames(locales))
+	assert.Equal(t, map[string]string{"TOOL_A_DESCRIPTION": "ツール A", "TOOL_B_DESCRIPTION": "別のツール B"}, locales["ja"].Translations)
+	assert.Equal(t, "custom/ja.json", locales["ja"].Path)
+	// The base locales are left alone
+	assert.Equal(t, "ツール B", base["ja"].Translations["TOOL_B_DESCRIPTION"])
+}
+
+func Test_NewTranslationHelper(t *testing.T) {
+	dir := t.TempDir()
+	writeLocale(t, dir, "pt.json", `{"TOOL_A_DESCRIPTION": "Ferramenta A", "TOOL_B_DESCRIPTION": "Ferramenta B", "TOOL_C_DESCRIPTION": "Ferramenta C"}`)
+	writeLocale(t, dir, "pt-BR.json", `{"TOOL_A_DESCRIPTION": "Ferramenta A do Brasil"}`)
+
+	t.Setenv("GITHUB_MCP_TOOL_C_DESCRIPTION", "from env")
+
+	translate, _, err := NewTranslationHelper(Options{LocalesDir: dir, Locales: []string{"pt-BR"}})
+	require.NoError(t, err)
+
+	// The most specific locale wins, then its parent, then the default value
+	assert.Equal(t, "Ferramenta A do Brasil", translate("TOOL_A_DESCRIPTION", "Tool A"))
+	assert.Equal(t, "Ferramenta B", translate("TOOL_B_DESCRIPTION", "Tool B"))
+	assert.Equal(t, "Tool D", translate("TOOL_D_DESCRIPTION", "Tool D"))
+	// Environment variables override locale files
+	assert.Equal(t, "from env", translate("TOOL_C_DESCRIPTION", "Tool C"))
+
+	// Without a locale, locale files are not used
+	translate, _, err = NewTranslationHelper(Options{LocalesDir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, "Tool A", translate("TOOL_A_DESCRIPTION", "Tool A"))
+
+	// Unknown locales fall back to the default values
+	translate, _, err = NewTranslationHelper(Options{LocalesDir: dir, Locales: []string{"de"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Tool A", translate("TOOL_A_DESCRIPTION", "Tool A"))
+
+	// The bundled locales are used without a directory, and a directory overrides their keys
+	bundled, err := BundledLocales()
+	require.NoError(t, err)
+	jaTitle := bundled["ja"].Translations["TOOL_GET_ME_USER_TITLE"]
+	require.NotEmpty(t, jaTitle)
+
+	translate, _, err = NewTranslationHelper(Options{Locales: []string{"ja"}})
+	require.NoError(t, err)
+	assert.Equal(t, jaTitle, translate("TOOL_GET_ME_USER_TITLE", "Get my user profile"))
+
+	writeLocale(t, dir, "ja.json", `{"TOOL_GET_ME_DESCRIPTION": "自分のプロフィール"}`)
+	translate, _, err = NewTranslationHelper(Options{LocalesDir: dir, Locales: []string{"ja"}})
+	require.NoError(t, err)
+	assert.Equal(t, "自分のプロフィール", translate("TOOL_GET_ME_DESCRIPTION", "Get details of the authenticated user"))
+	assert.Equal(t, jaTitle, translate("TOOL_GET_ME_USER_TITLE", "Get my user profile"))
+}
@@ -2,39 +2,115 @@ package translations
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"slices"
 	"strings"
+	"sync"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
 
+// DefaultConfigPath is the config file holding translation overrides unless another one is configured
+const DefaultConfigPath = "github-mcp-server-config.json"
+
 type TranslationHelperFunc func(key string, defaultValue string) string
 
 func NullTranslationHelper(_ string, defaultValue string) string {
 	return defaultValue
 }
 
+// Options configure the translation helper created by NewTranslationHelper
+type Options struct {
+	// ConfigPath is the JSON file holding translation overrides, defaults to DefaultConfigPath
+	ConfigPath string
+	// OutputPath is the file translations are exported to, defaults to ConfigPath so that overrides are preserved
+	OutputPath string
+	// LocalesDir is an optional directory of locale files such as ja.json, whose keys override those of the
+	// bundled locales
+	LocalesDir string
+	// Locales are the requested locales in order of preference, see LocaleFallbackChain
+	Locales []string
+	// Logger reports problems that do not prevent translating, defaults to the standard logrus logger
+	Logger *logrus.Logger
+}
+
 func TranslationHelper() (TranslationHelperFunc, func()) {
+	// Without locales, no locale files are read, so there is nothing to fail
+	t, dump, _ := NewTranslationHelper(Options{})
+	return t, func() {
+		if err := dump(); err != nil {
+			log.Fatalf("Could not dump translation key map: %v", err)
+		}
+	}
+}
+
+// NewTranslationHelper creates a translation helper and a function exporting every translation requested so far
+// to the output path, which fails if locales are requested. Values are looked up, in order, in the GITHUB_MCP_* environment variables, in the config
+// file, in the locale files of the requested locales and finally fall back to the default value given by the caller.
+// Both functions are safe for concurrent use.
+func NewTranslationHelper(opts Options) (TranslationHelperFunc, func() error, error) {
+	configPath := opts.ConfigPath
+	if configPath == "" {
+		configPath = DefaultConfigPath
+	}
+	outputPath := opts.OutputPath
+	if outputPath == "" {
+		outputPath = configPath
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = logrus.StandardLogger()
+	}
+
+	// mu guards translationKeyMap as well as v, as viper is not safe for concurrent use
+	var mu sync.Mutex
 	var translationKeyMap = map[string]string{}
 	v := viper.New()
 
 	// Load from JSON file
-	v.SetConfigName("github-mcp-server-config")
+	v.SetConfigFile(configPath)
 	v.SetConfigType("json")
-	v.AddConfigPath(".")
 
 	if err := v.ReadInConfig(); err != nil {
 		// ignore error if file not found as it is not required
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			log.Printf("Could not read JSON config: %v", err)
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("Could not read JSON config: %v", err)
+		}
+	}
+
+	chain := LocaleFallbackChain(opts.Locales)
+	locales := map[string]*Locale{}
+	if len(chain) > 0 {
+		var err error
+		locales, err = BundledLocales()
+		if err != nil {
+			return nil, nil, err
+		}
+		if opts.LocalesDir != "" {
+			overrides, err := LoadLocales(opts.LocalesDir)
+			if err != nil {
+				return nil, nil, err
+			}
+			locales = OverlayLocales(locales, overrides)
+		}
+		if !slices.ContainsFunc(chain, func(name string) bool { return locales[strings.ToLower(name)] != nil }) {
+			logger.Warnf("No locale file found for %s, using default translations", strings.Join(chain, ", "))
 		}
 	}
 
 	// create a function that takes both a key, and a default value and returns either the default value or an override value
 	return func(key string, defaultValue string) string {
 			key = strings.ToUpper(key)
+
+			mu.Lock()
+			defer mu.Unlock()
+
 			if value, exists := translationKeyMap[key]; exists {
 				return value
 			}
@@ -45,20 +121,37 @@ func TranslationHelper() (TranslationHelperFunc, func()) {
 				return value
 			}
 
+			// the locale translation is the default, so the config file still overrides it
+			if value, exists := localeLookup(locales, chain, key); exists {
+				defaultValue = value
+			}
+
 			v.SetDefault(key, defaultValue)
 			translationKeyMap[key] = v.GetString(key)
 			return translationKeyMap[key]
-		}, func() {
-			// dump the translationKeyMap to a json file
-			if err := DumpTranslationKeyMap(translationKeyMap); err != nil {
-				log.Fatalf("Could not dump translation key map: %v", err)
+		}, func() error {
+			// The config file overrides locale files, so exported locale text would replace every other locale
+			if len(chain) > 0 {
+				return fmt.Errorf("cannot export translations with the locale %s selected, as the exported text would override every locale", strings.Join(opts.Locales, ","))
 			}
-		}
+
+			// dump a snapshot of the translationKeyMap, so the helper is not blocked while writing
+			mu.Lock()
+			snapshot := maps.Clone(translationKeyMap)
+			mu.Unlock()
+
+			return WriteTranslationKeyMap(outputPath, snapshot)
+		}, nil
 }
 
 // DumpTranslationKeyMap writes the translation map to a json file called github-mcp-server-config.json
 func DumpTranslationKeyMap(translationKeyMap map[string]string) error {
-	file, err := os.Create("github-mcp-server-config.json")
+	return WriteTranslationKeyMap(DefaultConfigPath, translationKeyMap)
+}
+
+// WriteTranslationKeyMap writes the translation map to the json file at path
+func WriteTranslationKeyMap(path string, translationKeyMap map[string]string) error {
+	file, err := os.Create(path) //#nosec G304 - path is configured by the caller
 	if err != nil {
 		return fmt.Errorf("error creating file: %v", err)
 	}
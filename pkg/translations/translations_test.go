@@ -0,0 +1,84 @@
+package translations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewTranslationHelper_ConfigAndOutputPath(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	outputPath := filepath.Join(dir, "output.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"TOOL_A_DESCRIPTION": "overridden"}`), 0600))
+
+	translate, dump, err := NewTranslationHelper(Options{ConfigPath: configPath, OutputPath: outputPath})
+	require.NoError(t, err)
+	assert.Equal(t, "overridden", translate("TOOL_A_DESCRIPTION", "Tool A"))
+	assert.Equal(t, "Tool B", translate("tool_b_description", "Tool B"))
+
+	require.NoError(t, dump())
+
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	var exported map[string]string
+	require.NoError(t, json.Unmarshal(data, &exported))
+	assert.Equal(t, map[string]string{"TOOL_A_DESCRIPTION": "overridden", "TOOL_B_DESCRIPTION": "Tool B"}, exported)
+
+	// The config file is left untouched when exporting elsewhere
+	data, err = os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"TOOL_A_DESCRIPTION": "overridden"}`, string(data))
+}
+
+func Test_NewTranslationHelper_MissingConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+
+	translate, dump, err := NewTranslationHelper(Options{ConfigPath: configPath})
+	require.NoError(t, err)
+	assert.Equal(t, "Tool A", translate("TOOL_A_DESCRIPTION", "Tool A"))
+
+	// Without an output path, the config file is created
+	require.NoError(t, dump())
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"TOOL_A_DESCRIPTION": "Tool A"}`, string(data))
+}
+
+func Test_NewTranslationHelper_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	translate, dump, err := NewTranslationHelper(Options{
+		ConfigPath: filepath.Join(dir, "config.json"),
+		OutputPath: filepath.Join(dir, "output.json"),
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				key := fmt.Sprintf("TOOL_%d_DESCRIPTION", j)
+				assert.Equal(t, fmt.Sprintf("Tool %d", j), translate(key, fmt.Sprintf("Tool %d", j)))
+				if j%10 == 0 {
+					assert.NoError(t, dump())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, dump())
+	data, err := os.ReadFile(filepath.Join(dir, "output.json"))
+	require.NoError(t, err)
+	var exported map[string]string
+	require.NoError(t, json.Unmarshal(data, &exported))
+	assert.Len(t, exported, 50)
+}
@@ -0,0 +1,556 @@
+// Package workspace extracts repository archives into temporary directories, so their files can be read
+// locally without further API calls.
+package workspace
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits cap the archives that are extracted into workspaces.
+type Limits struct {
+	// MaxArchiveSize is the largest archive, in bytes, that is downloaded
+	MaxArchiveSize int64
+	// MaxTotalSize is the largest total size, in bytes, of the extracted files
+	MaxTotalSize int64
+	// MaxFileSize is the largest file, in bytes, that is extracted, larger files are listed but skipped
+	MaxFileSize int64
+	// MaxFiles is the largest number of files and directories in an archive
+	MaxFiles int
+}
+
+// DefaultLimits are the limits used unless others are configured.
+var DefaultLimits = Limits{
+	MaxArchiveSize: 100 * 1024 * 1024,
+	MaxTotalSize:   250 * 1024 * 1024,
+	MaxFileSize:    10 * 1024 * 1024,
+	MaxFiles:       20000,
+}
+
+const (
+	// DefaultMaxWorkspaces is the number of workspaces kept before the least recently used one is removed
+	DefaultMaxWorkspaces = 5
+	// DefaultTTL is how long a workspace is kept after it was last used
+	DefaultTTL = time.Hour
+	// DefaultExpiryInterval is how often Run removes the expired workspaces
+	DefaultExpiryInterval = 5 * time.Minute
+)
+
+// ErrNotFound is returned for unknown or expired workspace handles.
+var ErrNotFound = errors.New("workspace not found, it may have expired")
+
+// Entry is a file, directory or symbolic link of a workspace.
+type Entry struct {
+	Path string `json:"path"`
+	// Type is one of file, dir or symlink
+	Type string `json:"type"`
+	Size int64  `json:"size,omitempty"`
+	// Target is the target of a symbolic link, which is not extracted
+	Target string `json:"target,omitempty"`
+	// Skipped is set for files that were not extracted because they exceed the file size limit
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+// Source describes where the archive of a workspace came from.
+type Source struct {
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Ref    string `json:"ref,omitempty"`
+	Format string `json:"format"`
+}
+
+// Workspace is an extracted archive.
+type Workspace struct {
+	Handle  string
+	Source  Source
+	Entries []Entry
+	// Size is the total size of the extracted files in bytes
+	Size int64
+
+	root     string
+	session  string
+	lastUsed time.Time
+}
+
+// Manager keeps the workspaces of a server in a temporary directory. Workspaces are removed once they were
+// not used for the TTL, when more than MaxWorkspaces exist, or when the session they belong to ends. The
+// owner of a manager must Close it to remove the directory. Manager is safe for concurrent use.
+type Manager struct {
+	Limits        Limits
+	MaxWorkspaces int
+	TTL           time.Duration
+
+	mu         sync.Mutex
+	dir        string
+	workspaces map[string]*Workspace
+	now        func() time.Time
+}
+
+// NewManager creates a manager with the given limits, which creates its temporary directory when first used.
+func NewManager(limits Limits) *Manager {
+	return &Manager{
+		Limits:        limits,
+		MaxWorkspaces: DefaultMaxWorkspaces,
+		TTL:           DefaultTTL,
+		workspaces:    map[string]*Workspace{},
+		now:           time.Now,
+	}
+}
+
+// Extract extracts an archive of the given format, tarball or zipball, into a new workspace of a client
+// session. The archives of GitHub hold a single top level directory, which is stripped from the paths.
+func (m *Manager) Extract(session string, r io.Reader, source Source) (*Workspace, error) {
+	dir, err := m.tempDir()
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := newHandle()
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Join(dir, handle)
+	if err := os.Mkdir(root, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	// The archive is usually streamed from the network, so it is extracted without holding the lock
+	ws := &Workspace{Handle: handle, Source: source, root: root, session: session}
+	extractor := &extractor{ws: ws, limits: m.Limits, dirs: map[string]bool{}}
+	switch source.Format {
+	case "tarball":
+		err = extractor.tarball(r)
+	case "zipball":
+		err = extractor.zipball(r, dir)
+	default:
+		err = fmt.Errorf("unsupported archive format: %s", source.Format)
+	}
+	if err != nil {
+		_ = os.RemoveAll(root)
+		return nil, err
+	}
+	sort.Slice(ws.Entries, func(i, j int) bool { return ws.Entries[i].Path < ws.Entries[j].Path })
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ws.lastUsed = m.now()
+	m.workspaces[handle] = ws
+	m.expire()
+	m.evict()
+	return ws, nil
+}
+
+// tempDir returns the directory holding the workspaces, creating it when first used
+func (m *Manager) tempDir() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.dir == "" {
+		dir, err := os.MkdirTemp("", "github-mcp-server-workspaces-")
+		if err != nil {
+			return "", fmt.Errorf("failed to create workspaces directory: %w", err)
+		}
+		m.dir = dir
+	}
+	return m.dir, nil
+}
+
+// Get returns the workspace of a handle, which counts as using it. Workspaces of other sessions are not found.
+func (m *Manager) Get(session, handle string) (*Workspace, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.expire()
+	ws, ok := m.workspaces[handle]
+	if !ok || ws.session != session {
+		return nil, ErrNotFound
+	}
+	ws.lastUsed = m.now()
+	return ws, nil
+}
+
+// RemoveSession removes the workspaces of a client session, once it ended.
+func (m *Manager) RemoveSession(session string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for handle, ws := range m.workspaces {
+		if ws.session == session {
+			m.remove(handle)
+		}
+	}
+}
+
+// Run removes the expired workspaces every interval, so idle workspaces do not wait for the next Extract
+// or Get to be removed. It returns once ctx is done.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.mu.Lock()
+			m.expire()
+			m.mu.Unlock()
+		}
+	}
+}
+
+// Close removes every workspace along with the temporary directory.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.workspaces = map[string]*Workspace{}
+	if m.dir == "" {
+		return nil
+	}
+	dir := m.dir
+	m.dir = ""
+	return os.RemoveAll(dir)
+}
+
+// expire removes the workspaces that were not used for the TTL, m.mu must be held
+func (m *Manager) expire() {
+	for handle, ws := range m.workspaces {
+		if m.now().Sub(ws.lastUsed) > m.TTL {
+			m.remove(handle)
+		}
+	}
+}
+
+// evict removes the least recently used workspaces beyond MaxWorkspaces, m.mu must be held
+func (m *Manager) evict() {
+	for len(m.workspaces) > m.MaxWorkspaces {
+		var oldest *Workspace
+		for _, ws := range m.workspaces {
+			if oldest == nil || ws.lastUsed.Before(oldest.lastUsed) {
+				oldest = ws
+			}
+		}
+		m.remove(oldest.Handle)
+	}
+}
+
+func (m *Manager) remove(handle string) {
+	if ws, ok := m.workspaces[handle]; ok {
+		_ = os.RemoveAll(ws.root)
+		delete(m.workspaces, handle)
+	}
+}
+
+func newHandle() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to create workspace handle: %w", err)
+	}
+	return "ws_" + hex.EncodeToString(b), nil
+}
+
+// ReadFile reads a file of the workspace, by its path in the repository.
+func (w *Workspace) ReadFile(filePath string) ([]byte, error) {
+	name, err := localPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+	entry := w.entry(name)
+	switch {
+	case entry == nil:
+		return nil, fmt.Errorf("file not found in workspace: %s", filePath)
+	case entry.Type != "file":
+		return nil, fmt.Errorf("not a file: %s is a %s", filePath, entry.Type)
+	case entry.Skipped:
+		return nil, fmt.Errorf("file %s was not extracted, as its %d bytes exceed the file size limit", filePath, entry.Size)
+	}
+
+	data, err := os.ReadFile(filepath.Join(w.root, filepath.FromSlash(name)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+// List returns the entries directly inside a directory of the workspace, the root directory if dirPath is empty.
+func (w *Workspace) List(dirPath string) ([]Entry, error) {
+	prefix := ""
+	if strings.Trim(dirPath, "/") != "" {
+		name, err := localPath(dirPath)
+		if err != nil {
+			return nil, err
+		}
+		entry := w.entry(name)
+		switch {
+		case entry == nil:
+			return nil, fmt.Errorf("directory not found in workspace: %s", dirPath)
+		case entry.Type != "dir":
+			return nil, fmt.Errorf("not a directory: %s is a %s", dirPath, entry.Type)
+		}
+		prefix = name + "/"
+	}
+
+	entries := []Entry{}
+	for _, entry := range w.Entries {
+		rest, ok := strings.CutPrefix(entry.Path, prefix)
+		if ok && rest != "" && !strings.Contains(rest, "/") {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// Entry returns the entry of a path of the workspace.
+func (w *Workspace) Entry(entryPath string) (Entry, bool) {
+	name, err := localPath(entryPath)
+	if err != nil {
+		return Entry{}, false
+	}
+	if entry := w.entry(name); entry != nil {
+		return *entry, true
+	}
+	return Entry{}, false
+}
+
+func (w *Workspace) entry(name string) *Entry {
+	i := sort.Search(len(w.Entries), func(i int) bool { return w.Entries[i].Path >= name })
+	if i < len(w.Entries) && w.Entries[i].Path == name {
+		return &w.Entries[i]
+	}
+	return nil
+}
+
+// localPath cleans a path of a repository file and rejects paths outside the repository
+func localPath(name string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(name, "/"))
+	if cleaned == "." || !filepath.IsLocal(filepath.FromSlash(cleaned)) {
+		return "", fmt.Errorf("invalid path: %s", name)
+	}
+	return cleaned, nil
+}
+
+// extractor extracts the entries of an archive into a workspace, enforcing the limits
+type extractor struct {
+	ws     *Workspace
+	limits Limits
+	// dirs holds the directories already recorded as entries
+	dirs map[string]bool
+}
+
+// add records an entry and returns the path to write it to, or an empty path if it is not written
+func (e *extractor) add(name, entryType string, size int64, target string) (string, error) {
+	// Strip the top level directory, named after the repository and commit
+	_, name, _ = strings.Cut(strings.TrimPrefix(name, "/"), "/")
+	name = strings.TrimSuffix(name, "/")
+	if name == "" {
+		return "", nil
+	}
+	name, err := localPath(name)
+	if err != nil {
+		return "", fmt.Errorf("archive holds an invalid path: %w", err)
+	}
+
+	// Archives may omit the entries of directories, which are then recorded for their first child
+	for i := range name {
+		if name[i] == '/' && !e.dirs[name[:i]] {
+			if err := e.record(Entry{Path: name[:i], Type: "dir"}); err != nil {
+				return "", err
+			}
+		}
+	}
+	if entryType == "dir" && e.dirs[name] {
+		return filepath.Join(e.ws.root, filepath.FromSlash(name)), nil
+	}
+
+	entry := Entry{Path: name, Type: entryType, Size: size, Target: target}
+	if entryType == "file" {
+		if size > e.limits.MaxFileSize {
+			entry.Skipped = true
+		} else {
+			e.ws.Size += size
+			if e.ws.Size > e.limits.MaxTotalSize {
+				return "", fmt.Errorf("archive holds more than %d bytes of files", e.limits.MaxTotalSize)
+			}
+		}
+	}
+	if err := e.record(entry); err != nil {
+		return "", err
+	}
+
+	if entryType == "symlink" || entry.Skipped {
+		return "", nil
+	}
+	return filepath.Join(e.ws.root, filepath.FromSlash(name)), nil
+}
+
+// record appends an entry, within the limit of the number of entries
+func (e *extractor) record(entry Entry) error {
+	if len(e.ws.Entries) >= e.limits.MaxFiles {
+		return fmt.Errorf("archive holds more than %d files", e.limits.MaxFiles)
+	}
+	e.ws.Entries = append(e.ws.Entries, entry)
+	if entry.Type == "dir" {
+		e.dirs[entry.Path] = true
+	}
+	return nil
+}
+
+// write writes a file of exactly size bytes, as the size in archive headers is not trusted
+func (e *extractor) write(target string, r io.Reader, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	n, err := io.Copy(file, io.LimitReader(r, size+1))
+	if err != nil {
+		return fmt.Errorf("failed to extract file: %w", err)
+	}
+	if n != size {
+		return fmt.Errorf("failed to extract file: expected %d bytes, got %d", size, n)
+	}
+	return nil
+}
+
+func (e *extractor) tarball(r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to read tarball: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tarball: %w", err)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			target, err := e.add(header.Name, "dir", 0, "")
+			if err != nil {
+				return err
+			}
+			if target != "" {
+				if err := os.MkdirAll(target, 0o700); err != nil {
+					return fmt.Errorf("failed to create directory: %w", err)
+				}
+			}
+		case tar.TypeReg:
+			target, err := e.add(header.Name, "file", header.Size, "")
+			if err != nil {
+				return err
+			}
+			if target != "" {
+				if err := e.write(target, tr, header.Size); err != nil {
+					return err
+				}
+			}
+		case tar.TypeSymlink:
+			if _, err := e.add(header.Name, "symlink", 0, header.Linkname); err != nil {
+				return err
+			}
+		}
+		// Other entries, such as the global header holding the commit, are not part of the repository
+	}
+}
+
+func (e *extractor) zipball(r io.Reader, tempDir string) error {
+	// Zip archives are read from their end, so the archive is stored first
+	file, err := os.CreateTemp(tempDir, "zipball-")
+	if err != nil {
+		return fmt.Errorf("failed to store zipball: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+	size, err := io.Copy(file, r)
+	if err != nil {
+		return fmt.Errorf("failed to store zipball: %w", err)
+	}
+
+	zr, err := zip.NewReader(file, size)
+	if err != nil {
+		return fmt.Errorf("failed to read zipball: %w", err)
+	}
+	for _, f := range zr.File {
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			target, err := e.add(f.Name, "dir", 0, "")
+			if err != nil {
+				return err
+			}
+			if target != "" {
+				if err := os.MkdirAll(target, 0o700); err != nil {
+					return fmt.Errorf("failed to create directory: %w", err)
+				}
+			}
+		case mode&os.ModeSymlink != 0:
+			link, err := readZipFile(f, 4096)
+			if err != nil {
+				return err
+			}
+			if _, err := e.add(f.Name, "symlink", 0, string(link)); err != nil {
+				return err
+			}
+		case mode.IsRegular():
+			target, err := e.add(f.Name, "file", int64(f.UncompressedSize64), "")
+			if err != nil {
+				return err
+			}
+			if target == "" {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return fmt.Errorf("failed to read zipball: %w", err)
+			}
+			err = e.write(target, rc, int64(f.UncompressedSize64))
+			_ = rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readZipFile(f *zip.File, maxSize int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read zipball: %w", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(rc, maxSize)); err != nil {
+		return nil, fmt.Errorf("failed to read zipball: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,349 @@
+package workspace
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type archiveEntry struct {
+	name    string
+	content string
+	link    string
+}
+
+func tarball(t *testing.T, entries ...archiveEntry) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	// GitHub tarballs start with a global header holding the commit
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeXGlobalHeader, Name: "pax_global_header", PAXRecords: map[string]string{"comment": "abc123"}}))
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Mode: 0o644, Typeflag: tar.TypeReg, Size: int64(len(e.content))}
+		switch {
+		case e.link != "":
+			header.Typeflag, header.Linkname, header.Size = tar.TypeSymlink, e.link, 0
+		case e.name[len(e.name)-1] == '/':
+			header.Typeflag, header.Mode, header.Size = tar.TypeDir, 0o755, 0
+		}
+		require.NoError(t, tw.WriteHeader(header))
+		if header.Typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte(e.content))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return &buf
+}
+
+func zipball(t *testing.T, entries ...archiveEntry) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		header := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		switch {
+		case e.link != "":
+			header.SetMode(os.ModeSymlink | 0o777)
+			e.content = e.link
+		case e.name[len(e.name)-1] == '/':
+			header.SetMode(os.ModeDir | 0o755)
+		default:
+			header.SetMode(0o644)
+		}
+		w, err := zw.CreateHeader(header)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(e.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return &buf
+}
+
+var repoEntries = []archiveEntry{
+	{name: "octocat-hello-abc123/"},
+	{name: "octocat-hello-abc123/README.md", content: "# Hello\n"},
+	{name: "octocat-hello-abc123/src/"},
+	{name: "octocat-hello-abc123/src/main.go", content: "package main\n"},
+	{name: "octocat-hello-abc123/docs", link: "src"},
+	{name: "octocat-hello-abc123/big.bin", content: "0123456789abcdef"},
+}
+
+func testLimits() Limits {
+	return Limits{MaxArchiveSize: 1 << 20, MaxTotalSize: 1 << 20, MaxFileSize: 15, MaxFiles: 100}
+}
+
+func TestManager_Extract(t *testing.T) {
+	for _, format := range []string{"tarball", "zipball"} {
+		t.Run(format, func(t *testing.T) {
+			m := NewManager(testLimits())
+			t.Cleanup(func() { _ = m.Close() })
+
+			archive := tarball(t, repoEntries...)
+			if format == "zipball" {
+				archive = zipball(t, repoEntries...)
+			}
+
+			source := Source{Owner: "octocat", Repo: "hello", Ref: "main", Format: format}
+			ws, err := m.Extract("session", archive, source)
+			require.NoError(t, err)
+
+			assert.Regexp(t, `^ws_[0-9a-f]{16}$`, ws.Handle)
+			assert.Equal(t, source, ws.Source)
+			assert.Equal(t, []Entry{
+				{Path: "README.md", Type: "file", Size: 8},
+				{Path: "big.bin", Type: "file", Size: 16, Skipped: true},
+				{Path: "docs", Type: "symlink", Target: "src"},
+				{Path: "src", Type: "dir"},
+				{Path: "src/main.go", Type: "file", Size: 13},
+			}, ws.Entries)
+			assert.Equal(t, int64(21), ws.Size)
+
+			data, err := ws.ReadFile("src/main.go")
+			require.NoError(t, err)
+			assert.Equal(t, "package main\n", string(data))
+
+			data, err = ws.ReadFile("/README.md")
+			require.NoError(t, err)
+			assert.Equal(t, "# Hello\n", string(data))
+
+			_, err = ws.ReadFile("big.bin")
+			assert.EqualError(t, err, "file big.bin was not extracted, as its 16 bytes exceed the file size limit")
+			_, err = ws.ReadFile("src")
+			assert.EqualError(t, err, "not a file: src is a dir")
+			_, err = ws.ReadFile("docs/main.go")
+			assert.EqualError(t, err, "file not found in workspace: docs/main.go")
+			_, err = ws.ReadFile("../../etc/passwd")
+			assert.EqualError(t, err, "invalid path: ../../etc/passwd")
+		})
+	}
+}
+
+func TestManager_ExtractLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		limits      func(*Limits)
+		entries     []archiveEntry
+		expectError string
+	}{
+		{
+			name:        "path outside the repository",
+			entries:     []archiveEntry{{name: "root/../../evil.sh", content: "boom"}},
+			expectError: "archive holds an invalid path: invalid path: ../../evil.sh",
+		},
+		{
+			name:        "too many files",
+			limits:      func(l *Limits) { l.MaxFiles = 2 },
+			entries:     repoEntries,
+			expectError: "archive holds more than 2 files",
+		},
+		{
+			name:        "too large in total",
+			limits:      func(l *Limits) { l.MaxTotalSize = 10 },
+			entries:     repoEntries,
+			expectError: "archive holds more than 10 bytes of files",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			limits := testLimits()
+			if tc.limits != nil {
+				tc.limits(&limits)
+			}
+			m := NewManager(limits)
+			t.Cleanup(func() { _ = m.Close() })
+
+			_, err := m.Extract("session", tarball(t, tc.entries...), Source{Owner: "octocat", Repo: "hello", Format: "tarball"})
+			assert.EqualError(t, err, tc.expectError)
+
+			// Nothing is left behind of a failed extraction
+			entries, err := os.ReadDir(m.dir)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestManager_ExpiryAndEviction(t *testing.T) {
+	m := NewManager(testLimits())
+	m.MaxWorkspaces = 2
+	t.Cleanup(func() { _ = m.Close() })
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	extract := func() *Workspace {
+		t.Helper()
+		ws, err := m.Extract("session", tarball(t, repoEntries...), Source{Owner: "octocat", Repo: "hello", Format: "tarball"})
+		require.NoError(t, err)
+		now = now.Add(time.Minute)
+		return ws
+	}
+
+	first, second := extract(), extract()
+
+	// Using the first workspace makes the second one the least recently used
+	_, err := m.Get("session", first.Handle)
+	require.NoError(t, err)
+	now = now.Add(time.Minute)
+
+	third := extract()
+	_, err = m.Get("session", second.Handle)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoDirExists(t, second.root)
+	_, err = m.Get("session", first.Handle)
+	require.NoError(t, err)
+
+	// Workspaces expire once unused for the TTL
+	now = now.Add(m.TTL + time.Second)
+	_, err = m.Get("session", third.Handle)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoDirExists(t, third.root)
+
+	require.NoError(t, m.Close())
+	assert.NoDirExists(t, first.root)
+}
+
+func TestManager_Run(t *testing.T) {
+	m := NewManager(testLimits())
+	t.Cleanup(func() { _ = m.Close() })
+
+	var mu sync.Mutex
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	ws, err := m.Extract("session", tarball(t, repoEntries...), Source{Owner: "octocat", Repo: "hello", Format: "tarball"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	// Idle workspaces are removed without further calls to the manager
+	mu.Lock()
+	now = now.Add(m.TTL + time.Second)
+	mu.Unlock()
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(ws.root)
+		return os.IsNotExist(err)
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestManager_Sessions(t *testing.T) {
+	m := NewManager(testLimits())
+	t.Cleanup(func() { _ = m.Close() })
+
+	ws, err := m.Extract("first", tarball(t, repoEntries...), Source{Owner: "octocat", Repo: "hello", Format: "tarball"})
+	require.NoError(t, err)
+	other, err := m.Extract("second", tarball(t, repoEntries...), Source{Owner: "octocat", Repo: "hello", Format: "tarball"})
+	require.NoError(t, err)
+
+	_, err = m.Get("second", ws.Handle)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = m.Get("first", ws.Handle)
+	require.NoError(t, err)
+
+	m.RemoveSession("first")
+	_, err = m.Get("first", ws.Handle)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NoDirExists(t, ws.root)
+	_, err = m.Get("second", other.Handle)
+	require.NoError(t, err)
+}
+
+func TestManager_Close(t *testing.T) {
+	m := NewManager(testLimits())
+	_, err := m.Extract("session", tarball(t, repoEntries...), Source{Owner: "octocat", Repo: "hello", Format: "tarball"})
+	require.NoError(t, err)
+	dir := m.dir
+	require.DirExists(t, dir)
+
+	require.NoError(t, m.Close())
+	assert.NoDirExists(t, dir)
+
+	// A closed manager can still be used, and creates a new directory
+	_, err = m.Extract("session", tarball(t, repoEntries...), Source{Owner: "octocat", Repo: "hello", Format: "tarball"})
+	require.NoError(t, err)
+	assert.NotEqual(t, dir, m.dir)
+	require.NoError(t, m.Close())
+}
+
+func TestWorkspace_List(t *testing.T) {
+	m := NewManager(testLimits())
+	t.Cleanup(func() { _ = m.Close() })
+	ws, err := m.Extract("session", tarball(t, repoEntries...), Source{Owner: "octocat", Repo: "hello", Format: "tarball"})
+	require.NoError(t, err)
+
+	entries, err := ws.List("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "big.bin", "docs", "src"}, entryPaths(entries))
+
+	entries, err = ws.List("src/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"src/main.go"}, entryPaths(entries))
+
+	_, err = ws.List("README.md")
+	assert.EqualError(t, err, "not a directory: README.md is a file")
+	_, err = ws.List("missing")
+	assert.EqualError(t, err, "directory not found in workspace: missing")
+
+	entry, ok := ws.Entry("docs")
+	assert.True(t, ok)
+	assert.Equal(t, Entry{Path: "docs", Type: "symlink", Target: "src"}, entry)
+	_, ok = ws.Entry("missing")
+	assert.False(t, ok)
+}
+
+func entryPaths(entries []Entry) []string {
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	return paths
+}
+
+func TestManager_Extract_ImplicitDirectories(t *testing.T) {
+	m := NewManager(testLimits())
+	t.Cleanup(func() { _ = m.Close() })
+
+	archive := tarball(t,
+		archiveEntry{name: "octocat-hello-abc123/a/b/c.txt", content: "c"},
+		archiveEntry{name: "octocat-hello-abc123/a/"},
+		archiveEntry{name: "octocat-hello-abc123/a/d.txt", content: "d"},
+	)
+	ws, err := m.Extract("session", archive, Source{Owner: "octocat", Repo: "hello", Format: "tarball"})
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Path: "a", Type: "dir"},
+		{Path: "a/b", Type: "dir"},
+		{Path: "a/b/c.txt", Type: "file", Size: 1},
+		{Path: "a/d.txt", Type: "file", Size: 1},
+	}, ws.Entries)
+
+	data, err := ws.ReadFile("a/b/c.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "c", string(data))
+}